@@ -1,8 +1,11 @@
 package app
 
 import (
+	"time"
+
 	"github.com/raihanmd/fp-superbootcamp-go/helper"
 	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -15,15 +18,182 @@ func NewConnection() *gorm.DB {
 	})
 	helper.PanicIfError(err)
 
-	err = db.AutoMigrate(&entity.User{}, &entity.Car{}, &entity.CarSpecification{}, &entity.Brand{}, &entity.Review{}, &entity.Comment{}, &entity.Favourite{}, &entity.Profile{})
-	helper.PanicIfError(err)
+	helper.PanicIfError(Migrate(db))
+
+	return db
+}
+
+// Migrate brings the schema and the data of db up to date, the tests run it
+// on their database too. The statements after AutoMigrate are safe to run
+// again.
+func Migrate(db *gorm.DB) error {
+	err := db.AutoMigrate(&entity.User{}, &entity.Media{}, &entity.MediaVariant{}, &entity.Car{}, &entity.CarSpecification{}, &entity.Brand{}, &entity.Review{}, &entity.ReviewVote{}, &entity.GalleryImage{}, &entity.Revision{}, &entity.Comment{}, &entity.CommentReaction{}, &entity.Report{}, &entity.Mention{}, &entity.Notification{}, &entity.NotificationPreference{}, &entity.FavouriteCollection{}, &entity.Favourite{}, &entity.Profile{}, &entity.UserIdentity{}, &entity.Session{}, &entity.AuditEvent{}, &entity.ImportJob{}, &entity.StreamEvent{}, &entity.Webhook{}, &entity.WebhookDelivery{}, &entity.OutboxJob{}, &entity.Follow{}, &entity.WatchDigest{})
+	if err != nil {
+		return err
+	}
+
+	// replaced by idx_review_car_user, which ignores deleted reviews
+	db.Exec("DROP INDEX IF EXISTS idx_car_id_user_id")
+
+	// replaced by idx_brand_name, which ignores deleted brands
+	for _, constraint := range []string{"brands_name_key", "uni_brands_name"} {
+		if err := db.Exec("ALTER TABLE brands DROP CONSTRAINT IF EXISTS " + constraint).Error; err != nil {
+			return err
+		}
+	}
+
+	// watches became follows with alerts
+	db.Exec(`DO $$ BEGIN
+		IF to_regclass('watches') IS NOT NULL THEN
+			INSERT INTO follows (follower_id, target_type, target_id, alerts, created_at)
+				SELECT user_id, target_type, target_id, true, created_at FROM watches
+				ON CONFLICT (follower_id, target_type, target_id) DO UPDATE SET alerts = true;
+			DROP TABLE watches;
+		END IF;
+	END $$`)
+
+	// a car can be in several collections of a user, replaced by
+	// idx_favourite_item
+	db.Exec("DROP INDEX IF EXISTS idx_favourite")
+
+	// the favourites made before collections move into a default collection
+	db.Exec(`INSERT INTO favourite_collections (user_id, name, slug, visibility, is_default, created_at, updated_at)
+		SELECT u.user_id, ?, 'favourites-' || substr(md5(random()::text), 1, 10), ?, true, now(), now()
+		FROM (SELECT DISTINCT user_id FROM favourites WHERE collection_id IS NULL) u
+		ON CONFLICT (user_id) WHERE is_default DO NOTHING`, "Favourites", entity.CollectionPrivate)
+	db.Exec(`UPDATE favourites f SET collection_id = o.collection_id, position = o.position, created_at = COALESCE(f.created_at, now())
+		FROM (SELECT f.user_id, f.car_id, c.id AS collection_id, ROW_NUMBER() OVER (PARTITION BY f.user_id ORDER BY f.car_id) - 1 AS position
+			FROM favourites f JOIN favourite_collections c ON c.user_id = f.user_id AND c.is_default
+			WHERE f.collection_id IS NULL) o
+		WHERE f.collection_id IS NULL AND f.user_id = o.user_id AND f.car_id = o.car_id`)
 
 	// create full text index on reviews.title
 	db.Exec("CREATE INDEX IF NOT EXISTS idx_title_fulltext ON reviews USING GIN (to_tsvector('english', title))")
 
 	// create index on cars.model
 	db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm;")
-	db.Exec("CREATE INDEX idx_model_gin ON cars USING GIN (model gin_trgm_ops);")
+	db.Exec("CREATE INDEX IF NOT EXISTS idx_model_gin ON cars USING GIN (model gin_trgm_ops);")
 
-	return db
+	// a car is identified by its brand, name, model and year, the car create
+	// and the import rely on it to skip or update existing cars
+	if err := dedupeCars(db); err != nil {
+		return err
+	}
+	if err := db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_car_identity ON cars (brand_id, name, model, year) WHERE deleted_at IS NULL").Error; err != nil {
+		return err
+	}
+
+	// one cover per gallery
+	db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_gallery_cover ON gallery_images (owner_type, owner_id) WHERE is_cover")
+
+	// the single image of cars and reviews created before galleries becomes
+	// their cover, once: an owner keeps its image_url when its gallery is
+	// emptied later
+	err = runOnce(db, "gallery_covers", func(tx *gorm.DB) error {
+		for _, owner := range []string{entity.GalleryOwnerCar, entity.GalleryOwnerReview} {
+			err := tx.Exec(`INSERT INTO gallery_images (owner_type, owner_id, media_id, image_url, caption, position, is_cover, created_at)
+				SELECT ?, o.id, o.media_id, o.image_url, '', 0, true, o.created_at FROM `+owner+` o
+				WHERE o.image_url <> '' AND NOT EXISTS (SELECT 1 FROM gallery_images g WHERE g.owner_type = ? AND g.owner_id = o.id)`, owner, owner).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// webhook deliveries are sent by the outbox, the ones still pending from
+	// the poller get their job
+	db.Exec(`INSERT INTO outbox_jobs (kind, payload, status, attempts, run_at, last_error, created_at, updated_at)
+		SELECT ?, jsonb_build_object('delivery_id', d.id), ?, 0, COALESCE(d.next_attempt_at, now()), '', now(), now()
+		FROM webhook_deliveries d
+		WHERE d.status = ? AND NOT EXISTS (SELECT 1 FROM outbox_jobs j WHERE j.kind = ? AND j.status = ? AND j.payload = jsonb_build_object('delivery_id', d.id))`,
+		services.OutboxWebhookDeliver, entity.OutboxJobPending, entity.WebhookDeliveryPending, services.OutboxWebhookDeliver, entity.OutboxJobPending)
+
+	// keep audit_events append-only
+	db.Exec(`CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_events is append-only';
+		END;
+		$$ LANGUAGE plpgsql`)
+	db.Exec("DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events")
+	db.Exec("CREATE TRIGGER audit_events_append_only BEFORE UPDATE OR DELETE ON audit_events FOR EACH ROW EXECUTE FUNCTION audit_events_append_only()")
+
+	// revisions are immutable, they are only deleted with their owner
+	db.Exec(`CREATE OR REPLACE FUNCTION revisions_immutable() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'revisions are immutable';
+		END;
+		$$ LANGUAGE plpgsql`)
+	db.Exec("DROP TRIGGER IF EXISTS revisions_immutable ON revisions")
+	db.Exec("CREATE TRIGGER revisions_immutable BEFORE UPDATE ON revisions FOR EACH ROW EXECUTE FUNCTION revisions_immutable()")
+
+	return nil
+}
+
+// runOnce runs the data migration named name unless schema_migrations says
+// it already ran, and records it in the same transaction.
+func runOnce(db *gorm.DB, name string, migrate func(tx *gorm.DB) error) error {
+	if err := db.Exec("CREATE TABLE IF NOT EXISTS schema_migrations (name varchar(100) PRIMARY KEY, applied_at timestamptz NOT NULL)").Error; err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec("INSERT INTO schema_migrations (name, applied_at) VALUES (?, now()) ON CONFLICT (name) DO NOTHING", name)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		return migrate(tx)
+	})
+}
+
+// dedupeCars keeps the oldest of the cars sharing a brand, name, model and
+// year, which could be created twice before idx_car_identity. The reviews,
+// favourites and follows of the others move to it, unless their user already
+// has one there, and the others go to the trash with what is left.
+func dedupeCars(db *gorm.DB) error {
+	var duplicates []struct {
+		ID     uint
+		KeepID uint
+	}
+	err := db.Raw(`SELECT id, keep_id FROM (
+			SELECT id, MIN(id) OVER (PARTITION BY brand_id, name, model, year) AS keep_id FROM cars WHERE deleted_at IS NULL
+		) c WHERE id <> keep_id ORDER BY id`).Scan(&duplicates).Error
+	if err != nil || len(duplicates) == 0 {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		for _, duplicate := range duplicates {
+			statements := []struct {
+				sql  string
+				vars []any
+			}{
+				{`UPDATE reviews SET car_id = ? WHERE car_id = ? AND (deleted_at IS NOT NULL
+					OR user_id NOT IN (SELECT user_id FROM reviews WHERE car_id = ? AND deleted_at IS NULL))`, []any{duplicate.KeepID, duplicate.ID, duplicate.KeepID}},
+				{"UPDATE reviews SET deleted_at = ? WHERE car_id = ? AND deleted_at IS NULL", []any{now, duplicate.ID}},
+				{`UPDATE favourites SET car_id = ? WHERE car_id = ?
+					AND collection_id NOT IN (SELECT collection_id FROM favourites WHERE car_id = ?)`, []any{duplicate.KeepID, duplicate.ID, duplicate.KeepID}},
+				{"DELETE FROM favourites WHERE car_id = ?", []any{duplicate.ID}},
+				{`UPDATE follows SET target_id = ? WHERE target_type = ? AND target_id = ?
+					AND follower_id NOT IN (SELECT follower_id FROM follows WHERE target_type = ? AND target_id = ?)`,
+					[]any{duplicate.KeepID, entity.FollowTargetCar, duplicate.ID, entity.FollowTargetCar, duplicate.KeepID}},
+				{"DELETE FROM follows WHERE target_type = ? AND target_id = ?", []any{entity.FollowTargetCar, duplicate.ID}},
+				{"UPDATE cars SET deleted_at = ? WHERE id = ?", []any{now, duplicate.ID}},
+			}
+
+			for _, statement := range statements {
+				if err := tx.Exec(statement.sql, statement.vars...).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
 }
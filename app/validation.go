@@ -0,0 +1,29 @@
+package app
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// RegisterValidations adds the custom binding tags used by the request
+// structs, anything validating them outside a request has to call it first.
+func RegisterValidations() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterValidation("no_space", func(fl validator.FieldLevel) bool {
+			return !strings.Contains(fl.Field().String(), " ")
+		})
+		v.RegisterValidation("lowercase", func(fl validator.FieldLevel) bool {
+			return fl.Field().String() == strings.ToLower(fl.Field().String())
+		})
+		v.RegisterValidation("uppercase", func(fl validator.FieldLevel) bool {
+			return fl.Field().String() == strings.ToUpper(fl.Field().String())
+		})
+		v.RegisterValidation("url", func(fl validator.FieldLevel) bool {
+			_, err := url.ParseRequestURI(fl.Field().String())
+			return err == nil
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package assets
+
+import _ "embed"
+
+// BreachedPasswords is a list of upper-case SHA-1 hashes of known breached
+// passwords, one per line, in the same format as the Have I Been Pwned
+// downloads (an optional ":count" suffix is ignored).
+//
+//go:embed breached_passwords.txt
+var BreachedPasswords []byte
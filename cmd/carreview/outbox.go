@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+// newOutbox has the handlers of every job kind, registered by the services
+// that enqueue them.
+func newOutbox() services.Outbox {
+	outbox := services.NewOutbox()
+	services.NewMediaService(utils.NewBlobStore(), outbox)
+	services.NewWatchService(outbox, utils.NewMailer())
+	services.NewWebhookService(services.NewEventBus(), outbox, services.NewWebhookClient())
+	return outbox
+}
+
+// outboxList prints the counts of each status and the latest jobs.
+func outboxList(args []string) error {
+	flags := flag.NewFlagSet("outbox list", flag.ExitOnError)
+	status := flags.String("status", "", "only jobs with this status: pending, done or dead")
+	kind := flags.String("kind", "", "only jobs of this kind")
+	limit := flags.Int("limit", 20, "how many jobs to list")
+	flags.Parse(args)
+
+	c := newContext()
+	db, _ := helper.GetDBAndLogger(c)
+
+	counts, err := services.CountOutboxJobs(db)
+	if err != nil {
+		return err
+	}
+
+	jobs, err := services.FindOutboxJobs(db, &services.OutboxFilter{Status: *status, Kind: *kind}, *limit)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d pending, %d done, %d dead\n\n", counts[entity.OutboxJobPending], counts[entity.OutboxJobDone], counts[entity.OutboxJobDead])
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tKIND\tSTATUS\tATTEMPTS\tRUN AT\tLAST ERROR")
+	for _, job := range jobs {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\t%s\n", job.ID, job.Kind, job.Status, job.Attempts, job.RunAt.Format(time.RFC3339), truncate(job.LastError, 60))
+	}
+
+	return w.Flush()
+}
+
+// outboxShow prints a job with its payload.
+func outboxShow(args []string) error {
+	flags := flag.NewFlagSet("outbox show", flag.ExitOnError)
+	id := flags.Uint("id", 0, "job ID")
+	flags.Parse(args)
+
+	if *id == 0 {
+		return errors.New("--id is required")
+	}
+
+	c := newContext()
+	db, _ := helper.GetDBAndLogger(c)
+
+	jobs, err := services.FindOutboxJobs(db, &services.OutboxFilter{IDs: []uint{*id}}, 1)
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		return fmt.Errorf("job %d not found", *id)
+	}
+
+	job := jobs[0]
+	out, _ := json.MarshalIndent(map[string]any{
+		"id":           job.ID,
+		"kind":         job.Kind,
+		"status":       job.Status,
+		"attempts":     job.Attempts,
+		"run_at":       job.RunAt,
+		"last_error":   job.LastError,
+		"processed_at": job.ProcessedAt,
+		"created_at":   job.CreatedAt,
+		"payload":      json.RawMessage(job.Payload),
+	}, "", "  ")
+
+	fmt.Println(string(out))
+
+	return nil
+}
+
+// outboxReplay queues jobs again with their attempts reset, either the given
+// IDs or every dead job.
+func outboxReplay(args []string) error {
+	flags := flag.NewFlagSet("outbox replay", flag.ExitOnError)
+	ids := flags.String("id", "", "comma separated job IDs")
+	dead := flags.Bool("dead", false, "replay every dead job")
+	kind := flags.String("kind", "", "only jobs of this kind")
+	flags.Parse(args)
+
+	filter := services.OutboxFilter{Kind: *kind}
+
+	switch {
+	case *ids != "" && *dead:
+		return errors.New("use either --id or --dead")
+	case *ids != "":
+		for _, id := range strings.Split(*ids, ",") {
+			jobID, err := strconv.ParseUint(strings.TrimSpace(id), 10, 32)
+			if err != nil {
+				return fmt.Errorf("--id: %q is not an integer", id)
+			}
+			filter.IDs = append(filter.IDs, uint(jobID))
+		}
+	case *dead:
+		filter.Status = entity.OutboxJobDead
+	default:
+		return errors.New("--id or --dead is required")
+	}
+
+	c := newContext()
+	db, _ := helper.GetDBAndLogger(c)
+
+	replayed, err := services.ReplayOutboxJobs(db, &filter, time.Now())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("replayed %d jobs\n", replayed)
+
+	return nil
+}
+
+// outboxWork dispatches the due jobs every --interval until interrupted, to
+// run the jobs in their own process when OUTBOX_POLL_SECONDS is 0.
+func outboxWork(args []string) error {
+	flags := flag.NewFlagSet("outbox work", flag.ExitOnError)
+	interval := flags.Duration("interval", 2*time.Second, "how often to poll for due jobs")
+	once := flags.Bool("once", false, "dispatch the due jobs and exit")
+	flags.Parse(args)
+
+	c := newContext()
+	db, logger := helper.GetDBAndLogger(c)
+	outbox := newOutbox()
+
+	if *once {
+		dispatched, err := services.DrainOutbox(outbox, db, logger)
+		fmt.Printf("dispatched %d jobs\n", dispatched)
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	services.WorkOutbox(ctx, outbox, db, logger, *interval)
+
+	return nil
+}
+
+func truncate(s string, size int) string {
+	if len(s) <= size {
+		return s
+	}
+	return s[:size-3] + "..."
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+)
+
+// seedBrands reads a JSON array of brands, e.g. [{"name": "Toyota"}], and
+// creates the ones that do not exist yet.
+func seedBrands(args []string) error {
+	flags := flag.NewFlagSet("seed brands", flag.ExitOnError)
+	file := flags.String("file", "", "JSON file with the brands")
+	flags.Parse(args)
+
+	if *file == "" {
+		return errors.New("--file is required")
+	}
+
+	raw, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+
+	var brandReqs []request.BrandRequest
+	if err := json.Unmarshal(raw, &brandReqs); err != nil {
+		return fmt.Errorf("%s: %w", *file, err)
+	}
+
+	for i := range brandReqs {
+		if err := binding.Validator.ValidateStruct(&brandReqs[i]); err != nil {
+			return fmt.Errorf("brand %d: %w", i+1, err)
+		}
+	}
+
+	c := newContext()
+	db, _ := helper.GetDBAndLogger(c)
+	brandService := services.NewBrandService(newEventBus(c))
+
+	created, skipped := 0, 0
+	for _, brandReq := range brandReqs {
+		var count int64
+		db.Model(&entity.Brand{}).Where("LOWER(name) = LOWER(?)", brandReq.Name).Count(&count)
+		if count > 0 {
+			skipped++
+			continue
+		}
+
+		if _, err := brandService.Create(c, &brandReq); err != nil {
+			return fmt.Errorf("brand %q: %s", brandReq.Name, describeError(err))
+		}
+		created++
+	}
+
+	fmt.Printf("brands: %d created, %d already existed\n", created, skipped)
+
+	return nil
+}
+
+// seedCars imports cars from a CSV or NDJSON file through the same import as
+// POST /api/admin/cars/import, so cars are upserted on brand, name, model and
+// year.
+func seedCars(args []string) error {
+	flags := flag.NewFlagSet("seed cars", flag.ExitOnError)
+	file := flags.String("file", "", "CSV or NDJSON file with the cars")
+	createBrands := flags.Bool("create-brands", false, "create brands that do not exist")
+	dryRun := flags.Bool("dry-run", false, "only validate the file")
+	flags.Parse(args)
+
+	if *file == "" {
+		return errors.New("--file is required")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	importReq := request.CarImportRequest{Format: "csv", DryRun: *dryRun, CreateBrands: *createBrands}
+	if ext := strings.ToLower(filepath.Ext(*file)); ext == ".ndjson" || ext == ".jsonl" {
+		importReq.Format = "ndjson"
+	}
+
+	c := newContext()
+	eventBus := newEventBus(c)
+	carImportService := services.NewCarImportService(services.NewCarService(eventBus), services.NewBrandService(eventBus))
+
+	job, err := carImportService.Import(c, &importReq, f)
+	if err != nil {
+		return err
+	}
+
+	for job.Status == entity.ImportJobPending || job.Status == entity.ImportJobRunning {
+		time.Sleep(time.Second)
+
+		if job, err = carImportService.FindJob(c, job.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range job.Rows {
+		for field, message := range row.Errors {
+			fmt.Fprintf(os.Stderr, "line %d: %s: %s\n", row.Row, field, message)
+		}
+	}
+
+	if job.Error != nil {
+		return errors.New(*job.Error)
+	}
+
+	fmt.Printf("cars: %d created, %d updated, %d failed\n", job.Created, job.Updated, job.Failed)
+
+	return nil
+}
+
+// newEventBus has the same subscribers as the API, so that seeded cars and
+// brands reach the webhooks and notify their watchers like the ones changed
+// through the API. The notifications reach the stream clients of the API
+// through the backplane and the webhook deliveries are sent by the outbox.
+func newEventBus(c *gin.Context) services.EventBus {
+	db, logger := helper.GetDBAndLogger(c)
+
+	eventBus := services.NewEventBus()
+	services.NewNotificationService(eventBus)
+	services.NewStreamService(services.NewStreamHub(services.NewStreamBackplane(db, logger)), eventBus)
+	services.NewWebhookService(eventBus, newOutbox(), services.NewWebhookClient())
+	return eventBus
+}
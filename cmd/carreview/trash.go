@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+)
+
+// trashPurge permanently deletes the records that have been in the trash for
+// longer than --days, TRASH_RETENTION_DAYS by default.
+func trashPurge(args []string) error {
+	flags := flag.NewFlagSet("trash purge", flag.ExitOnError)
+	days := flags.Int("days", helper.GetEnvInt("TRASH_RETENTION_DAYS", 30), "purge records deleted more than this many days ago")
+	flags.Parse(args)
+
+	if *days < 0 {
+		return fmt.Errorf("--days must not be negative")
+	}
+
+	c := newContext()
+
+	purged, err := services.NewTrashService().Purge(c, time.Now().AddDate(0, 0, -*days))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("purged %d comments, %d reviews, %d cars and %d brands\n", purged.Comments, purged.Reviews, purged.Cars, purged.Brands)
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+)
+
+func userCreate(args []string) error {
+	flags := flag.NewFlagSet("user create", flag.ExitOnError)
+	username := flags.String("username", "", "username")
+	email := flags.String("email", "", "email")
+	password := flags.String("password", "", "password, read from stdin when empty")
+	admin := flags.Bool("admin", false, "create the user as an admin")
+	flags.Parse(args)
+
+	if *password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return err
+		}
+		*password = strings.TrimRight(line, "\r\n")
+	}
+
+	registerReq := request.RegisterRequest{
+		Username: *username,
+		Email:    *email,
+		Password: *password,
+	}
+
+	if err := binding.Validator.ValidateStruct(&registerReq); err != nil {
+		return err
+	}
+
+	newUser := entity.User{
+		Username: registerReq.Username,
+		Email:    registerReq.Email,
+		Password: registerReq.Password,
+		Role:     entity.RoleUser,
+	}
+
+	if *admin {
+		newUser.Role = entity.RoleAdmin
+	}
+
+	c := newContext()
+	userService := services.NewUserService(services.NewPasswordPolicyService())
+
+	registerResponse, err := userService.Register(c, &newUser)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("created %s %s (%s)\n", registerResponse.Role, registerResponse.Username, registerResponse.Email)
+
+	return nil
+}
+
+func userSetRole(args []string) error {
+	flags := flag.NewFlagSet("user set-role", flag.ExitOnError)
+	username := flags.String("username", "", "username of the user")
+	email := flags.String("email", "", "email of the user")
+	role := flags.String("role", "", "new role, ADMIN, MODERATOR or USER")
+	flags.Parse(args)
+
+	updateRoleReq := request.AdminUpdateRoleRequest{Role: *role}
+
+	if err := binding.Validator.ValidateStruct(&updateRoleReq); err != nil {
+		return err
+	}
+
+	if (*username == "") == (*email == "") {
+		return errors.New("either --username or --email is required")
+	}
+
+	c := newContext()
+	db, _ := helper.GetDBAndLogger(c)
+
+	var user entity.User
+	if err := db.Where("username = ? OR email = ?", *username, *email).Take(&user).Error; err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+
+	updatedUser, err := services.NewAdminUserService().UpdateRole(c, user.ID, &updateRoleReq)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s is now %s\n", updatedUser.Username, updatedUser.Role)
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+)
+
+// watchDigest queues the due watch digests, to run them from a scheduler
+// when WATCH_DIGEST_INTERVAL_MINUTES is 0. The emails are sent by the outbox,
+// --send sends them right away.
+func watchDigest(args []string) error {
+	flags := flag.NewFlagSet("watch digest", flag.ExitOnError)
+	send := flags.Bool("send", false, "run the outbox jobs after queuing the digests")
+	flags.Parse(args)
+
+	c := newContext()
+	db, logger := helper.GetDBAndLogger(c)
+	outbox := newOutbox()
+
+	queued, err := services.SendWatchDigests(db, outbox, logger, time.Now())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("queued %d digests\n", queued)
+
+	if *send {
+		dispatched, err := services.DrainOutbox(outbox, db, logger)
+		fmt.Printf("dispatched %d jobs\n", dispatched)
+		return err
+	}
+
+	return nil
+}
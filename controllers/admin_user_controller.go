@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type AdminUserController interface {
+	FindAll(*gin.Context)
+	FindByID(*gin.Context)
+	UpdateRole(*gin.Context)
+	Ban(*gin.Context)
+	Unban(*gin.Context)
+	ForcePasswordReset(*gin.Context)
+	Impersonate(*gin.Context)
+}
+
+type adminUserControllerImpl struct {
+	services.AdminUserService
+}
+
+func NewAdminUserController(adminUserService services.AdminUserService) AdminUserController {
+	return &adminUserControllerImpl{adminUserService}
+}
+
+// Find all users godoc
+// @Summary Search users.
+// @Description Search and filter users, admin only.
+// @Tags Admin
+// @Param limit query int false "Limit" default(10)
+// @Param page query int false "Page" default(1)
+// @Param search query string false "Search username or email"
+// @Param role query string false "Role" Enums(ADMIN, MODERATOR, USER)
+// @Param verified query bool false "Email verified"
+// @Param banned query bool false "Currently banned"
+// @Param created_from query string false "Created from (RFC3339)"
+// @Param created_to query string false "Created to (RFC3339)"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.AdminUserResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/users [get]
+func (controller *adminUserControllerImpl) FindAll(c *gin.Context) {
+	var pagination web.PaginationRequest
+	var userQueryReq request.AdminUserQueryRequest
+
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		panic(err)
+	}
+
+	if err := c.ShouldBindQuery(&userQueryReq); err != nil {
+		panic(err)
+	}
+
+	if pagination.Limit == 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	utils.UserRoleMustAdmin(c)
+
+	users, metadata, err := controller.AdminUserService.FindAll(c, &userQueryReq, &pagination)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, users, metadata)
+}
+
+// Find user by id godoc
+// @Summary Get a user.
+// @Description Get a user with its account status, admin only.
+// @Tags Admin
+// @Param id path int true "User ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.AdminUserResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/users/{id} [get]
+func (controller *adminUserControllerImpl) FindByID(c *gin.Context) {
+	userID := adminUserIDParam(c)
+
+	utils.UserRoleMustAdmin(c)
+
+	user, err := controller.AdminUserService.FindByID(c, userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, user, nil)
+}
+
+// Update user role godoc
+// @Summary Change a user's role.
+// @Description Promote or demote a user, the user is logged out everywhere. Admin only.
+// @Tags Admin
+// @Param id path int true "User ID"
+// @Param body body request.AdminUpdateRoleRequest true "the body to change the role"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.AdminUserResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/users/{id}/role [patch]
+func (controller *adminUserControllerImpl) UpdateRole(c *gin.Context) {
+	var updateRoleReq request.AdminUpdateRoleRequest
+
+	userID := adminUserIDParam(c)
+
+	if err := c.ShouldBindJSON(&updateRoleReq); err != nil {
+		panic(err)
+	}
+
+	utils.UserRoleMustAdmin(c)
+
+	user, err := controller.AdminUserService.UpdateRole(c, userID, &updateRoleReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, user, nil)
+}
+
+// Ban user godoc
+// @Summary Ban a user.
+// @Description Suspend a user until the given expiry, or permanently without one. Admin only.
+// @Tags Admin
+// @Param id path int true "User ID"
+// @Param body body request.AdminBanUserRequest true "the body to ban the user"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.AdminUserResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/users/{id}/ban [post]
+func (controller *adminUserControllerImpl) Ban(c *gin.Context) {
+	var banReq request.AdminBanUserRequest
+
+	userID := adminUserIDParam(c)
+
+	if err := c.ShouldBindJSON(&banReq); err != nil {
+		panic(err)
+	}
+
+	utils.UserRoleMustAdmin(c)
+
+	user, err := controller.AdminUserService.Ban(c, userID, &banReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, user, nil)
+}
+
+// Unban user godoc
+// @Summary Unban a user.
+// @Description Lift the ban of a user, admin only.
+// @Tags Admin
+// @Param id path int true "User ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.AdminUserResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/users/{id}/ban [delete]
+func (controller *adminUserControllerImpl) Unban(c *gin.Context) {
+	userID := adminUserIDParam(c)
+
+	utils.UserRoleMustAdmin(c)
+
+	user, err := controller.AdminUserService.Unban(c, userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, user, nil)
+}
+
+// Force password reset godoc
+// @Summary Force a password reset.
+// @Description Log the user out everywhere and block logins until the password is reset. Admin only.
+// @Tags Admin
+// @Param id path int true "User ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.AdminUserResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/users/{id}/force-password-reset [post]
+func (controller *adminUserControllerImpl) ForcePasswordReset(c *gin.Context) {
+	userID := adminUserIDParam(c)
+
+	utils.UserRoleMustAdmin(c)
+
+	user, err := controller.AdminUserService.ForcePasswordReset(c, userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, user, nil)
+}
+
+// Impersonate user godoc
+// @Summary Impersonate a user.
+// @Description Get a token acting as the user for support, actions taken with it are audited with the admin as impersonator. Admin only.
+// @Tags Admin
+// @Param id path int true "User ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.LoginResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/users/{id}/impersonate [post]
+func (controller *adminUserControllerImpl) Impersonate(c *gin.Context) {
+	userID := adminUserIDParam(c)
+
+	utils.UserRoleMustAdmin(c)
+
+	loginResponse, err := controller.AdminUserService.Impersonate(c, userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, loginResponse, nil)
+}
+
+func adminUserIDParam(c *gin.Context) uint {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Id must be an integer"))
+	}
+	return uint(userID)
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"go.uber.org/zap"
+)
+
+type AuditController interface {
+	FindAll(*gin.Context)
+	Export(*gin.Context)
+}
+
+type auditControllerImpl struct {
+	services.AuditService
+}
+
+func NewAuditController(auditService services.AuditService) AuditController {
+	return &auditControllerImpl{auditService}
+}
+
+// Find all audit events godoc
+// @Summary Search audit log.
+// @Description Search the security audit log, admin only.
+// @Tags Admin
+// @Param limit query int false "Limit" default(10)
+// @Param page query int false "Page" default(1)
+// @Param actor query int false "Actor user ID"
+// @Param action query string false "Action, e.g. car.update"
+// @Param target_type query string false "Target type, e.g. car"
+// @Param target_id query int false "Target ID"
+// @Param from query string false "From (RFC3339)"
+// @Param to query string false "To (RFC3339)"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.AuditEventResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/audit [get]
+func (controller *auditControllerImpl) FindAll(c *gin.Context) {
+	var pagination web.PaginationRequest
+	var auditQueryReq request.AuditQueryRequest
+
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		panic(err)
+	}
+
+	if err := c.ShouldBindQuery(&auditQueryReq); err != nil {
+		panic(err)
+	}
+
+	if pagination.Limit == 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	utils.UserRoleMustAdmin(c)
+
+	events, metadata, err := controller.AuditService.FindAll(c, &auditQueryReq, &pagination)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, events, metadata)
+}
+
+// Export audit events godoc
+// @Summary Export audit log.
+// @Description Export the matching audit events as newline delimited JSON, admin only.
+// @Tags Admin
+// @Param actor query int false "Actor user ID"
+// @Param action query string false "Action, e.g. car.update"
+// @Param target_type query string false "Target type, e.g. car"
+// @Param target_id query int false "Target ID"
+// @Param from query string false "From (RFC3339)"
+// @Param to query string false "To (RFC3339)"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce application/x-ndjson
+// @Success 200 {object} response.AuditEventResponse
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/audit/export [get]
+func (controller *auditControllerImpl) Export(c *gin.Context) {
+	var auditQueryReq request.AuditQueryRequest
+
+	if err := c.ShouldBindQuery(&auditQueryReq); err != nil {
+		panic(err)
+	}
+
+	utils.UserRoleMustAdmin(c)
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Content-Disposition", `attachment; filename="audit.ndjson"`)
+	c.Header("Trailer", streamErrorTrailer)
+
+	err := controller.AuditService.Export(c, &auditQueryReq, c.Writer)
+	endStream(c, err)
+}
+
+// streamErrorTrailer is set when a streamed response fails after its status
+// was sent, telling the client the body is truncated.
+const streamErrorTrailer = "X-Stream-Error"
+
+// endStream handles the error of a streamed response. Before the first write
+// the error goes through the regular error response, after it the 200 is
+// already sent so the error is logged and reported in the trailer instead.
+func endStream(c *gin.Context, err error) {
+	if err == nil {
+		return
+	}
+
+	if !c.Writer.Written() {
+		for _, header := range []string{"Content-Type", "Content-Disposition", "Trailer"} {
+			c.Writer.Header().Del(header)
+		}
+		panic(err)
+	}
+
+	_, logger := helper.GetDBAndLogger(c)
+	logger.Error("failed to stream response", zap.String("path", c.FullPath()), zap.Error(err))
+
+	c.Writer.Header().Set(streamErrorTrailer, "stream failed")
+	c.Abort()
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"io"
 	"net/http"
 	"strconv"
 
@@ -24,10 +25,11 @@ type CarController interface {
 
 type carControllerImpl struct {
 	services.CarService
+	services.ExportService
 }
 
-func NewCarController(carService services.CarService) CarController {
-	return &carControllerImpl{carService}
+func NewCarController(carService services.CarService, exportService services.ExportService) CarController {
+	return &carControllerImpl{carService, exportService}
 }
 
 // Create car godoc
@@ -130,8 +132,10 @@ func (controller *carControllerImpl) Delete(c *gin.Context) {
 // @Param model query string false "Model"
 // @Param min_year query int false "Minimum Year"
 // @Param max_year query int false "Maximum Year"
-// @Produce json
+// @Param format query string false "json, or csv / ndjson to stream every matching car without pagination, also negotiated with the Accept header" Enums(json, csv, ndjson)
+// @Produce json,text/csv,application/x-ndjson
 // @Success 200 {object} web.WebSuccess[[]response.CarResponse]
+// @Failure 400 {object} web.WebBadRequestError
 // @Failure 500 {object} web.WebInternalServerError
 // @Router /api/cars [get]
 func (controller *carControllerImpl) FindAll(c *gin.Context) {
@@ -146,6 +150,13 @@ func (controller *carControllerImpl) FindAll(c *gin.Context) {
 		panic(err)
 	}
 
+	if format := exportFormat(c, ""); format != "" {
+		writeExport(c, "cars", format, func(w io.Writer) error {
+			return controller.ExportService.ExportCars(c, &carQueryReq, format, w)
+		})
+		return
+	}
+
 	if pagination.Limit == 0 {
 		pagination.Limit = 10
 	}
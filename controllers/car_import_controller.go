@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type CarImportController interface {
+	Import(*gin.Context)
+	FindJob(*gin.Context)
+}
+
+type carImportControllerImpl struct {
+	services.CarImportService
+}
+
+func NewCarImportController(carImportService services.CarImportService) CarImportController {
+	return &carImportControllerImpl{carImportService}
+}
+
+// Import cars godoc
+// @Summary Import cars.
+// @Description Import cars from a CSV or NDJSON file whose columns match the create car body, plus an optional brand column with the brand name. Cars are upserted on brand, name, model and year. Large files are imported in the background, poll the returned job. Admin only.
+// @Tags Admin
+// @Accept multipart/form-data
+// @Param file formData file true "CSV or NDJSON file"
+// @Param format query string false "File format, guessed from the file name when empty" Enums(csv, ndjson)
+// @Param dry_run query bool false "Only validate and report what would happen"
+// @Param create_brands query bool false "Create brands that do not exist"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.ImportJobResponse]
+// @Success 202 {object} web.WebSuccess[response.ImportJobResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/cars/import [post]
+func (controller *carImportControllerImpl) Import(c *gin.Context) {
+	var importReq request.CarImportRequest
+
+	if err := c.ShouldBindQuery(&importReq); err != nil {
+		panic(err)
+	}
+
+	utils.UserRoleMustAdmin(c)
+
+	maxBytes := int64(helper.GetEnvInt("CAR_IMPORT_MAX_BYTES", 20<<20))
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, fmt.Sprintf("A file of at most %d bytes is required", maxBytes)))
+	}
+
+	if importReq.Format == "" {
+		switch strings.ToLower(filepath.Ext(fileHeader.Filename)) {
+		case ".ndjson", ".jsonl":
+			importReq.Format = "ndjson"
+		default:
+			importReq.Format = "csv"
+		}
+	}
+
+	file, err := fileHeader.Open()
+	helper.PanicIfError(err)
+	defer file.Close()
+
+	job, err := controller.CarImportService.Import(c, &importReq, file)
+	helper.PanicIfError(err)
+
+	status := http.StatusOK
+	if job.Status == entity.ImportJobPending || job.Status == entity.ImportJobRunning {
+		status = http.StatusAccepted
+	}
+
+	helper.ToResponseJSON(c, status, job, nil)
+}
+
+// Find import job godoc
+// @Summary Get an import job.
+// @Description Get the progress and the per-row report of a car import, admin only.
+// @Tags Admin
+// @Param id path int true "Import job ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.ImportJobResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/cars/import/{id} [get]
+func (controller *carImportControllerImpl) FindJob(c *gin.Context) {
+	jobID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Id must be an integer"))
+	}
+
+	utils.UserRoleMustAdmin(c)
+
+	job, err := controller.CarImportService.FindJob(c, uint(jobID))
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, job, nil)
+}
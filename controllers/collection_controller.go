@@ -0,0 +1,341 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type CollectionController interface {
+	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
+	FindBySlug(*gin.Context)
+	FindByUser(*gin.Context)
+	FindItems(*gin.Context)
+	AddItem(*gin.Context)
+	UpdateItem(*gin.Context)
+	RemoveItem(*gin.Context)
+	Reorder(*gin.Context)
+}
+
+type collectionControllerImpl struct {
+	services.CollectionService
+}
+
+func NewCollectionController(collectionService services.CollectionService) CollectionController {
+	return &collectionControllerImpl{collectionService}
+}
+
+// Create collection godoc
+// @Summary Create a collection.
+// @Description Create a named collection of favourite cars, private by default.
+// @Tags Collections
+// @Param Body body request.CollectionCreateRequest true "the body to create a collection"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 201 {object} web.WebSuccess[response.CollectionResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/collections [post]
+func (controller *collectionControllerImpl) Create(c *gin.Context) {
+	var collectionCreateReq request.CollectionCreateRequest
+
+	if err := c.ShouldBindJSON(&collectionCreateReq); err != nil {
+		panic(err)
+	}
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	collection, err := controller.CollectionService.Create(c, userID, &collectionCreateReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusCreated, collection, nil)
+}
+
+// Update collection godoc
+// @Summary Update a collection.
+// @Description Rename a collection or change its visibility, the slug does not change.
+// @Tags Collections
+// @Param slug path string true "Collection slug"
+// @Param Body body request.CollectionUpdateRequest true "the body to update a collection"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.CollectionResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/collections/{slug} [patch]
+func (controller *collectionControllerImpl) Update(c *gin.Context) {
+	var collectionUpdateReq request.CollectionUpdateRequest
+
+	if err := c.ShouldBindJSON(&collectionUpdateReq); err != nil {
+		panic(err)
+	}
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	collection, err := controller.CollectionService.Update(c, c.Param("slug"), userID, &collectionUpdateReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, collection, nil)
+}
+
+// Delete collection godoc
+// @Summary Delete a collection.
+// @Description Delete a collection with its items. The default collection cannot be deleted.
+// @Tags Collections
+// @Param slug path string true "Collection slug"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[string]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/collections/{slug} [delete]
+func (controller *collectionControllerImpl) Delete(c *gin.Context) {
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	err = controller.CollectionService.Delete(c, c.Param("slug"), userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, "collection deleted", nil)
+}
+
+// Find collection godoc
+// @Summary Get a collection.
+// @Description Get a public or unlisted collection by its slug. Private collections are only found by their owner.
+// @Tags Collections
+// @Param slug path string true "Collection slug"
+// @Param Authorization header string false "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.CollectionResponse]
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/collections/{slug} [get]
+func (controller *collectionControllerImpl) FindBySlug(c *gin.Context) {
+	collection, err := controller.CollectionService.FindBySlug(c, c.Param("slug"))
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, collection, nil)
+}
+
+// Find user collections godoc
+// @Summary Get the collections of a user.
+// @Description The public collections of a user, or all of them for the user themselves. The default collection comes first, then the latest.
+// @Tags Collections
+// @Param id path int true "User ID"
+// @Param limit query int false "Limit" default(10)
+// @Param page query int false "Page" default(1)
+// @Param Authorization header string false "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.CollectionResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/{id}/collections [get]
+func (controller *collectionControllerImpl) FindByUser(c *gin.Context) {
+	ownerID := galleryOwnerIDParam(c)
+
+	pagination := collectionPagination(c)
+
+	collections, metadata, err := controller.CollectionService.FindByUser(c, ownerID, &pagination)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, collections, metadata)
+}
+
+// Find collection items godoc
+// @Summary Get the cars of a collection.
+// @Description The cars of a collection with their notes, in their manual order.
+// @Tags Collections
+// @Param slug path string true "Collection slug"
+// @Param limit query int false "Limit" default(10)
+// @Param page query int false "Page" default(1)
+// @Param Authorization header string false "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.CollectionItemResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/collections/{slug}/items [get]
+func (controller *collectionControllerImpl) FindItems(c *gin.Context) {
+	pagination := collectionPagination(c)
+
+	items, metadata, err := controller.CollectionService.FindItems(c, c.Param("slug"), &pagination)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, items, metadata)
+}
+
+// Add collection item godoc
+// @Summary Add a car to a collection.
+// @Description Add a car with an optional note at the end of a collection.
+// @Tags Collections
+// @Param slug path string true "Collection slug"
+// @Param Body body request.CollectionItemCreateRequest true "the body to add a car"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 201 {object} web.WebSuccess[response.CollectionItemResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 409 {object} web.WebBadRequestError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/collections/{slug}/items [post]
+func (controller *collectionControllerImpl) AddItem(c *gin.Context) {
+	var collectionItemReq request.CollectionItemCreateRequest
+
+	if err := c.ShouldBindJSON(&collectionItemReq); err != nil {
+		panic(err)
+	}
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	item, err := controller.CollectionService.AddItem(c, c.Param("slug"), userID, &collectionItemReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusCreated, item, nil)
+}
+
+// Update collection item godoc
+// @Summary Update the note of a car in a collection.
+// @Description Change or clear the note of a car in a collection.
+// @Tags Collections
+// @Param slug path string true "Collection slug"
+// @Param carID path int true "Car ID"
+// @Param Body body request.CollectionItemUpdateRequest true "the body to update the note"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.CollectionItemResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/collections/{slug}/items/{carID} [patch]
+func (controller *collectionControllerImpl) UpdateItem(c *gin.Context) {
+	var collectionItemReq request.CollectionItemUpdateRequest
+
+	carID := collectionCarIDParam(c)
+
+	if err := c.ShouldBindJSON(&collectionItemReq); err != nil {
+		panic(err)
+	}
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	item, err := controller.CollectionService.UpdateItem(c, c.Param("slug"), carID, userID, &collectionItemReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, item, nil)
+}
+
+// Remove collection item godoc
+// @Summary Remove a car from a collection.
+// @Description Remove a car from a collection, the other cars keep their order.
+// @Tags Collections
+// @Param slug path string true "Collection slug"
+// @Param carID path int true "Car ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[string]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/collections/{slug}/items/{carID} [delete]
+func (controller *collectionControllerImpl) RemoveItem(c *gin.Context) {
+	carID := collectionCarIDParam(c)
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	err = controller.CollectionService.RemoveItem(c, c.Param("slug"), carID, userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, "car removed from collection", nil)
+}
+
+// Reorder collection godoc
+// @Summary Reorder a collection.
+// @Description Set the order of the cars of a collection, car_ids lists every car of the collection once.
+// @Tags Collections
+// @Param slug path string true "Collection slug"
+// @Param Body body request.CollectionReorderRequest true "the body to reorder a collection"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.CollectionItemResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/collections/{slug}/items/order [put]
+func (controller *collectionControllerImpl) Reorder(c *gin.Context) {
+	var collectionReorderReq request.CollectionReorderRequest
+
+	if err := c.ShouldBindJSON(&collectionReorderReq); err != nil {
+		panic(err)
+	}
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	items, err := controller.CollectionService.Reorder(c, c.Param("slug"), userID, &collectionReorderReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, items, nil)
+}
+
+func collectionPagination(c *gin.Context) web.PaginationRequest {
+	var pagination web.PaginationRequest
+
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		panic(err)
+	}
+
+	if pagination.Limit == 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	return pagination
+}
+
+func collectionCarIDParam(c *gin.Context) uint {
+	carID, err := strconv.ParseUint(c.Param("carID"), 10, 32)
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "CarID must be an integer"))
+	}
+	return uint(carID)
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type CommentReactionController interface {
+	React(*gin.Context)
+	Unreact(*gin.Context)
+}
+
+type commentReactionControllerImpl struct {
+	services.CommentReactionService
+}
+
+func NewCommentReactionController(commentReactionService services.CommentReactionService) CommentReactionController {
+	return &commentReactionControllerImpl{commentReactionService}
+}
+
+// React comment godoc
+// @Summary React comment.
+// @Description React to a comment with one of the emojis of COMMENT_REACTIONS, by default 👍 ❤️ 😂 😮 😢. Reacting twice with the same emoji changes nothing. Returns the reactions of the comment.
+// @Tags Comments
+// @Param id path int true "Comment ID"
+// @Param emoji path string true "Emoji, URL encoded"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.CommentReactionResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/comments/{id}/reactions/{emoji} [put]
+func (controller *commentReactionControllerImpl) React(c *gin.Context) {
+	commentID := galleryOwnerIDParam(c)
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	reactions, err := controller.CommentReactionService.React(c, commentID, userID, c.Param("emoji"))
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, reactions, nil)
+}
+
+// Unreact comment godoc
+// @Summary Unreact comment.
+// @Description Remove your reaction from a comment. Returns the reactions of the comment.
+// @Tags Comments
+// @Param id path int true "Comment ID"
+// @Param emoji path string true "Emoji, URL encoded"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.CommentReactionResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/comments/{id}/reactions/{emoji} [delete]
+func (controller *commentReactionControllerImpl) Unreact(c *gin.Context) {
+	commentID := galleryOwnerIDParam(c)
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	reactions, err := controller.CommentReactionService.Unreact(c, commentID, userID, c.Param("emoji"))
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, reactions, nil)
+}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type ExportController interface {
+	Cars(*gin.Context)
+	Reviews(*gin.Context)
+	Comments(*gin.Context)
+}
+
+type exportControllerImpl struct {
+	services.ExportService
+}
+
+func NewExportController(exportService services.ExportService) ExportController {
+	return &exportControllerImpl{exportService}
+}
+
+// Export cars godoc
+// @Summary Export cars.
+// @Description Stream every matching car as CSV or NDJSON, admin only.
+// @Tags Admin
+// @Param format query string false "Export format, defaults to the Accept header or csv" Enums(csv, ndjson)
+// @Param brand_id query int false "Brand ID"
+// @Param name query string false "Name"
+// @Param model query string false "Model"
+// @Param min_year query int false "Minimum Year"
+// @Param max_year query int false "Maximum Year"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce text/csv,application/x-ndjson
+// @Success 200 {object} response.CarExportResponse
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/export/cars [get]
+func (controller *exportControllerImpl) Cars(c *gin.Context) {
+	var carQueryReq request.CarQueryRequest
+
+	if err := c.ShouldBindQuery(&carQueryReq); err != nil {
+		panic(err)
+	}
+
+	format := exportFormat(c, services.ExportFormatCSV)
+
+	utils.UserRoleMustAdmin(c)
+
+	writeExport(c, "cars", format, func(w io.Writer) error {
+		return controller.ExportService.ExportCars(c, &carQueryReq, format, w)
+	})
+}
+
+// Export reviews godoc
+// @Summary Export reviews.
+// @Description Stream every matching review as CSV or NDJSON, admin only.
+// @Tags Admin
+// @Param format query string false "Export format, defaults to the Accept header or csv" Enums(csv, ndjson)
+// @Param title query string false "Title"
+// @Param car_id query int false "Car ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce text/csv,application/x-ndjson
+// @Success 200 {object} response.ReviewExportResponse
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/export/reviews [get]
+func (controller *exportControllerImpl) Reviews(c *gin.Context) {
+	var reviewQueryReq request.ReviewQueryRequest
+
+	if err := c.ShouldBindQuery(&reviewQueryReq); err != nil {
+		panic(err)
+	}
+
+	format := exportFormat(c, services.ExportFormatCSV)
+
+	utils.UserRoleMustAdmin(c)
+
+	writeExport(c, "reviews", format, func(w io.Writer) error {
+		return controller.ExportService.ExportReviews(c, &reviewQueryReq, format, w)
+	})
+}
+
+// Export comments godoc
+// @Summary Export comments.
+// @Description Stream every matching comment as CSV or NDJSON, admin only.
+// @Tags Admin
+// @Param format query string false "Export format, defaults to the Accept header or csv" Enums(csv, ndjson)
+// @Param review_id query int false "Review ID"
+// @Param user_id query int false "User ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce text/csv,application/x-ndjson
+// @Success 200 {object} response.CommentExportResponse
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/export/comments [get]
+func (controller *exportControllerImpl) Comments(c *gin.Context) {
+	var commentQueryReq request.CommentQueryRequest
+
+	if err := c.ShouldBindQuery(&commentQueryReq); err != nil {
+		panic(err)
+	}
+
+	format := exportFormat(c, services.ExportFormatCSV)
+
+	utils.UserRoleMustAdmin(c)
+
+	writeExport(c, "comments", format, func(w io.Writer) error {
+		return controller.ExportService.ExportComments(c, &commentQueryReq, format, w)
+	})
+}
+
+// exportFormat picks the export format from the format query parameter,
+// then the Accept header. fallback is returned when neither asks for an
+// export, an empty fallback meaning the regular JSON response.
+func exportFormat(c *gin.Context, fallback string) string {
+	switch format := strings.ToLower(c.Query("format")); format {
+	case services.ExportFormatCSV, services.ExportFormatNDJSON:
+		return format
+	case "json":
+		return fallback
+	case "":
+	default:
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Format must be one of json, csv or ndjson"))
+	}
+
+	accept := c.GetHeader("Accept")
+	switch {
+	case strings.Contains(accept, "text/csv"):
+		return services.ExportFormatCSV
+	case strings.Contains(accept, "application/x-ndjson"):
+		return services.ExportFormatNDJSON
+	default:
+		return fallback
+	}
+}
+
+// writeExport streams the export as an attachment, a failure after the first
+// row is reported through endStream.
+func writeExport(c *gin.Context, name, format string, export func(io.Writer) error) {
+	contentType, extension := "text/csv; charset=utf-8", "csv"
+	if format == services.ExportFormatNDJSON {
+		contentType, extension = "application/x-ndjson", "ndjson"
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, extension))
+	c.Header("Trailer", streamErrorTrailer)
+
+	err := export(c.Writer)
+	endStream(c, err)
+}
@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type FollowController interface {
+	Follow(*gin.Context)
+	Unfollow(*gin.Context)
+	FindFollowers(*gin.Context)
+	FindFollowing(*gin.Context)
+}
+
+// followControllerImpl follows one target type, the router mounts one for
+// users, one for brands and one for cars.
+type followControllerImpl struct {
+	services.FollowService
+	targetType string
+}
+
+func NewFollowController(followService services.FollowService, targetType string) FollowController {
+	return &followControllerImpl{followService, targetType}
+}
+
+// Follow godoc
+// @Summary Follow a user, a brand or a car.
+// @Description Follow a user, a brand or a car, their new reviews show up in GET /api/feed. Following a user counts on both profiles.
+// @Tags Follows
+// @Param id path int true "User, brand or car ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 201 {object} web.WebSuccess[string]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 409 {object} web.WebBadRequestError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/{id}/follow [post]
+// @Router /api/brands/{id}/follow [post]
+// @Router /api/cars/{id}/follow [post]
+func (controller *followControllerImpl) Follow(c *gin.Context) {
+	targetID := galleryOwnerIDParam(c)
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	err = controller.FollowService.Follow(c, controller.targetType, targetID, userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusCreated, "followed", nil)
+}
+
+// Unfollow godoc
+// @Summary Unfollow a user, a brand or a car.
+// @Description Stop following a user, a brand or a car.
+// @Tags Follows
+// @Param id path int true "User, brand or car ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[string]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/{id}/follow [delete]
+// @Router /api/brands/{id}/follow [delete]
+// @Router /api/cars/{id}/follow [delete]
+func (controller *followControllerImpl) Unfollow(c *gin.Context) {
+	targetID := galleryOwnerIDParam(c)
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	err = controller.FollowService.Unfollow(c, controller.targetType, targetID, userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, "unfollowed", nil)
+}
+
+// Find followers godoc
+// @Summary Find followers.
+// @Description The users following a user, a brand or a car, latest first.
+// @Tags Follows
+// @Param id path int true "User, brand or car ID"
+// @Param limit query int false "Limit" default(10)
+// @Param page query int false "Page" default(1)
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.FollowResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/{id}/followers [get]
+// @Router /api/brands/{id}/followers [get]
+// @Router /api/cars/{id}/followers [get]
+func (controller *followControllerImpl) FindFollowers(c *gin.Context) {
+	var pagination web.PaginationRequest
+
+	targetID := galleryOwnerIDParam(c)
+
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		panic(err)
+	}
+
+	if pagination.Limit == 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	followers, metadata, err := controller.FollowService.FindFollowers(c, controller.targetType, targetID, &pagination)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, followers, metadata)
+}
+
+// Find following godoc
+// @Summary Find following.
+// @Description The users, brands or cars a user follows, latest first.
+// @Tags Follows
+// @Param id path int true "User ID"
+// @Param type query string false "What is followed" Enums(users, brands, cars) default(users)
+// @Param limit query int false "Limit" default(10)
+// @Param page query int false "Page" default(1)
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.FollowResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/{id}/following [get]
+func (controller *followControllerImpl) FindFollowing(c *gin.Context) {
+	var pagination web.PaginationRequest
+	var followingQueryReq request.FollowingQueryRequest
+
+	userID := galleryOwnerIDParam(c)
+
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		panic(err)
+	}
+
+	if err := c.ShouldBindQuery(&followingQueryReq); err != nil {
+		panic(err)
+	}
+
+	if followingQueryReq.Type == "" {
+		followingQueryReq.Type = entity.FollowTargetUser
+	}
+	if pagination.Limit == 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	following, metadata, err := controller.FollowService.FindFollowing(c, userID, followingQueryReq.Type, &pagination)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, following, metadata)
+}
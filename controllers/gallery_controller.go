@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type GalleryController interface {
+	FindAll(*gin.Context)
+	Add(*gin.Context)
+	Update(*gin.Context)
+	Reorder(*gin.Context)
+	Remove(*gin.Context)
+}
+
+// galleryControllerImpl serves the galleries of one owner type, the router
+// mounts one for cars and one for reviews.
+type galleryControllerImpl struct {
+	services.GalleryService
+	ownerType string
+}
+
+func NewGalleryController(galleryService services.GalleryService, ownerType string) GalleryController {
+	return &galleryControllerImpl{galleryService, ownerType}
+}
+
+// Find gallery godoc
+// @Summary Find gallery.
+// @Description Find the images of a car or a review in display order.
+// @Tags Galleries
+// @Param id path int true "Car or review ID"
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.GalleryImageResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/cars/{id}/images [get]
+// @Router /api/reviews/{id}/images [get]
+func (controller *galleryControllerImpl) FindAll(c *gin.Context) {
+	ownerID := galleryOwnerIDParam(c)
+
+	gallery, err := controller.GalleryService.FindAll(c, controller.ownerType, ownerID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, gallery, nil)
+}
+
+// Add gallery image godoc
+// @Summary Add gallery image.
+// @Description Append an image to the gallery of a car (admin only) or of your review. Returns the whole gallery.
+// @Tags Galleries
+// @Param id path int true "Car or review ID"
+// @Param Body body request.GalleryImageCreateRequest true "the body to add an image"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 201 {object} web.WebSuccess[[]response.GalleryImageResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 409 {object} web.WebBadRequestError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/cars/{id}/images [post]
+// @Router /api/reviews/{id}/images [post]
+func (controller *galleryControllerImpl) Add(c *gin.Context) {
+	ownerID := galleryOwnerIDParam(c)
+
+	var galleryImageReq request.GalleryImageCreateRequest
+
+	err := c.ShouldBindJSON(&galleryImageReq)
+	helper.PanicIfError(err)
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	gallery, err := controller.GalleryService.Add(c, controller.ownerType, ownerID, userID, &galleryImageReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusCreated, gallery, nil)
+}
+
+// Update gallery image godoc
+// @Summary Update gallery image.
+// @Description Change the caption of an image or make it the cover. Returns the whole gallery.
+// @Tags Galleries
+// @Param id path int true "Car or review ID"
+// @Param image_id path int true "Image ID"
+// @Param Body body request.GalleryImageUpdateRequest true "the body to update an image"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.GalleryImageResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/cars/{id}/images/{image_id} [patch]
+// @Router /api/reviews/{id}/images/{image_id} [patch]
+func (controller *galleryControllerImpl) Update(c *gin.Context) {
+	ownerID := galleryOwnerIDParam(c)
+
+	imageID, err := strconv.ParseUint(c.Param("imageID"), 10, 32)
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Image id must be an integer"))
+	}
+
+	var galleryImageReq request.GalleryImageUpdateRequest
+
+	err = c.ShouldBindJSON(&galleryImageReq)
+	helper.PanicIfError(err)
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	gallery, err := controller.GalleryService.Update(c, controller.ownerType, ownerID, uint(imageID), userID, &galleryImageReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, gallery, nil)
+}
+
+// Reorder gallery godoc
+// @Summary Reorder gallery.
+// @Description Set the display order, image_ids must list every image of the gallery once.
+// @Tags Galleries
+// @Param id path int true "Car or review ID"
+// @Param Body body request.GalleryReorderRequest true "the new order"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.GalleryImageResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/cars/{id}/images/order [put]
+// @Router /api/reviews/{id}/images/order [put]
+func (controller *galleryControllerImpl) Reorder(c *gin.Context) {
+	ownerID := galleryOwnerIDParam(c)
+
+	var galleryReorderReq request.GalleryReorderRequest
+
+	err := c.ShouldBindJSON(&galleryReorderReq)
+	helper.PanicIfError(err)
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	gallery, err := controller.GalleryService.Reorder(c, controller.ownerType, ownerID, userID, &galleryReorderReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, gallery, nil)
+}
+
+// Remove gallery image godoc
+// @Summary Remove gallery image.
+// @Description Remove an image, when it was the cover the first remaining image becomes the cover. Returns the whole gallery.
+// @Tags Galleries
+// @Param id path int true "Car or review ID"
+// @Param image_id path int true "Image ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.GalleryImageResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/cars/{id}/images/{image_id} [delete]
+// @Router /api/reviews/{id}/images/{image_id} [delete]
+func (controller *galleryControllerImpl) Remove(c *gin.Context) {
+	ownerID := galleryOwnerIDParam(c)
+
+	imageID, err := strconv.ParseUint(c.Param("imageID"), 10, 32)
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Image id must be an integer"))
+	}
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	gallery, err := controller.GalleryService.Remove(c, controller.ownerType, ownerID, uint(imageID), userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, gallery, nil)
+}
+
+func galleryOwnerIDParam(c *gin.Context) uint {
+	ownerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Id must be an integer"))
+	}
+	return uint(ownerID)
+}
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type MediaController interface {
+	Upload(*gin.Context)
+	FindByID(*gin.Context)
+	File(*gin.Context)
+	Delete(*gin.Context)
+}
+
+type mediaControllerImpl struct {
+	services.MediaService
+}
+
+func NewMediaController(mediaService services.MediaService) MediaController {
+	return &mediaControllerImpl{mediaService}
+}
+
+// Upload media godoc
+// @Summary Upload media.
+// @Description Upload a JPEG, PNG or GIF image. The type is sniffed from the content, metadata such as EXIF is stripped and thumbnails are generated. Use the returned id as media_id of a car or a review.
+// @Tags Media
+// @Accept multipart/form-data
+// @Param file formData file true "Image file"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 201 {object} web.WebSuccess[response.MediaResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 413 {object} web.WebBadRequestError
+// @Failure 415 {object} web.WebBadRequestError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/media [post]
+func (controller *mediaControllerImpl) Upload(c *gin.Context) {
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	// leave room for the multipart envelope, the service enforces the real limit
+	maxBytes := int64(helper.GetEnvInt("MEDIA_MAX_BYTES", 10<<20))
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, fmt.Sprintf("A file of at most %d bytes is required", maxBytes)))
+	}
+
+	file, err := fileHeader.Open()
+	helper.PanicIfError(err)
+	defer file.Close()
+
+	media, err := controller.MediaService.Upload(c, userID, file)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusCreated, media, nil)
+}
+
+// Find media godoc
+// @Summary Find media.
+// @Description Find media and its variants by id.
+// @Tags Media
+// @Param id path int true "Media ID"
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.MediaResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/media/{id} [get]
+func (controller *mediaControllerImpl) FindByID(c *gin.Context) {
+	mediaID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Id must be an integer"))
+	}
+
+	media, err := controller.MediaService.FindByID(c, uint(mediaID))
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, media, nil)
+}
+
+// Media file godoc
+// @Summary Media file.
+// @Description Download a variant of the media, original or thumb_<size>.
+// @Tags Media
+// @Param id path int true "Media ID"
+// @Param variant path string true "Variant name" default(original)
+// @Produce image/jpeg,image/png,image/gif
+// @Success 200 {file} binary
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/media/{id}/{variant} [get]
+func (controller *mediaControllerImpl) File(c *gin.Context) {
+	mediaID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Id must be an integer"))
+	}
+
+	blob, variant, err := controller.MediaService.Open(c, uint(mediaID), c.Param("variant"))
+	helper.PanicIfError(err)
+	defer blob.Close()
+
+	// variants never change once stored
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, variant.Size, variant.ContentType, blob, nil)
+}
+
+// Delete media godoc
+// @Summary Delete media.
+// @Description Delete media of the current user, admins may delete any. Media used by a car or a review cannot be deleted.
+// @Tags Media
+// @Param id path int true "Media ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[string]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 409 {object} web.WebBadRequestError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/media/{id} [delete]
+func (controller *mediaControllerImpl) Delete(c *gin.Context) {
+	mediaID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Id must be an integer"))
+	}
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	err = controller.MediaService.Delete(c, userID, uint(mediaID))
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, "media deleted", nil)
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type ModerationController interface {
+	Queue(*gin.Context)
+	Decide(*gin.Context)
+}
+
+type moderationControllerImpl struct {
+	services.ModerationService
+}
+
+func NewModerationController(moderationService services.ModerationService) ModerationController {
+	return &moderationControllerImpl{moderationService}
+}
+
+// Moderation queue godoc
+// @Summary Moderation queue.
+// @Description List the reviews and comments waiting for a moderator, pending ones and those with open reports, the most reported first. Moderators only.
+// @Tags Moderation
+// @Param limit query int false "Limit" default(10)
+// @Param page query int false "Page" default(1)
+// @Param type query string false "Content type" Enums(reviews, comments)
+// @Param status query string false "Status" Enums(pending, published, hidden, rejected)
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.ModerationQueueItemResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/moderation/queue [get]
+func (controller *moderationControllerImpl) Queue(c *gin.Context) {
+	var pagination web.PaginationRequest
+	var queueReq request.ModerationQueueRequest
+
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		panic(err)
+	}
+
+	if err := c.ShouldBindQuery(&queueReq); err != nil {
+		panic(err)
+	}
+
+	if pagination.Limit == 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	utils.UserRoleMustModerator(c)
+
+	items, metadata, err := controller.ModerationService.Queue(c, &queueReq, &pagination)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, items, metadata)
+}
+
+// Moderate godoc
+// @Summary Moderate.
+// @Description Approve, hide or reject a review or a comment with a note, closing its open reports. Moderators only.
+// @Tags Moderation
+// @Param type path string true "Content type" Enums(reviews, comments)
+// @Param id path int true "Review or comment ID"
+// @Param Body body request.ModerationDecisionRequest true "the decision"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.ModerationDecisionResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/moderation/{type}/{id} [post]
+func (controller *moderationControllerImpl) Decide(c *gin.Context) {
+	targetID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Id must be an integer"))
+	}
+
+	var decisionReq request.ModerationDecisionRequest
+
+	err = c.ShouldBindJSON(&decisionReq)
+	helper.PanicIfError(err)
+
+	utils.UserRoleMustModerator(c)
+
+	decision, err := controller.ModerationService.Decide(c, c.Param("type"), uint(targetID), &decisionReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, decision, nil)
+}
@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type NotificationController interface {
+	FindAll(*gin.Context)
+	MarkRead(*gin.Context)
+	MarkAllRead(*gin.Context)
+	FindPreferences(*gin.Context)
+	UpdatePreferences(*gin.Context)
+}
+
+type notificationControllerImpl struct {
+	services.NotificationService
+}
+
+func NewNotificationController(notificationService services.NotificationService) NotificationController {
+	return &notificationControllerImpl{notificationService}
+}
+
+// Find notifications godoc
+// @Summary Get user notifications.
+// @Description Get the notifications of the current user, newest first.
+// @Tags Users
+// @Param unread query bool false "Only unread (true) or read (false) notifications"
+// @Param limit query int false "Limit" default(10)
+// @Param page query int false "Page" default(1)
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.NotificationResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/notifications [get]
+func (controller *notificationControllerImpl) FindAll(c *gin.Context) {
+	var pagination web.PaginationRequest
+	var notificationQueryReq request.NotificationQueryRequest
+
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		panic(err)
+	}
+
+	if err := c.ShouldBindQuery(&notificationQueryReq); err != nil {
+		panic(err)
+	}
+
+	if pagination.Limit == 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	notifications, metadata, err := controller.NotificationService.FindAll(c, userID, &notificationQueryReq, &pagination)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, notifications, metadata)
+}
+
+// Read notification godoc
+// @Summary Mark a notification as read.
+// @Description Mark one notification of the current user as read, a notification read before keeps its read date.
+// @Tags Users
+// @Param id path int true "Notification ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.NotificationResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/notifications/{id}/read [patch]
+func (controller *notificationControllerImpl) MarkRead(c *gin.Context) {
+	notificationID := galleryOwnerIDParam(c)
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	notification, err := controller.NotificationService.MarkRead(c, userID, notificationID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, notification, nil)
+}
+
+// Read all notifications godoc
+// @Summary Mark all notifications as read.
+// @Description Mark every unread notification of the current user as read.
+// @Tags Users
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.NotificationReadAllResponse]
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/notifications/read-all [post]
+func (controller *notificationControllerImpl) MarkAllRead(c *gin.Context) {
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	read, err := controller.NotificationService.MarkAllRead(c, userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, read, nil)
+}
+
+// Find notification preferences godoc
+// @Summary Get notification preferences.
+// @Description Get whether each notification type is on for the current user, every type is on by default.
+// @Tags Users
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.NotificationPreferenceResponse]
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/notifications/preferences [get]
+func (controller *notificationControllerImpl) FindPreferences(c *gin.Context) {
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	preferences, err := controller.NotificationService.FindPreferences(c, userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, preferences, nil)
+}
+
+// Update notification preferences godoc
+// @Summary Update notification preferences.
+// @Description Turn notification types on or off for the current user, the types left out keep their setting. Types: review_comment, comment_reply, mention, favourite_review, moderation.
+// @Tags Users
+// @Param Body body request.NotificationPreferenceRequest true "the body to update notification preferences"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.NotificationPreferenceResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/notifications/preferences [put]
+func (controller *notificationControllerImpl) UpdatePreferences(c *gin.Context) {
+	var notificationPreferenceReq request.NotificationPreferenceRequest
+
+	err := c.ShouldBindJSON(&notificationPreferenceReq)
+	helper.PanicIfError(err)
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	preferences, err := controller.NotificationService.UpdatePreferences(c, userID, &notificationPreferenceReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, preferences, nil)
+}
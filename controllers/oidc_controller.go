@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+)
+
+const oidcFlowCookie = "oidc_flow"
+
+type OIDCController interface {
+	Start(*gin.Context)
+	Callback(*gin.Context)
+}
+
+type oidcControllerImpl struct {
+	services.OIDCService
+}
+
+func NewOIDCController(oidcService services.OIDCService) OIDCController {
+	return &oidcControllerImpl{oidcService}
+}
+
+// Start OIDC login godoc
+// @Summary Start OIDC login.
+// @Description Redirect to the OpenID Connect provider to sign in using authorization code + PKCE.
+// @Tags Auth
+// @Param provider path string true "Provider name"
+// @Success 302
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/auth/oidc/{provider}/start [get]
+func (controller *oidcControllerImpl) Start(c *gin.Context) {
+	authURL, flowToken, err := controller.OIDCService.Start(c, c.Param("provider"))
+	helper.PanicIfError(err)
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcFlowCookie, flowToken, 600, "/api/auth/oidc", "", os.Getenv("ENVIRONMENT") == "production", true)
+
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// OIDC callback godoc
+// @Summary OIDC login callback.
+// @Description Complete the OpenID Connect login and get jwt token. Accounts are linked by verified email.
+// @Tags Auth
+// @Param provider path string true "Provider name"
+// @Param code query string true "Authorization code"
+// @Param state query string true "State"
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.LoginResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/auth/oidc/{provider}/callback [get]
+func (controller *oidcControllerImpl) Callback(c *gin.Context) {
+	var callbackReq request.OIDCCallbackRequest
+
+	err := c.ShouldBindQuery(&callbackReq)
+	helper.PanicIfError(err)
+
+	flowToken, _ := c.Cookie(oidcFlowCookie)
+
+	c.SetCookie(oidcFlowCookie, "", -1, "/api/auth/oidc", "", os.Getenv("ENVIRONMENT") == "production", true)
+
+	loginRes, err := controller.OIDCService.Callback(c, c.Param("provider"), &callbackReq, flowToken)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, loginRes, nil)
+}
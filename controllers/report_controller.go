@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type ReportController interface {
+	Create(*gin.Context)
+}
+
+// reportControllerImpl reports one type of content, the router mounts one
+// for reviews and one for comments.
+type reportControllerImpl struct {
+	services.ModerationService
+	targetType string
+}
+
+func NewReportController(moderationService services.ModerationService, targetType string) ReportController {
+	return &reportControllerImpl{moderationService, targetType}
+}
+
+// Report godoc
+// @Summary Report.
+// @Description Report a review or a comment to the moderators, once per user. Content with enough open reports is hidden until a moderator decides.
+// @Tags Moderation
+// @Param id path int true "Review or comment ID"
+// @Param Body body request.ReportCreateRequest true "the reason of the report"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 201 {object} web.WebSuccess[response.ReportResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 409 {object} web.WebBadRequestError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/reviews/{id}/report [post]
+// @Router /api/comments/{id}/report [post]
+func (controller *reportControllerImpl) Create(c *gin.Context) {
+	targetID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Id must be an integer"))
+	}
+
+	var reportReq request.ReportCreateRequest
+
+	err = c.ShouldBindJSON(&reportReq)
+	helper.PanicIfError(err)
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	report, err := controller.ModerationService.Report(c, controller.targetType, uint(targetID), userID, &reportReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusCreated, report, nil)
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"io"
 	"net/http"
 	"strconv"
 
@@ -21,15 +22,17 @@ type ReviewController interface {
 	FindAll(*gin.Context)
 	FindById(*gin.Context)
 	FindComments(*gin.Context)
+	Feed(*gin.Context)
 }
 
 type reviewControllerImpl struct {
 	services.ReviewService
 	services.CommentService
+	services.ExportService
 }
 
-func NewreviewController(reviewService services.ReviewService, commentService services.CommentService) ReviewController {
-	return &reviewControllerImpl{reviewService, commentService}
+func NewreviewController(reviewService services.ReviewService, commentService services.CommentService, exportService services.ExportService) ReviewController {
+	return &reviewControllerImpl{reviewService, commentService, exportService}
 }
 
 // Create review godoc
@@ -130,8 +133,11 @@ func (controller *reviewControllerImpl) Delete(c *gin.Context) {
 // @Param page query int false "Page" default(1)
 // @Param title query string false "Title"
 // @Param car_id query string false "Car ID"
-// @Produce json
+// @Param sort query string false "newest (default), oldest, helpful by the Wilson score of the votes, or rating by helpful minus unhelpful votes" Enums(newest, oldest, helpful, rating)
+// @Param format query string false "json, or csv / ndjson to stream every matching review without pagination, also negotiated with the Accept header" Enums(json, csv, ndjson)
+// @Produce json,text/csv,application/x-ndjson
 // @Success 200 {object} web.WebSuccess[[]response.FindReviewResponse]
+// @Failure 400 {object} web.WebBadRequestError
 // @Failure 500 {object} web.WebInternalServerError
 // @Router /api/reviews [get]
 func (controller *reviewControllerImpl) FindAll(c *gin.Context) {
@@ -146,6 +152,13 @@ func (controller *reviewControllerImpl) FindAll(c *gin.Context) {
 		panic(err)
 	}
 
+	if format := exportFormat(c, ""); format != "" {
+		writeExport(c, "reviews", format, func(w io.Writer) error {
+			return controller.ExportService.ExportReviews(c, &reviewQueryReq, format, w)
+		})
+		return
+	}
+
 	if pagination.Limit == 0 {
 		pagination.Limit = 10
 	}
@@ -186,7 +199,8 @@ func (controller *reviewControllerImpl) FindById(c *gin.Context) {
 // @Description Find a comment by review id.
 // @Tags Reviews
 // @Param id path int true "Review ID"
-// @Produce json
+// @Param format query string false "json, or csv / ndjson to stream the comments, also negotiated with the Accept header" Enums(json, csv, ndjson)
+// @Produce json,text/csv,application/x-ndjson
 // @Success 201 {object} web.WebSuccess[[]response.CommentResponse]
 // @Failure 400 {object} web.WebBadRequestError
 // @Failure 404 {object} web.WebNotFoundError
@@ -198,8 +212,46 @@ func (controller *reviewControllerImpl) FindComments(c *gin.Context) {
 		panic(exceptions.NewCustomError(http.StatusBadRequest, "ReviewID must be an integer"))
 	}
 
+	if format := exportFormat(c, ""); format != "" {
+		reviewID := uint(reviewID)
+		writeExport(c, "comments", format, func(w io.Writer) error {
+			return controller.ExportService.ExportComments(c, &request.CommentQueryRequest{ReviewID: &reviewID}, format, w)
+		})
+		return
+	}
+
 	comments, err := controller.CommentService.FindByReviewId(c, uint(reviewID))
 	helper.PanicIfError(err)
 
 	helper.ToResponseJSON(c, http.StatusOK, comments, nil)
 }
+
+// Feed godoc
+// @Summary Personal feed.
+// @Description The published reviews by the users, and of the brands and cars, that the current user follows, latest first. Pass metadata.next_cursor as cursor for the next page, there is none on the last page.
+// @Tags Reviews
+// @Param limit query int false "Limit, at most 50" default(20)
+// @Param cursor query string false "Cursor of the next page"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.FindReviewResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/feed [get]
+func (controller *reviewControllerImpl) Feed(c *gin.Context) {
+	var feedReq request.FeedRequest
+
+	if err := c.ShouldBindQuery(&feedReq); err != nil {
+		panic(err)
+	}
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	reviews, metadata, err := controller.ReviewService.Feed(c, userID, &feedReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, reviews, metadata)
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type ReviewVoteController interface {
+	Vote(*gin.Context)
+	Unvote(*gin.Context)
+}
+
+type reviewVoteControllerImpl struct {
+	services.ReviewVoteService
+}
+
+func NewReviewVoteController(reviewVoteService services.ReviewVoteService) ReviewVoteController {
+	return &reviewVoteControllerImpl{reviewVoteService}
+}
+
+// Vote review godoc
+// @Summary Vote review.
+// @Description Vote a review helpful or unhelpful, one vote per user, voting again changes it. Sort reviews with sort=helpful to rank them by their votes.
+// @Tags Reviews
+// @Param id path int true "Review ID"
+// @Param Body body request.ReviewVoteRequest true "the vote"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.ReviewVoteResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/reviews/{id}/vote [put]
+func (controller *reviewVoteControllerImpl) Vote(c *gin.Context) {
+	reviewID := galleryOwnerIDParam(c)
+
+	var voteReq request.ReviewVoteRequest
+
+	err := c.ShouldBindJSON(&voteReq)
+	helper.PanicIfError(err)
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	tally, err := controller.ReviewVoteService.Vote(c, reviewID, userID, &voteReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, tally, nil)
+}
+
+// Unvote review godoc
+// @Summary Unvote review.
+// @Description Remove your vote on a review.
+// @Tags Reviews
+// @Param id path int true "Review ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.ReviewVoteResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/reviews/{id}/vote [delete]
+func (controller *reviewVoteControllerImpl) Unvote(c *gin.Context) {
+	reviewID := galleryOwnerIDParam(c)
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	tally, err := controller.ReviewVoteService.Unvote(c, reviewID, userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, tally, nil)
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+)
+
+type RevisionController interface {
+	FindAll(*gin.Context)
+	FindByNumber(*gin.Context)
+	Diff(*gin.Context)
+}
+
+// revisionControllerImpl serves the revisions of one owner type, the router
+// mounts one for cars and one for reviews.
+type revisionControllerImpl struct {
+	services.RevisionService
+	ownerType string
+}
+
+func NewRevisionController(revisionService services.RevisionService, ownerType string) RevisionController {
+	return &revisionControllerImpl{revisionService, ownerType}
+}
+
+// Find revisions godoc
+// @Summary Find revisions.
+// @Description List the edits of a car or a review, newest first, with who made them and the snapshots before and after.
+// @Tags Revisions
+// @Param id path int true "Car or review ID"
+// @Param limit query int false "Limit" default(10)
+// @Param page query int false "Page" default(1)
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.RevisionResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/cars/{id}/revisions [get]
+// @Router /api/reviews/{id}/revisions [get]
+func (controller *revisionControllerImpl) FindAll(c *gin.Context) {
+	ownerID := galleryOwnerIDParam(c)
+
+	var pagination web.PaginationRequest
+
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		panic(err)
+	}
+
+	if pagination.Limit == 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	revisions, metadata, err := controller.RevisionService.FindAll(c, controller.ownerType, ownerID, &pagination)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, revisions, metadata)
+}
+
+// Find revision godoc
+// @Summary Find revision.
+// @Description Find one edit of a car or a review by its number, counted from 1.
+// @Tags Revisions
+// @Param id path int true "Car or review ID"
+// @Param number path int true "Revision number"
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.RevisionResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/cars/{id}/revisions/{number} [get]
+// @Router /api/reviews/{id}/revisions/{number} [get]
+func (controller *revisionControllerImpl) FindByNumber(c *gin.Context) {
+	ownerID := galleryOwnerIDParam(c)
+
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Revision number must be an integer"))
+	}
+
+	revision, err := controller.RevisionService.FindByNumber(c, controller.ownerType, ownerID, number)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, revision, nil)
+}
+
+// Diff revisions godoc
+// @Summary Diff revisions.
+// @Description Compare the state after revision from with the state after revision to, field by field. Revision 0 is the state before the first edit.
+// @Tags Revisions
+// @Param id path int true "Car or review ID"
+// @Param from query int false "From revision" default(0)
+// @Param to query int true "To revision"
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.RevisionDiffResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/cars/{id}/revisions/diff [get]
+// @Router /api/reviews/{id}/revisions/diff [get]
+func (controller *revisionControllerImpl) Diff(c *gin.Context) {
+	ownerID := galleryOwnerIDParam(c)
+
+	var revisionDiffReq request.RevisionDiffRequest
+
+	if err := c.ShouldBindQuery(&revisionDiffReq); err != nil {
+		panic(err)
+	}
+
+	diff, err := controller.RevisionService.Diff(c, controller.ownerType, ownerID, &revisionDiffReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, diff, nil)
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type SessionController interface {
+	FindAll(*gin.Context)
+	Revoke(*gin.Context)
+	RevokeAll(*gin.Context)
+}
+
+type sessionControllerImpl struct {
+	services.SessionService
+}
+
+func NewSessionController(sessionService services.SessionService) SessionController {
+	return &sessionControllerImpl{sessionService}
+}
+
+// Find all sessions godoc
+// @Summary Get user sessions.
+// @Description Get the active sessions of the current user.
+// @Tags Users
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.SessionResponse]
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/sessions [get]
+func (controller *sessionControllerImpl) FindAll(c *gin.Context) {
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	sessions, err := controller.SessionService.FindAll(c, userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, sessions, nil)
+}
+
+// Revoke session godoc
+// @Summary Revoke a session.
+// @Description Log out a session of the current user.
+// @Tags Users
+// @Param id path int true "Session ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[string]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/sessions/{id} [delete]
+func (controller *sessionControllerImpl) Revoke(c *gin.Context) {
+	sessionID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Id must be an integer"))
+	}
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	err = controller.SessionService.Revoke(c, userID, uint(sessionID))
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, "session revoked", nil)
+}
+
+// Revoke all sessions godoc
+// @Summary Log out everywhere.
+// @Description Revoke every session of the current user, including the current one.
+// @Tags Users
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[string]
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/sessions [delete]
+func (controller *sessionControllerImpl) RevokeAll(c *gin.Context) {
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	err = controller.SessionService.RevokeAll(c, userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, "all sessions revoked", nil)
+}
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+)
+
+// streamRetry is how long browsers wait before reconnecting.
+const streamRetry = 3 * time.Second
+
+type StreamController interface {
+	Stream(*gin.Context)
+}
+
+type streamControllerImpl struct {
+	services.StreamService
+}
+
+func NewStreamController(streamService services.StreamService) StreamController {
+	return &streamControllerImpl{streamService}
+}
+
+// Stream godoc
+// @Summary Stream real-time updates.
+// @Description Server-Sent Events of the subscribed topics. review:<id> pushes comment.created, comment.updated and comment.deleted for the comments of a review, user:me pushes notification.created for the notifications of the current user and needs a token, in the Authorization header or the token query parameter for EventSource. Each event has an id and its data is {"topic": ..., "data": ...}, a client that reconnects with the Last-Event-ID header (or last_event_id) first gets the events it missed, with the few stored just before the last one again since they may have been committed late, and drops the IDs it already has. A comment line is sent every STREAM_HEARTBEAT_SECONDS to keep the connection open.
+// @Tags Stream
+// @Param topics query string true "Comma separated topics" example(review:12,user:me)
+// @Param last_event_id query int false "Resume after this event"
+// @Param Last-Event-ID header string false "Resume after this event, sent by EventSource on reconnect"
+// @Param Authorization header string false "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce text/event-stream
+// @Success 200 {string} string "event stream"
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/stream [get]
+func (controller *streamControllerImpl) Stream(c *gin.Context) {
+	var streamReq request.StreamRequest
+
+	if err := c.ShouldBindQuery(&streamReq); err != nil {
+		panic(err)
+	}
+
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		id, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			panic(exceptions.NewCustomError(http.StatusBadRequest, "Last-Event-ID must be an integer"))
+		}
+		streamReq.LastEventID = id
+	}
+
+	subscription, backlog, err := controller.StreamService.Open(c, &streamReq)
+	helper.PanicIfError(err)
+	defer controller.StreamService.Close(subscription)
+
+	heartbeat := time.NewTicker(time.Duration(helper.GetEnvInt("STREAM_HEARTBEAT_SECONDS", 15)) * time.Second)
+	defer heartbeat.Stop()
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry.Milliseconds())
+
+	// a replayed event can also arrive live when it committed meanwhile
+	replayed := map[uint64]bool{}
+	for i := range backlog {
+		replayed[backlog[i].ID] = true
+		writeStreamEvent(c.Writer, &backlog[i])
+	}
+	c.Writer.Flush()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-subscription.Events:
+			// too slow, the client resumes from its last event
+			if !ok {
+				return
+			}
+			if replayed[event.ID] {
+				continue
+			}
+			writeStreamEvent(c.Writer, event)
+			c.Writer.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": heartbeat\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+func writeStreamEvent(w io.Writer, event *entity.StreamEvent) {
+	data, _ := json.Marshal(struct {
+		Topic string          `json:"topic"`
+		Data  json.RawMessage `json:"data"`
+	}{event.Topic, json.RawMessage(event.Data)})
+
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Event, data)
+}
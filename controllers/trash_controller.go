@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type TrashController interface {
+	FindAll(*gin.Context)
+	Restore(*gin.Context)
+}
+
+// trashControllerImpl serves the deleted records of one kind, the router
+// mounts one for cars, brands, reviews and comments.
+type trashControllerImpl struct {
+	services.TrashService
+	kind string
+}
+
+func NewTrashController(trashService services.TrashService, kind string) TrashController {
+	return &trashControllerImpl{trashService, kind}
+}
+
+// Find trash godoc
+// @Summary Find trash.
+// @Description List deleted records, newest first, with the time they will be purged permanently. Admin only.
+// @Tags Admin
+// @Param limit query int false "Limit" default(10)
+// @Param page query int false "Page" default(1)
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.TrashItemResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/trash/cars [get]
+// @Router /api/admin/trash/brands [get]
+// @Router /api/admin/trash/reviews [get]
+// @Router /api/admin/trash/comments [get]
+func (controller *trashControllerImpl) FindAll(c *gin.Context) {
+	var pagination web.PaginationRequest
+
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		panic(err)
+	}
+
+	utils.UserRoleMustAdmin(c)
+
+	if pagination.Limit == 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	items, metadata, err := controller.TrashService.FindAll(c, controller.kind, &pagination)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, items, metadata)
+}
+
+// Restore godoc
+// @Summary Restore.
+// @Description Restore a deleted record. Restoring a review restores the comments deleted with it, a record whose parent is deleted needs the parent restored first. Admin only.
+// @Tags Admin
+// @Param id path int true "ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[string]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 409 {object} web.WebBadRequestError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/cars/{id}/restore [post]
+// @Router /api/brands/{id}/restore [post]
+// @Router /api/reviews/{id}/restore [post]
+// @Router /api/comments/{id}/restore [post]
+func (controller *trashControllerImpl) Restore(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Id must be an integer"))
+	}
+
+	utils.UserRoleMustAdmin(c)
+
+	err = controller.TrashService.Restore(c, controller.kind, uint(id))
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, "restored", nil)
+}
@@ -96,7 +96,7 @@ func (controller *userControllerImpl) Login(c *gin.Context) {
 
 // UpdatePassword godoc
 // @Summary Update user password.
-// @Description Update the current user's password.
+// @Description Update the current user's password. The current password is required and the new one must satisfy the password policy.
 // @Tags Users
 // @Param Body body request.UpdatePasswordRequest true "the body to update a password"
 // @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
@@ -115,7 +115,7 @@ func (controller *userControllerImpl) UpdatePassword(c *gin.Context) {
 	userID, _, err := utils.ExtractTokenClaims(c)
 	helper.PanicIfError(err)
 
-	err = controller.UserService.UpdatePassword(c, userID, updatePasswordReq.Password)
+	err = controller.UserService.UpdatePassword(c, userID, updatePasswordReq.CurrentPassword, updatePasswordReq.Password)
 	helper.PanicIfError(err)
 
 	helper.ToResponseJSON(c, http.StatusOK, "password updated", nil)
@@ -207,24 +207,39 @@ func (controller *userControllerImpl) DeleteUserProfile(c *gin.Context) {
 
 // GetFavourites godoc
 // @Summary Get user favourites.
-// @Description Get user profile data.
+// @Description Get the cars of the default collection of the current user, in their manual order.
 // @Tags Users
+// @Param limit query int false "Limit" default(10)
+// @Param page query int false "Page" default(1)
 // @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
 // @Security BearerToken
 // @Produce json
-// @Success 200 {object} web.WebSuccess[response.FavouriteResponse]
+// @Success 200 {object} web.WebSuccess[[]response.FavouriteResponse]
 // @Failure 400 {object} web.WebBadRequestError
 // @Failure 404 {object} web.WebNotFoundError
 // @Failure 500 {object} web.WebInternalServerError
 // @Router /api/users/favourites [get]
 func (controller *userControllerImpl) GetFavourites(c *gin.Context) {
+	var pagination web.PaginationRequest
+
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		panic(err)
+	}
+
+	if pagination.Limit == 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
 	userID, _, err := utils.ExtractTokenClaims(c)
 	helper.PanicIfError(err)
 
-	favourites, err := controller.FavouriteService.GetUserFavourites(c, uint(userID))
+	favourites, metadata, err := controller.FavouriteService.GetUserFavourites(c, uint(userID), &pagination)
 	helper.PanicIfError(err)
 
-	helper.ToResponseJSON(c, http.StatusOK, favourites, nil)
+	helper.ToResponseJSON(c, http.StatusOK, favourites, metadata)
 }
 
 // ForgotPassword godoc
@@ -0,0 +1,181 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type WatchController interface {
+	Watch(*gin.Context)
+	Unwatch(*gin.Context)
+	FindAll(*gin.Context)
+	FindDigest(*gin.Context)
+	UpdateDigest(*gin.Context)
+}
+
+// watchControllerImpl watches one target type, the router mounts one for
+// brands and one for cars. The watch-list and digest routes do not depend on
+// it and are served by one without a target type.
+type watchControllerImpl struct {
+	services.WatchService
+	targetType string
+}
+
+func NewWatchController(watchService services.WatchService, targetType string) WatchController {
+	return &watchControllerImpl{watchService, targetType}
+}
+
+// Watch godoc
+// @Summary Watch a car or a brand.
+// @Description Get notified of the new reviews and the spec changes of a car, or of every car of a brand. The car or brand is followed too.
+// @Tags Watches
+// @Param id path int true "Car or brand ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 201 {object} web.WebSuccess[string]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 409 {object} web.WebBadRequestError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/cars/{id}/watch [post]
+// @Router /api/brands/{id}/watch [post]
+func (controller *watchControllerImpl) Watch(c *gin.Context) {
+	targetID := galleryOwnerIDParam(c)
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	err = controller.WatchService.Watch(c, controller.targetType, targetID, userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusCreated, "watched", nil)
+}
+
+// Unwatch godoc
+// @Summary Unwatch a car or a brand.
+// @Description Stop the notifications of a car or a brand, it stays followed.
+// @Tags Watches
+// @Param id path int true "Car or brand ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[string]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/cars/{id}/watch [delete]
+// @Router /api/brands/{id}/watch [delete]
+func (controller *watchControllerImpl) Unwatch(c *gin.Context) {
+	targetID := galleryOwnerIDParam(c)
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	err = controller.WatchService.Unwatch(c, controller.targetType, targetID, userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, "unwatched", nil)
+}
+
+// Find watches godoc
+// @Summary Get the watch list.
+// @Description The cars and brands the current user watches, latest first.
+// @Tags Watches
+// @Param type query string false "Only cars or brands" Enums(cars, brands)
+// @Param limit query int false "Limit" default(10)
+// @Param page query int false "Page" default(1)
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.WatchResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/watches [get]
+func (controller *watchControllerImpl) FindAll(c *gin.Context) {
+	var pagination web.PaginationRequest
+	var watchQueryReq request.WatchQueryRequest
+
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		panic(err)
+	}
+
+	if err := c.ShouldBindQuery(&watchQueryReq); err != nil {
+		panic(err)
+	}
+
+	if pagination.Limit == 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	watches, metadata, err := controller.WatchService.FindAll(c, userID, &watchQueryReq, &pagination)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, watches, metadata)
+}
+
+// Find watch digest godoc
+// @Summary Get the watch digest setting.
+// @Description How often the current user gets their watch notifications by email, off by default.
+// @Tags Watches
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.WatchDigestResponse]
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/watches/digest [get]
+func (controller *watchControllerImpl) FindDigest(c *gin.Context) {
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	digest, err := controller.WatchService.FindDigest(c, userID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, digest, nil)
+}
+
+// Update watch digest godoc
+// @Summary Update the watch digest setting.
+// @Description Get the unread watch notifications by email daily or weekly, or turn the digest off. The first digest comes a period after it is turned on.
+// @Tags Watches
+// @Param Body body request.WatchDigestRequest true "the body to update the watch digest"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.WatchDigestResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/users/watches/digest [put]
+func (controller *watchControllerImpl) UpdateDigest(c *gin.Context) {
+	var watchDigestReq request.WatchDigestRequest
+
+	if err := c.ShouldBindJSON(&watchDigestReq); err != nil {
+		panic(err)
+	}
+
+	userID, _, err := utils.ExtractTokenClaims(c)
+	helper.PanicIfError(err)
+
+	digest, err := controller.WatchService.UpdateDigest(c, userID, &watchDigestReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, digest, nil)
+}
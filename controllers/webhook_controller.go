@@ -0,0 +1,255 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	_ "github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+)
+
+type WebhookController interface {
+	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
+	FindAll(*gin.Context)
+	FindByID(*gin.Context)
+	FindDeliveries(*gin.Context)
+	Redeliver(*gin.Context)
+}
+
+type webhookControllerImpl struct {
+	services.WebhookService
+}
+
+func NewWebhookController(webhookService services.WebhookService) WebhookController {
+	return &webhookControllerImpl{webhookService}
+}
+
+// Create webhook godoc
+// @Summary Create a webhook.
+// @Description Register a URL to receive car.created, car.updated, car.deleted, brand.created, brand.updated, brand.deleted and review.published events. Each delivery is a POST of {"event", "created_at", "data"} signed in the X-Webhook-Signature header as sha256=<hex HMAC-SHA256 of X-Webhook-Timestamp + "." + body>, keyed with the secret. The URL must only resolve to public addresses. The secret is generated when omitted and only returned here. Admin only.
+// @Tags Admin
+// @Param body body request.WebhookCreateRequest true "the body to create a webhook"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 201 {object} web.WebSuccess[response.WebhookResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/webhooks [post]
+func (controller *webhookControllerImpl) Create(c *gin.Context) {
+	var webhookCreateReq request.WebhookCreateRequest
+
+	if err := c.ShouldBindJSON(&webhookCreateReq); err != nil {
+		panic(err)
+	}
+
+	utils.UserRoleMustAdmin(c)
+
+	webhook, err := controller.WebhookService.Create(c, &webhookCreateReq)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusCreated, webhook, nil)
+}
+
+// Update webhook godoc
+// @Summary Update a webhook.
+// @Description Change the URL, events, secret, description or active flag of a webhook, the deliveries of an inactive webhook fail. Admin only.
+// @Tags Admin
+// @Param id path int true "Webhook ID"
+// @Param body body request.WebhookUpdateRequest true "the body to update a webhook"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.WebhookResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/webhooks/{id} [patch]
+func (controller *webhookControllerImpl) Update(c *gin.Context) {
+	var webhookUpdateReq request.WebhookUpdateRequest
+
+	webhookID := webhookIDParam(c)
+
+	if err := c.ShouldBindJSON(&webhookUpdateReq); err != nil {
+		panic(err)
+	}
+
+	utils.UserRoleMustAdmin(c)
+
+	webhook, err := controller.WebhookService.Update(c, &webhookUpdateReq, webhookID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, webhook, nil)
+}
+
+// Delete webhook godoc
+// @Summary Delete a webhook.
+// @Description Delete a webhook with its delivery log, admin only.
+// @Tags Admin
+// @Param id path int true "Webhook ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[string]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/webhooks/{id} [delete]
+func (controller *webhookControllerImpl) Delete(c *gin.Context) {
+	webhookID := webhookIDParam(c)
+
+	utils.UserRoleMustAdmin(c)
+
+	err := controller.WebhookService.Delete(c, webhookID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, "webhook deleted", nil)
+}
+
+// Find all webhooks godoc
+// @Summary List webhooks.
+// @Description List the webhooks, without their secrets. Admin only.
+// @Tags Admin
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.WebhookResponse]
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/webhooks [get]
+func (controller *webhookControllerImpl) FindAll(c *gin.Context) {
+	utils.UserRoleMustAdmin(c)
+
+	webhooks, err := controller.WebhookService.FindAll(c)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, webhooks, nil)
+}
+
+// Find webhook by id godoc
+// @Summary Get a webhook.
+// @Description Get a webhook without its secret, admin only.
+// @Tags Admin
+// @Param id path int true "Webhook ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[response.WebhookResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/webhooks/{id} [get]
+func (controller *webhookControllerImpl) FindByID(c *gin.Context) {
+	webhookID := webhookIDParam(c)
+
+	utils.UserRoleMustAdmin(c)
+
+	webhook, err := controller.WebhookService.FindByID(c, webhookID)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, webhook, nil)
+}
+
+// Find webhook deliveries godoc
+// @Summary List the deliveries of a webhook.
+// @Description The delivery log of a webhook, newest first, with the payload, attempts and the last response. Failed attempts are retried with exponential backoff until WEBHOOK_MAX_ATTEMPTS. Admin only.
+// @Tags Admin
+// @Param id path int true "Webhook ID"
+// @Param limit query int false "Limit" default(10)
+// @Param page query int false "Page" default(1)
+// @Param status query string false "Status" Enums(pending, delivered, failed)
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 200 {object} web.WebSuccess[[]response.WebhookDeliveryResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/webhooks/{id}/deliveries [get]
+func (controller *webhookControllerImpl) FindDeliveries(c *gin.Context) {
+	var pagination web.PaginationRequest
+	var deliveryQueryReq request.WebhookDeliveryQueryRequest
+
+	webhookID := webhookIDParam(c)
+
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		panic(err)
+	}
+
+	if err := c.ShouldBindQuery(&deliveryQueryReq); err != nil {
+		panic(err)
+	}
+
+	if pagination.Limit == 0 {
+		pagination.Limit = 10
+	}
+	if pagination.Page == 0 {
+		pagination.Page = 1
+	}
+
+	utils.UserRoleMustAdmin(c)
+
+	deliveries, metadata, err := controller.WebhookService.FindDeliveries(c, webhookID, &deliveryQueryReq, &pagination)
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusOK, deliveries, metadata)
+}
+
+// Redeliver webhook delivery godoc
+// @Summary Redeliver a webhook delivery.
+// @Description Queue the payload of a delivery again as a new delivery, admin only.
+// @Tags Admin
+// @Param id path int true "Webhook ID"
+// @Param deliveryID path int true "Delivery ID"
+// @Param Authorization header string true "Authorization. How to input in swagger : 'Bearer <insert_your_token_here>'"
+// @Security BearerToken
+// @Produce json
+// @Success 202 {object} web.WebSuccess[response.WebhookDeliveryResponse]
+// @Failure 400 {object} web.WebBadRequestError
+// @Failure 401 {object} web.WebUnauthorizedError
+// @Failure 403 {object} web.WebForbiddenError
+// @Failure 404 {object} web.WebNotFoundError
+// @Failure 500 {object} web.WebInternalServerError
+// @Router /api/admin/webhooks/{id}/deliveries/{deliveryID}/redeliver [post]
+func (controller *webhookControllerImpl) Redeliver(c *gin.Context) {
+	webhookID := webhookIDParam(c)
+
+	deliveryID, err := strconv.ParseUint(c.Param("deliveryID"), 10, 32)
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Delivery id must be an integer"))
+	}
+
+	utils.UserRoleMustAdmin(c)
+
+	delivery, err := controller.WebhookService.Redeliver(c, webhookID, uint(deliveryID))
+	helper.PanicIfError(err)
+
+	helper.ToResponseJSON(c, http.StatusAccepted, delivery, nil)
+}
+
+func webhookIDParam(c *gin.Context) uint {
+	webhookID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		panic(exceptions.NewCustomError(http.StatusBadRequest, "Id must be an integer"))
+	}
+	return uint(webhookID)
+}
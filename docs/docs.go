@@ -15,38 +15,97 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/auth/forgot-password": {
-            "post": {
-                "description": "Request forgot password.",
+        "/api/admin/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Search the security audit log, admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Forgot password.",
+                "summary": "Search audit log.",
                 "parameters": [
                     {
-                        "description": "the body to request forgot password",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.ForgotPasswordRequest"
-                        }
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor user ID",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action, e.g. car.update",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target type, e.g. car",
+                        "name": "target_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Target ID",
+                        "name": "target_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From (RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To (RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_ForgotPasswordResponse"
+                            "$ref": "#/definitions/web.WebSuccess-array_response_AuditEventResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/web.WebNotFoundError"
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
                         }
                     },
                     "500": {
@@ -58,32 +117,71 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/login": {
-            "post": {
-                "description": "Logging in to get jwt token to access admin or user api by roles.",
+        "/api/admin/audit/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Export the matching audit events as newline delimited JSON, admin only.",
                 "produces": [
-                    "application/json"
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "User login.",
+                "summary": "Export audit log.",
                 "parameters": [
                     {
-                        "description": "the body to login a user",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.LoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "Actor user ID",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action, e.g. car.update",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target type, e.g. car",
+                        "name": "target_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Target ID",
+                        "name": "target_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From (RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To (RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_LoginResponse"
+                            "$ref": "#/definitions/response.AuditEventResponse"
                         }
                     },
                     "400": {
@@ -92,10 +190,10 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebBadRequestError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                            "$ref": "#/definitions/web.WebForbiddenError"
                         }
                     },
                     "500": {
@@ -107,32 +205,73 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/register": {
+        "/api/admin/cars/import": {
             "post": {
-                "description": "Registering a user from public access.",
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Import cars from a CSV or NDJSON file whose columns match the create car body, plus an optional brand column with the brand name. Cars are upserted on brand, name, model and year. Large files are imported in the background, poll the returned job. Admin only.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "User register.",
+                "summary": "Import cars.",
                 "parameters": [
                     {
-                        "description": "the body to register a user",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.RegisterRequest"
-                        }
+                        "type": "file",
+                        "description": "CSV or NDJSON file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "File format, guessed from the file name when empty",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate and report what would happen",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Create brands that do not exist",
+                        "name": "create_brands",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_RegisterResponse"
+                            "$ref": "#/definitions/web.WebSuccess-response_ImportJobResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_ImportJobResponse"
                         }
                     },
                     "400": {
@@ -141,6 +280,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebBadRequestError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -150,32 +295,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/reset-password": {
-            "post": {
-                "description": "Reset password.",
+        "/api/admin/cars/import/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get the progress and the per-row report of a car import, admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Reset password.",
+                "summary": "Get an import job.",
                 "parameters": [
                     {
-                        "description": "the body to reset password",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.ResetPasswordRequest"
-                        }
+                        "type": "integer",
+                        "description": "Import job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-string"
+                            "$ref": "#/definitions/web.WebSuccess-response_ImportJobResponse"
                         }
                     },
                     "400": {
@@ -184,6 +339,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebBadRequestError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -199,68 +360,76 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/brands": {
+        "/api/admin/export/cars": {
             "get": {
-                "description": "Find all brand.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Brands"
-                ],
-                "summary": "Find all brand.",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-array_response_BrandResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/web.WebInternalServerError"
-                        }
-                    }
-                }
-            },
-            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Create a brand.",
+                "description": "Stream every matching car as CSV or NDJSON, admin only.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "Brands"
+                    "Admin"
                 ],
-                "summary": "Create brand.",
+                "summary": "Export cars.",
                 "parameters": [
                     {
-                        "description": "the body to create a brand",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.BrandRequest"
-                        }
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Export format, defaults to the Accept header or csv",
+                        "name": "format",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
-                        "name": "Authorization",
-                        "in": "header",
+                        "type": "integer",
+                        "description": "Brand ID",
+                        "name": "brand_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Model",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum Year",
+                        "name": "min_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum Year",
+                        "name": "max_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_BrandResponse"
+                            "$ref": "#/definitions/response.CarExportResponse"
                         }
                     },
                     "400": {
@@ -284,28 +453,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/brands/{id}": {
-            "delete": {
+        "/api/admin/export/comments": {
+            "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Delete a brand.",
+                "description": "Stream every matching comment as CSV or NDJSON, admin only.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "Brands"
+                    "Admin"
                 ],
-                "summary": "Delete brand.",
+                "summary": "Export comments.",
                 "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Export format, defaults to the Accept header or csv",
+                        "name": "format",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "brand ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Review ID",
+                        "name": "review_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query"
                     },
                     {
                         "type": "string",
@@ -319,7 +504,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-string"
+                            "$ref": "#/definitions/response.CommentExportResponse"
                         }
                     },
                     "400": {
@@ -334,12 +519,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebForbiddenError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/web.WebNotFoundError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -347,37 +526,46 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/api/admin/export/reviews": {
+            "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Update a brand.",
+                "description": "Stream every matching review as CSV or NDJSON, admin only.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "Brands"
+                    "Admin"
                 ],
-                "summary": "Update brand.",
+                "summary": "Export reviews.",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "brand ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Export format, defaults to the Accept header or csv",
+                        "name": "format",
+                        "in": "query"
                     },
                     {
-                        "description": "the body to update a brand",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.BrandRequest"
-                        }
+                        "type": "string",
+                        "description": "Title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Car ID",
+                        "name": "car_id",
+                        "in": "query"
                     },
                     {
                         "type": "string",
@@ -391,7 +579,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_BrandResponse"
+                            "$ref": "#/definitions/response.ReviewExportResponse"
                         }
                     },
                     "400": {
@@ -406,12 +594,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebForbiddenError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/web.WebNotFoundError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -421,16 +603,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/cars": {
+        "/api/admin/trash/brands": {
             "get": {
-                "description": "Find all car.",
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "List deleted records, newest first, with the time they will be purged permanently. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Cars"
+                    "Admin"
                 ],
-                "summary": "Find all car.",
+                "summary": "Find trash.",
                 "parameters": [
                     {
                         "type": "integer",
@@ -446,42 +633,31 @@ const docTemplate = `{
                         "name": "page",
                         "in": "query"
                     },
-                    {
-                        "type": "integer",
-                        "description": "Brand ID",
-                        "name": "brand_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Name",
-                        "name": "name",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Model",
-                        "name": "model",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Minimum Year",
-                        "name": "min_year",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Maximum Year",
-                        "name": "max_year",
-                        "in": "query"
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-array_response_CarResponse"
+                            "$ref": "#/definitions/web.WebSuccess-array_response_TrashItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
                         }
                     },
                     "500": {
@@ -491,30 +667,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/admin/trash/cars": {
+            "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Create a car.",
+                "description": "List deleted records, newest first, with the time they will be purged permanently. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Cars"
+                    "Admin"
                 ],
-                "summary": "Create car.",
+                "summary": "Find trash.",
                 "parameters": [
                     {
-                        "description": "the body to create a car",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CarCreateRequest"
-                        }
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
                         "type": "string",
@@ -525,10 +708,10 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_CarResponse"
+                            "$ref": "#/definitions/web.WebSuccess-array_response_TrashItemResponse"
                         }
                     },
                     "400": {
@@ -552,22 +735,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/cars/{id}": {
+        "/api/admin/trash/comments": {
             "get": {
-                "description": "Find a car by id.",
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "List deleted records, newest first, with the time they will be purged permanently. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Cars"
+                    "Admin"
                 ],
-                "summary": "Find car.",
+                "summary": "Find trash.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Car ID",
-                        "name": "id",
-                        "in": "path",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
                     }
                 ],
@@ -575,13 +777,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_CarResponse"
+                            "$ref": "#/definitions/web.WebSuccess-array_response_TrashItemResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/web.WebNotFoundError"
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
                         }
                     },
                     "500": {
@@ -591,42 +799,51 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/admin/trash/reviews": {
+            "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Delete a car.",
+                "description": "List deleted records, newest first, with the time they will be purged permanently. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Cars"
+                    "Admin"
                 ],
-                "summary": "Delete car.",
+                "summary": "Find trash.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Car ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-string"
+                            "$ref": "#/definitions/web.WebSuccess-array_response_TrashItemResponse"
                         }
                     },
                     "400": {
@@ -641,12 +858,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebForbiddenError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/web.WebNotFoundError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -654,37 +865,78 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/api/admin/users": {
+            "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Update a car.",
+                "description": "Search and filter users, admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Cars"
+                    "Admin"
                 ],
-                "summary": "Update car.",
+                "summary": "Search users.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Car ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "description": "the body to update a car",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CarUpdateRequest"
-                        }
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search username or email",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ADMIN",
+                            "MODERATOR",
+                            "USER"
+                        ],
+                        "type": "string",
+                        "description": "Role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Email verified",
+                        "name": "verified",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Currently banned",
+                        "name": "banned",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created from (RFC3339)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created to (RFC3339)",
+                        "name": "created_to",
+                        "in": "query"
                     },
                     {
                         "type": "string",
@@ -698,7 +950,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_CarResponse"
+                            "$ref": "#/definitions/web.WebSuccess-array_response_AdminUserResponse"
                         }
                     },
                     "400": {
@@ -713,12 +965,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebForbiddenError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/web.WebNotFoundError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -728,30 +974,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/comments": {
-            "post": {
+        "/api/admin/users/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Create a comment.",
+                "description": "Get a user with its account status, admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Admin"
                 ],
-                "summary": "Create comment.",
+                "summary": "Get a user.",
                 "parameters": [
                     {
-                        "description": "the body to create a comment",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CommentCreateRequest"
-                        }
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
@@ -762,10 +1006,10 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_CommentResponse"
+                            "$ref": "#/definitions/web.WebSuccess-response_AdminUserResponse"
                         }
                     },
                     "400": {
@@ -774,6 +1018,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebBadRequestError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -783,29 +1039,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/comments/{id}": {
-            "delete": {
+        "/api/admin/users/{id}/ban": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Delete a comment.",
+                "description": "Suspend a user until the given expiry, or permanently without one. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Admin"
                 ],
-                "summary": "Delete comment.",
+                "summary": "Ban a user.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Comment ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "description": "the body to ban the user",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AdminBanUserRequest"
+                        }
+                    },
                     {
                         "type": "string",
                         "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
@@ -815,10 +1080,10 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-string"
+                            "$ref": "#/definitions/web.WebSuccess-response_AdminUserResponse"
                         }
                     },
                     "400": {
@@ -827,6 +1092,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebBadRequestError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -841,37 +1112,28 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
+            "delete": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Update a comment.",
+                "description": "Lift the ban of a user, admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Admin"
                 ],
-                "summary": "Update comment.",
+                "summary": "Unban a user.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Comment ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "description": "the body to update a comment",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CommentUpdateRequest"
-                        }
-                    },
                     {
                         "type": "string",
                         "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
@@ -881,10 +1143,10 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_CommentResponse"
+                            "$ref": "#/definitions/web.WebSuccess-response_AdminUserResponse"
                         }
                     },
                     "400": {
@@ -893,6 +1155,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebBadRequestError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -908,26 +1176,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/favourites/{carID}": {
+        "/api/admin/users/{id}/force-password-reset": {
             "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Favourite a car.",
+                "description": "Log the user out everywhere and block logins until the password is reset. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Favourites"
+                    "Admin"
                 ],
-                "summary": "Favourite a car.",
+                "summary": "Force a password reset.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "car ID",
-                        "name": "carID",
+                        "description": "User ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
@@ -940,10 +1208,10 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-string"
+                            "$ref": "#/definitions/web.WebSuccess-response_AdminUserResponse"
                         }
                     },
                     "400": {
@@ -952,6 +1220,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebBadRequestError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -959,26 +1239,28 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/admin/users/{id}/impersonate": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Unfavourite a car.",
+                "description": "Get a token acting as the user for support, actions taken with it are audited with the admin as impersonator. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Favourites"
+                    "Admin"
                 ],
-                "summary": "Unfavourite a car.",
+                "summary": "Impersonate a user.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "car ID",
-                        "name": "carID",
+                        "description": "User ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
@@ -991,10 +1273,10 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-string"
+                            "$ref": "#/definitions/web.WebSuccess-response_LoginResponse"
                         }
                     },
                     "400": {
@@ -1003,6 +1285,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebBadRequestError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1012,49 +1306,121 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/reviews": {
-            "get": {
-                "description": "Find all review.",
+        "/api/admin/users/{id}/role": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Promote or demote a user, the user is logged out everywhere. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Admin"
                 ],
-                "summary": "Find all review.",
+                "summary": "Change a user's role.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "default": 10,
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Title",
-                        "name": "title",
-                        "in": "query"
+                        "description": "the body to change the role",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AdminUpdateRoleRequest"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "Car ID",
-                        "name": "car_id",
-                        "in": "query"
-                    }
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-array_response_FindReviewResponse"
+                            "$ref": "#/definitions/web.WebSuccess-response_AdminUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "List the webhooks, without their secrets. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List webhooks.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_WebhookResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
                         }
                     },
                     "500": {
@@ -1071,22 +1437,22 @@ const docTemplate = `{
                         "BearerToken": []
                     }
                 ],
-                "description": "Create a review.",
+                "description": "Register a URL to receive car.created, car.updated, car.deleted, brand.created, brand.updated, brand.deleted and review.published events. Each delivery is a POST of {\"event\", \"created_at\", \"data\"} signed in the X-Webhook-Signature header as sha256=\u003chex HMAC-SHA256 of X-Webhook-Timestamp + \".\" + body\u003e, keyed with the secret. The URL must only resolve to public addresses. The secret is generated when omitted and only returned here. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Admin"
                 ],
-                "summary": "Create review.",
+                "summary": "Create a webhook.",
                 "parameters": [
                     {
-                        "description": "the body to create a review",
-                        "name": "Body",
+                        "description": "the body to create a webhook",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.ReviewCreateRequest"
+                            "$ref": "#/definitions/request.WebhookCreateRequest"
                         }
                     },
                     {
@@ -1101,7 +1467,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_ReviewResponse"
+                            "$ref": "#/definitions/web.WebSuccess-response_WebhookResponse"
                         }
                     },
                     "400": {
@@ -1110,10 +1476,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebBadRequestError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/web.WebNotFoundError"
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
                         }
                     },
                     "500": {
@@ -1125,30 +1497,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/reviews/{id}": {
+        "/api/admin/webhooks/{id}": {
             "get": {
-                "description": "Find a review by id.",
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get a webhook without its secret, admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Admin"
                 ],
-                "summary": "Find review.",
+                "summary": "Get a webhook.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Review ID",
+                        "description": "Webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_FindReviewResponse"
+                            "$ref": "#/definitions/web.WebSuccess-response_WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
                         }
                     },
                     "404": {
@@ -1171,18 +1573,18 @@ const docTemplate = `{
                         "BearerToken": []
                     }
                 ],
-                "description": "Delete a review.",
+                "description": "Delete a webhook with its delivery log, admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Admin"
                 ],
-                "summary": "Delete review.",
+                "summary": "Delete a webhook.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Review ID",
+                        "description": "Webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1208,6 +1610,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebBadRequestError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1228,29 +1642,29 @@ const docTemplate = `{
                         "BearerToken": []
                     }
                 ],
-                "description": "Update a review.",
+                "description": "Change the URL, events, secret, description or active flag of a webhook, the deliveries of an inactive webhook fail. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Admin"
                 ],
-                "summary": "Update review.",
+                "summary": "Update a webhook.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Review ID",
+                        "description": "Webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "the body to update a review",
-                        "name": "Body",
+                        "description": "the body to update a webhook",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.ReviewUpdateRequest"
+                            "$ref": "#/definitions/request.WebhookUpdateRequest"
                         }
                     },
                     {
@@ -1265,7 +1679,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_ReviewResponse"
+                            "$ref": "#/definitions/web.WebSuccess-response_WebhookResponse"
                         }
                     },
                     "400": {
@@ -1274,6 +1688,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebBadRequestError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1289,30 +1715,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/reviews/{id}/comments": {
+        "/api/admin/webhooks/{id}/deliveries": {
             "get": {
-                "description": "Find a comment by review id.",
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "The delivery log of a webhook, newest first, with the payload, attempts and the last response. Failed attempts are retried with exponential backoff until WEBHOOK_MAX_ATTEMPTS. Admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reviews"
+                    "Admin"
                 ],
-                "summary": "Find comment by review id.",
+                "summary": "List the deliveries of a webhook.",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Review ID",
+                        "description": "Webhook ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-array_response_CommentResponse"
+                            "$ref": "#/definitions/web.WebSuccess-array_response_WebhookDeliveryResponse"
                         }
                     },
                     "400": {
@@ -1321,6 +1784,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebBadRequestError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1336,22 +1811,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users": {
-            "delete": {
+        "/api/admin/webhooks/{id}/deliveries/{deliveryID}/redeliver": {
+            "post": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Delete a user profile by ID.",
+                "description": "Queue the payload of a delivery again as a new delivery, admin only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Delete user.",
+                "summary": "Redeliver a webhook delivery.",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "deliveryID",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
@@ -1361,10 +1850,10 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-string"
+                            "$ref": "#/definitions/web.WebSuccess-response_WebhookDeliveryResponse"
                         }
                     },
                     "400": {
@@ -1373,6 +1862,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebBadRequestError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1382,41 +1889,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/current": {
-            "get": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Get current user.",
+        "/api/auth/forgot-password": {
+            "post": {
+                "description": "Request forgot password.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Auth"
                 ],
-                "summary": "Get current user.",
+                "summary": "Forgot password.",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "the body to request forgot password",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ForgotPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_GetUserCurrentResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/web.WebBadRequestError"
+                            "$ref": "#/definitions/web.WebSuccess-response_ForgotPasswordResponse"
                         }
                     },
                     "404": {
@@ -1434,35 +1932,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/favourites": {
-            "get": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Get user profile data.",
+        "/api/auth/login": {
+            "post": {
+                "description": "Logging in to get jwt token to access admin or user api by roles.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Auth"
                 ],
-                "summary": "Get user favourites.",
+                "summary": "User login.",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "the body to login a user",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.LoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_FavouriteResponse"
+                            "$ref": "#/definitions/web.WebSuccess-response_LoginResponse"
                         }
                     },
                     "400": {
@@ -1471,10 +1966,10 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebBadRequestError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/web.WebNotFoundError"
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
                         }
                     },
                     "500": {
@@ -1486,36 +1981,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/password": {
-            "patch": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Update the current user's password.",
+        "/api/auth/oidc/{provider}/callback": {
+            "get": {
+                "description": "Complete the OpenID Connect login and get jwt token. Accounts are linked by verified email.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Auth"
                 ],
-                "summary": "Update user password.",
+                "summary": "OIDC login callback.",
                 "parameters": [
                     {
-                        "description": "the body to update a password",
-                        "name": "Body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UpdatePasswordRequest"
-                        }
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State",
+                        "name": "state",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -1523,7 +2018,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-string"
+                            "$ref": "#/definitions/web.WebSuccess-response_LoginResponse"
                         }
                     },
                     "400": {
@@ -1532,6 +2027,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/web.WebBadRequestError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1541,44 +2054,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/profile": {
-            "patch": {
-                "security": [
+        "/api/auth/oidc/{provider}/start": {
+            "get": {
+                "description": "Redirect to the OpenID Connect provider to sign in using authorization code + PKCE.",
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Start OIDC login.",
+                "parameters": [
                     {
-                        "BearerToken": []
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "description": "Update the profile of a user.",
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/register": {
+            "post": {
+                "description": "Registering a user from public access.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Auth"
                 ],
-                "summary": "Update user profile.",
+                "summary": "User register.",
                 "parameters": [
                     {
-                        "description": "the body to update user profile",
+                        "description": "the body to register a user",
                         "name": "Body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateUserProfileRequest"
+                            "$ref": "#/definitions/request.RegisterRequest"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_UpdateUserProfileResponse"
+                            "$ref": "#/definitions/web.WebSuccess-response_RegisterResponse"
                         }
                     },
                     "400": {
@@ -1596,30 +2132,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/profile/{id}": {
-            "get": {
-                "description": "Get user profile data.",
+        "/api/auth/reset-password": {
+            "post": {
+                "description": "Reset password.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Auth"
                 ],
-                "summary": "Get user profile.",
+                "summary": "Reset password.",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "the body to reset password",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ResetPasswordRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-response_UserProfileResponse"
+                            "$ref": "#/definitions/web.WebSuccess-string"
                         }
                     },
                     "400": {
@@ -1643,56 +2181,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/{id}/reviews": {
+        "/api/brands": {
             "get": {
-                "description": "Get user profile data.",
+                "description": "Find all brand.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
-                ],
-                "summary": "Get user reviews.",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Brands"
                 ],
+                "summary": "Find all brand.",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/web.WebSuccess-array_response_FindReviewResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/web.WebBadRequestError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/web.WebNotFoundError"
+                            "$ref": "#/definitions/web.WebSuccess-array_response_BrandResponse"
                         }
                     },
                     "500": {
@@ -1702,26 +2205,6185 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "request.BrandRequest": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "name": {
-                    "type": "string",
-                    "maxLength": 100
-                }
-            }
-        },
-        "request.CarCreateRequest": {
-            "type": "object",
-            "required": [
-                "acceleration",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Create a brand.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Brands"
+                ],
+                "summary": "Create brand.",
+                "parameters": [
+                    {
+                        "description": "the body to create a brand",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BrandRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_BrandResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/brands/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a brand.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Brands"
+                ],
+                "summary": "Delete brand.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update a brand.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Brands"
+                ],
+                "summary": "Update brand.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the body to update a brand",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BrandRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_BrandResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/brands/{id}/follow": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Follow a user, a brand or a car, their new reviews show up in GET /api/feed. Following a user counts on both profiles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follows"
+                ],
+                "summary": "Follow a user, a brand or a car.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User, brand or car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Stop following a user, a brand or a car.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follows"
+                ],
+                "summary": "Unfollow a user, a brand or a car.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User, brand or car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/brands/{id}/followers": {
+            "get": {
+                "description": "The users following a user, a brand or a car, latest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follows"
+                ],
+                "summary": "Find followers.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User, brand or car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_FollowResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/brands/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Restore a deleted record. Restoring a review restores the comments deleted with it, a record whose parent is deleted needs the parent restored first. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Restore.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/brands/{id}/watch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get notified of the new reviews and the spec changes of a car, or of every car of a brand. The car or brand is followed too.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watches"
+                ],
+                "summary": "Watch a car or a brand.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Stop the notifications of a car or a brand, it stays followed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watches"
+                ],
+                "summary": "Unwatch a car or a brand.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cars": {
+            "get": {
+                "description": "Find all car.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Cars"
+                ],
+                "summary": "Find all car.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Brand ID",
+                        "name": "brand_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Model",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum Year",
+                        "name": "min_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum Year",
+                        "name": "max_year",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "json, or csv / ndjson to stream every matching car without pagination, also negotiated with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_CarResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Create a car.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cars"
+                ],
+                "summary": "Create car.",
+                "parameters": [
+                    {
+                        "description": "the body to create a car",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CarCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_CarResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cars/{id}": {
+            "get": {
+                "description": "Find a car by id.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cars"
+                ],
+                "summary": "Find car.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_CarResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a car.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cars"
+                ],
+                "summary": "Delete car.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update a car.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cars"
+                ],
+                "summary": "Update car.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the body to update a car",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CarUpdateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_CarResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cars/{id}/follow": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Follow a user, a brand or a car, their new reviews show up in GET /api/feed. Following a user counts on both profiles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follows"
+                ],
+                "summary": "Follow a user, a brand or a car.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User, brand or car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Stop following a user, a brand or a car.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follows"
+                ],
+                "summary": "Unfollow a user, a brand or a car.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User, brand or car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cars/{id}/followers": {
+            "get": {
+                "description": "The users following a user, a brand or a car, latest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follows"
+                ],
+                "summary": "Find followers.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User, brand or car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_FollowResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cars/{id}/images": {
+            "get": {
+                "description": "Find the images of a car or a review in display order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Galleries"
+                ],
+                "summary": "Find gallery.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_GalleryImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Append an image to the gallery of a car (admin only) or of your review. Returns the whole gallery.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Galleries"
+                ],
+                "summary": "Add gallery image.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the body to add an image",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GalleryImageCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_GalleryImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cars/{id}/images/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Set the display order, image_ids must list every image of the gallery once.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Galleries"
+                ],
+                "summary": "Reorder gallery.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the new order",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GalleryReorderRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_GalleryImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cars/{id}/images/{image_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Remove an image, when it was the cover the first remaining image becomes the cover. Returns the whole gallery.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Galleries"
+                ],
+                "summary": "Remove gallery image.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image ID",
+                        "name": "image_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_GalleryImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Change the caption of an image or make it the cover. Returns the whole gallery.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Galleries"
+                ],
+                "summary": "Update gallery image.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image ID",
+                        "name": "image_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the body to update an image",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GalleryImageUpdateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_GalleryImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cars/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Restore a deleted record. Restoring a review restores the comments deleted with it, a record whose parent is deleted needs the parent restored first. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Restore.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cars/{id}/revisions": {
+            "get": {
+                "description": "List the edits of a car or a review, newest first, with who made them and the snapshots before and after.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Find revisions.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_RevisionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cars/{id}/revisions/diff": {
+            "get": {
+                "description": "Compare the state after revision from with the state after revision to, field by field. Revision 0 is the state before the first edit.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Diff revisions.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "From revision",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "To revision",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_RevisionDiffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cars/{id}/revisions/{number}": {
+            "get": {
+                "description": "Find one edit of a car or a review by its number, counted from 1.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Find revision.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_RevisionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/cars/{id}/watch": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get notified of the new reviews and the spec changes of a car, or of every car of a brand. The car or brand is followed too.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watches"
+                ],
+                "summary": "Watch a car or a brand.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Stop the notifications of a car or a brand, it stays followed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watches"
+                ],
+                "summary": "Unwatch a car or a brand.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or brand ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/collections": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Create a named collection of favourite cars, private by default.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Create a collection.",
+                "parameters": [
+                    {
+                        "description": "the body to create a collection",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CollectionCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/collections/{slug}": {
+            "get": {
+                "description": "Get a public or unlisted collection by its slug. Private collections are only found by their owner.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Get a collection.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_CollectionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a collection with its items. The default collection cannot be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Delete a collection.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Rename a collection or change its visibility, the slug does not change.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Update a collection.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the body to update a collection",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CollectionUpdateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/collections/{slug}/items": {
+            "get": {
+                "description": "The cars of a collection with their notes, in their manual order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Get the cars of a collection.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_CollectionItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Add a car with an optional note at the end of a collection.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Add a car to a collection.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the body to add a car",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CollectionItemCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_CollectionItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/collections/{slug}/items/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Set the order of the cars of a collection, car_ids lists every car of the collection once.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Reorder a collection.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the body to reorder a collection",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CollectionReorderRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_CollectionItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/collections/{slug}/items/{carID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Remove a car from a collection, the other cars keep their order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Remove a car from a collection.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Car ID",
+                        "name": "carID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Change or clear the note of a car in a collection.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Update the note of a car in a collection.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Car ID",
+                        "name": "carID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the body to update the note",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CollectionItemUpdateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_CollectionItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comments": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Create a comment.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Create comment.",
+                "parameters": [
+                    {
+                        "description": "the body to create a comment",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CommentCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comments/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a comment.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Delete comment.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update a comment.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Update comment.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the body to update a comment",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CommentUpdateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comments/{id}/reactions/{emoji}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "React to a comment with one of the emojis of COMMENT_REACTIONS, by default 👍 ❤️ 😂 😮 😢. Reacting twice with the same emoji changes nothing. Returns the reactions of the comment.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "React comment.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Emoji, URL encoded",
+                        "name": "emoji",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_CommentReactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Remove your reaction from a comment. Returns the reactions of the comment.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Unreact comment.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Comment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Emoji, URL encoded",
+                        "name": "emoji",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_CommentReactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comments/{id}/report": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Report a review or a comment to the moderators, once per user. Content with enough open reports is hidden until a moderator decides.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Report.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review or comment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the reason of the report",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReportCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_ReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/comments/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Restore a deleted record. Restoring a review restores the comments deleted with it, a record whose parent is deleted needs the parent restored first. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Restore.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/favourites/{carID}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Favourite a car.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Favourites"
+                ],
+                "summary": "Favourite a car.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "car ID",
+                        "name": "carID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Unfavourite a car.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Favourites"
+                ],
+                "summary": "Unfavourite a car.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "car ID",
+                        "name": "carID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/feed": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "The published reviews by the users, and of the brands and cars, that the current user follows, latest first. Pass metadata.next_cursor as cursor for the next page, there is none on the last page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Personal feed.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit, at most 50",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_FindReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/media": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Upload a JPEG, PNG or GIF image. The type is sniffed from the content, metadata such as EXIF is stripped and thumbnails are generated. Use the returned id as media_id of a car or a review.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Upload media.",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_MediaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/media/{id}": {
+            "get": {
+                "description": "Find media and its variants by id.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Find media.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Media ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_MediaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete media of the current user, admins may delete any. Media used by a car or a review cannot be deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Delete media.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Media ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/media/{id}/{variant}": {
+            "get": {
+                "description": "Download a variant of the media, original or thumb_\u003csize\u003e.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Media file.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Media ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "original",
+                        "description": "Variant name",
+                        "name": "variant",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/moderation/queue": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "List the reviews and comments waiting for a moderator, pending ones and those with open reports, the most reported first. Moderators only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Moderation queue.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "reviews",
+                            "comments"
+                        ],
+                        "type": "string",
+                        "description": "Content type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "published",
+                            "hidden",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_ModerationQueueItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/moderation/{type}/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Approve, hide or reject a review or a comment with a note, closing its open reports. Moderators only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Moderate.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "reviews",
+                            "comments"
+                        ],
+                        "type": "string",
+                        "description": "Content type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Review or comment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the decision",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ModerationDecisionRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_ModerationDecisionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews": {
+            "get": {
+                "description": "Find all review.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Find all review.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Car ID",
+                        "name": "car_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "oldest",
+                            "helpful",
+                            "rating"
+                        ],
+                        "type": "string",
+                        "description": "newest (default), oldest, helpful by the Wilson score of the votes, or rating by helpful minus unhelpful votes",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "json, or csv / ndjson to stream every matching review without pagination, also negotiated with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_FindReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Create a review.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Create review.",
+                "parameters": [
+                    {
+                        "description": "the body to create a review",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReviewCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews/{id}": {
+            "get": {
+                "description": "Find a review by id.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Find review.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_FindReviewResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a review.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Delete review.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update a review.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Update review.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the body to update a review",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReviewUpdateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_ReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews/{id}/comments": {
+            "get": {
+                "description": "Find a comment by review id.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Find comment by review id.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "json, or csv / ndjson to stream the comments, also negotiated with the Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews/{id}/images": {
+            "get": {
+                "description": "Find the images of a car or a review in display order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Galleries"
+                ],
+                "summary": "Find gallery.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_GalleryImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Append an image to the gallery of a car (admin only) or of your review. Returns the whole gallery.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Galleries"
+                ],
+                "summary": "Add gallery image.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the body to add an image",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GalleryImageCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_GalleryImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews/{id}/images/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Set the display order, image_ids must list every image of the gallery once.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Galleries"
+                ],
+                "summary": "Reorder gallery.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the new order",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GalleryReorderRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_GalleryImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews/{id}/images/{image_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Remove an image, when it was the cover the first remaining image becomes the cover. Returns the whole gallery.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Galleries"
+                ],
+                "summary": "Remove gallery image.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image ID",
+                        "name": "image_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_GalleryImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Change the caption of an image or make it the cover. Returns the whole gallery.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Galleries"
+                ],
+                "summary": "Update gallery image.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image ID",
+                        "name": "image_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the body to update an image",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.GalleryImageUpdateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_GalleryImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews/{id}/report": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Report a review or a comment to the moderators, once per user. Content with enough open reports is hidden until a moderator decides.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Moderation"
+                ],
+                "summary": "Report.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review or comment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the reason of the report",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReportCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_ReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Restore a deleted record. Restoring a review restores the comments deleted with it, a record whose parent is deleted needs the parent restored first. Admin only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Restore.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews/{id}/revisions": {
+            "get": {
+                "description": "List the edits of a car or a review, newest first, with who made them and the snapshots before and after.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Find revisions.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_RevisionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews/{id}/revisions/diff": {
+            "get": {
+                "description": "Compare the state after revision from with the state after revision to, field by field. Revision 0 is the state before the first edit.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Diff revisions.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "From revision",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "To revision",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_RevisionDiffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews/{id}/revisions/{number}": {
+            "get": {
+                "description": "Find one edit of a car or a review by its number, counted from 1.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Find revision.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Car or review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_RevisionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews/{id}/vote": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Vote a review helpful or unhelpful, one vote per user, voting again changes it. Sort reviews with sort=helpful to rank them by their votes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Vote review.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "the vote",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ReviewVoteRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_ReviewVoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Remove your vote on a review.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Unvote review.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_ReviewVoteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Server-Sent Events of the subscribed topics. review:\u003cid\u003e pushes comment.created, comment.updated and comment.deleted for the comments of a review, user:me pushes notification.created for the notifications of the current user and needs a token, in the Authorization header or the token query parameter for EventSource. Each event has an id and its data is {\"topic\": ..., \"data\": ...}, a client that reconnects with the Last-Event-ID header (or last_event_id) first gets the events it missed, with the few stored just before the last one again since they may have been committed late, and drops the IDs it already has. A comment line is sent every STREAM_HEARTBEAT_SECONDS to keep the connection open.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Stream"
+                ],
+                "summary": "Stream real-time updates.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "review:12,user:me",
+                        "description": "Comma separated topics",
+                        "name": "topics",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resume after this event",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resume after this event, sent by EventSource on reconnect",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebForbiddenError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Delete a user profile by ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete user.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/current": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get current user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get current user.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_GetUserCurrentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/favourites": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get the cars of the default collection of the current user, in their manual order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user favourites.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_FavouriteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get the notifications of the current user, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user notifications.",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only unread (true) or read (false) notifications",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_NotificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/notifications/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get whether each notification type is on for the current user, every type is on by default.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get notification preferences.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_NotificationPreferenceResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Turn notification types on or off for the current user, the types left out keep their setting. Types: review_comment, comment_reply, mention, favourite_review, moderation.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update notification preferences.",
+                "parameters": [
+                    {
+                        "description": "the body to update notification preferences",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.NotificationPreferenceRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_NotificationPreferenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/notifications/read-all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Mark every unread notification of the current user as read.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Mark all notifications as read.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_NotificationReadAllResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/notifications/{id}/read": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Mark one notification of the current user as read, a notification read before keeps its read date.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Mark a notification as read.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_NotificationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/password": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update the current user's password. The current password is required and the new one must satisfy the password policy.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user password.",
+                "parameters": [
+                    {
+                        "description": "the body to update a password",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdatePasswordRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/profile": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update the profile of a user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user profile.",
+                "parameters": [
+                    {
+                        "description": "the body to update user profile",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateUserProfileRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_UpdateUserProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/profile/{id}": {
+            "get": {
+                "description": "Get user profile data.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user profile.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_UserProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get the active sessions of the current user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user sessions.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_SessionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Revoke every session of the current user, including the current one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Log out everywhere.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Log out a session of the current user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Revoke a session.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/watches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "The cars and brands the current user watches, latest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watches"
+                ],
+                "summary": "Get the watch list.",
+                "parameters": [
+                    {
+                        "enum": [
+                            "cars",
+                            "brands"
+                        ],
+                        "type": "string",
+                        "description": "Only cars or brands",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_WatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/watches/digest": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "How often the current user gets their watch notifications by email, off by default.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watches"
+                ],
+                "summary": "Get the watch digest setting.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_WatchDigestResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Get the unread watch notifications by email daily or weekly, or turn the digest off. The first digest comes a period after it is turned on.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Watches"
+                ],
+                "summary": "Update the watch digest setting.",
+                "parameters": [
+                    {
+                        "description": "the body to update the watch digest",
+                        "name": "Body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.WatchDigestRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-response_WatchDigestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/collections": {
+            "get": {
+                "description": "The public collections of a user, or all of them for the user themselves. The default collection comes first, then the latest.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Collections"
+                ],
+                "summary": "Get the collections of a user.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/follow": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Follow a user, a brand or a car, their new reviews show up in GET /api/feed. Following a user counts on both profiles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follows"
+                ],
+                "summary": "Follow a user, a brand or a car.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User, brand or car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Stop following a user, a brand or a car.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follows"
+                ],
+                "summary": "Unfollow a user, a brand or a car.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User, brand or car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization. How to input in swagger : 'Bearer \u003cinsert_your_token_here\u003e'",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebUnauthorizedError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/followers": {
+            "get": {
+                "description": "The users following a user, a brand or a car, latest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follows"
+                ],
+                "summary": "Find followers.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User, brand or car ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_FollowResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/following": {
+            "get": {
+                "description": "The users, brands or cars a user follows, latest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Follows"
+                ],
+                "summary": "Find following.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "users",
+                            "brands",
+                            "cars"
+                        ],
+                        "type": "string",
+                        "default": "users",
+                        "description": "What is followed",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_FollowResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/reviews": {
+            "get": {
+                "description": "Get user profile data.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user reviews.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebSuccess-array_response_FindReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebBadRequestError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebNotFoundError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/web.WebInternalServerError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "request.AdminBanUserRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "x-order": "0",
+                    "example": "Spam"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "2030-01-01T00:00:00Z"
+                }
+            }
+        },
+        "request.AdminUpdateRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "ADMIN",
+                        "MODERATOR",
+                        "USER"
+                    ],
+                    "example": "ADMIN"
+                }
+            }
+        },
+        "request.BrandRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "request.CarCreateRequest": {
+            "type": "object",
+            "required": [
+                "acceleration",
                 "brand_id",
                 "breaking_system_back",
                 "breaking_system_front",
@@ -1729,7 +8391,6 @@ const docTemplate = `{
                 "fuel",
                 "height",
                 "horse_power",
-                "image_url",
                 "length",
                 "model",
                 "name",
@@ -1739,899 +8400,3527 @@ const docTemplate = `{
                 "year"
             ],
             "properties": {
-                "brand_id": {
+                "brand_id": {
+                    "type": "integer",
+                    "x-order": "0"
+                },
+                "name": {
+                    "type": "string",
+                    "x-order": "1"
+                },
+                "model": {
+                    "type": "string",
+                    "x-order": "1"
+                },
+                "acceleration": {
+                    "type": "number",
+                    "x-order": "10"
+                },
+                "horse_power": {
+                    "type": "integer",
+                    "x-order": "11"
+                },
+                "breaking_system_front": {
+                    "type": "string",
+                    "x-order": "12"
+                },
+                "breaking_system_back": {
+                    "type": "string",
+                    "x-order": "13"
+                },
+                "fuel": {
+                    "type": "string",
+                    "x-order": "14"
+                },
+                "year": {
+                    "type": "integer",
+                    "minimum": 1878,
+                    "x-order": "2"
+                },
+                "image_url": {
+                    "type": "string",
+                    "x-order": "3"
+                },
+                "media_id": {
+                    "type": "integer",
+                    "x-order": "3"
+                },
+                "width": {
+                    "type": "integer",
+                    "x-order": "4"
+                },
+                "height": {
+                    "type": "integer",
+                    "x-order": "5"
+                },
+                "length": {
+                    "type": "integer",
+                    "x-order": "6"
+                },
+                "engine": {
+                    "type": "string",
+                    "x-order": "7"
+                },
+                "torque": {
+                    "type": "integer",
+                    "x-order": "8"
+                },
+                "transmission": {
+                    "type": "string",
+                    "x-order": "9"
+                }
+            }
+        },
+        "request.CarUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "brand_id": {
+                    "type": "integer",
+                    "x-order": "0"
+                },
+                "model": {
+                    "type": "string",
+                    "x-order": "1"
+                },
+                "name": {
+                    "type": "string",
+                    "x-order": "1"
+                },
+                "acceleration": {
+                    "type": "number",
+                    "x-order": "10"
+                },
+                "horse_power": {
+                    "type": "integer",
+                    "x-order": "11"
+                },
+                "breaking_system_front": {
+                    "type": "string",
+                    "x-order": "12"
+                },
+                "breaking_system_back": {
+                    "type": "string",
+                    "x-order": "13"
+                },
+                "fuel": {
+                    "type": "string",
+                    "x-order": "14"
+                },
+                "year": {
+                    "type": "integer",
+                    "minimum": 1878,
+                    "x-order": "2"
+                },
+                "media_id": {
+                    "type": "integer",
+                    "x-order": "3"
+                },
+                "image_url": {
+                    "type": "string",
+                    "x-order": "3"
+                },
+                "width": {
+                    "type": "integer",
+                    "x-order": "4"
+                },
+                "height": {
+                    "type": "integer",
+                    "x-order": "5"
+                },
+                "length": {
+                    "type": "integer",
+                    "x-order": "6"
+                },
+                "engine": {
+                    "type": "string",
+                    "x-order": "7"
+                },
+                "torque": {
+                    "type": "integer",
+                    "x-order": "8"
+                },
+                "transmission": {
+                    "type": "string",
+                    "x-order": "9"
+                }
+            }
+        },
+        "request.CollectionCreateRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "x-order": "0",
+                    "example": "Family SUVs"
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "unlisted",
+                        "private"
+                    ],
+                    "x-order": "1",
+                    "example": "unlisted"
+                }
+            }
+        },
+        "request.CollectionItemCreateRequest": {
+            "type": "object",
+            "required": [
+                "car_id"
+            ],
+            "properties": {
+                "car_id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "x-order": "1",
+                    "example": "Test drive in spring"
+                }
+            }
+        },
+        "request.CollectionItemUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "x-order": "0",
+                    "example": "Too small for the dog"
+                }
+            }
+        },
+        "request.CollectionReorderRequest": {
+            "type": "object",
+            "required": [
+                "car_ids"
+            ],
+            "properties": {
+                "car_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "x-order": "0"
+                }
+            }
+        },
+        "request.CollectionUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "x-order": "0",
+                    "example": "Dream garage"
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "unlisted",
+                        "private"
+                    ],
+                    "x-order": "1",
+                    "example": "public"
+                }
+            }
+        },
+        "request.CommentCreateRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "review_id"
+            ],
+            "properties": {
+                "review_id": {
+                    "type": "integer",
+                    "x-order": "0"
+                },
+                "content": {
+                    "type": "string",
+                    "x-order": "1"
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "x-order": "2"
+                }
+            }
+        },
+        "request.CommentUpdateRequest": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "x-order": "0"
+                }
+            }
+        },
+        "request.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "username"
+            ],
+            "properties": {
+                "username": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 3,
+                    "x-order": "0"
+                },
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.GalleryImageCreateRequest": {
+            "type": "object",
+            "properties": {
+                "image_url": {
+                    "type": "string",
+                    "x-order": "0"
+                },
+                "media_id": {
+                    "type": "integer",
+                    "x-order": "1"
+                },
+                "caption": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "x-order": "2"
+                },
+                "is_cover": {
+                    "type": "boolean",
+                    "x-order": "3"
+                }
+            }
+        },
+        "request.GalleryImageUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "caption": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "x-order": "0"
+                },
+                "is_cover": {
+                    "type": "boolean",
+                    "x-order": "1"
+                }
+            }
+        },
+        "request.GalleryReorderRequest": {
+            "type": "object",
+            "required": [
+                "image_ids"
+            ],
+            "properties": {
+                "image_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "x-order": "0"
+                }
+            }
+        },
+        "request.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "x-order": "0"
+                },
+                "password": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "password"
+                }
+            }
+        },
+        "request.ModerationDecisionRequest": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "approve",
+                        "hide",
+                        "reject"
+                    ],
+                    "x-order": "0",
+                    "example": "hide"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "x-order": "1",
+                    "example": "Advertising"
+                }
+            }
+        },
+        "request.NotificationPreferenceRequest": {
+            "type": "object",
+            "required": [
+                "preferences"
+            ],
+            "properties": {
+                "preferences": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    },
+                    "x-order": "0"
+                }
+            }
+        },
+        "request.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "username": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 3,
+                    "x-order": "0"
+                },
+                "email": {
+                    "type": "string",
+                    "x-order": "1"
+                },
+                "password": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "password"
+                }
+            }
+        },
+        "request.ReportCreateRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "spam",
+                        "harassment",
+                        "hate_speech",
+                        "misinformation",
+                        "off_topic",
+                        "other"
+                    ],
+                    "x-order": "0",
+                    "example": "spam"
+                },
+                "details": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "x-order": "1",
+                    "example": "Links to a scam shop"
+                }
+            }
+        },
+        "request.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "token"
+                },
+                "new_password": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "new_password"
+                }
+            }
+        },
+        "request.ReviewCreateRequest": {
+            "type": "object",
+            "required": [
+                "car_id",
+                "content",
+                "title"
+            ],
+            "properties": {
+                "car_id": {
+                    "type": "integer",
+                    "x-order": "0"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "x-order": "1"
+                },
+                "content": {
+                    "type": "string",
+                    "x-order": "2"
+                },
+                "image_url": {
+                    "type": "string",
+                    "x-order": "3"
+                },
+                "media_id": {
+                    "type": "integer",
+                    "x-order": "4"
+                }
+            }
+        },
+        "request.ReviewUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "title": {
+                    "type": "string",
+                    "x-order": "0"
+                },
+                "content": {
+                    "type": "string",
+                    "x-order": "1"
+                },
+                "image_url": {
+                    "type": "string",
+                    "x-order": "2"
+                },
+                "media_id": {
+                    "type": "integer",
+                    "x-order": "3"
+                }
+            }
+        },
+        "request.ReviewVoteRequest": {
+            "type": "object",
+            "required": [
+                "helpful"
+            ],
+            "properties": {
+                "helpful": {
+                    "type": "boolean",
+                    "x-order": "0"
+                }
+            }
+        },
+        "request.UpdatePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "password"
+                },
+                "password": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "new_password"
+                }
+            }
+        },
+        "request.UpdateUserProfileRequest": {
+            "type": "object",
+            "properties": {
+                "username": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 3,
+                    "x-order": "0"
+                },
+                "email": {
+                    "type": "string",
+                    "x-order": "1"
+                },
+                "full_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "x-order": "2"
+                },
+                "bio": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "x-order": "3"
+                },
+                "age": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "x-order": "4"
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "MALE",
+                        "FEMALE"
+                    ],
+                    "x-order": "5"
+                }
+            }
+        },
+        "request.WatchDigestRequest": {
+            "type": "object",
+            "required": [
+                "frequency"
+            ],
+            "properties": {
+                "frequency": {
+                    "type": "string",
+                    "enum": [
+                        "off",
+                        "daily",
+                        "weekly"
+                    ],
+                    "x-order": "0",
+                    "example": "weekly"
+                }
+            }
+        },
+        "request.WebhookCreateRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "x-order": "0",
+                    "example": "https://partner.example.com/hooks/carreview"
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 16,
+                    "x-order": "1",
+                    "example": "0123456789abcdef0123456789abcdef"
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "2",
+                    "example": [
+                        "car.created",
+                        "review.published"
+                    ]
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "x-order": "3",
+                    "example": "Partner catalogue sync"
+                },
+                "active": {
+                    "type": "boolean",
+                    "x-order": "4",
+                    "example": true
+                }
+            }
+        },
+        "request.WebhookUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "x-order": "0",
+                    "example": "https://partner.example.com/hooks/carreview"
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 16,
+                    "x-order": "1",
+                    "example": "0123456789abcdef0123456789abcdef"
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "2",
+                    "example": [
+                        "car.created",
+                        "car.updated"
+                    ]
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "x-order": "3",
+                    "example": "Partner catalogue sync"
+                },
+                "active": {
+                    "type": "boolean",
+                    "x-order": "4",
+                    "example": false
+                }
+            }
+        },
+        "response.AdminUserResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "username": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "luigi"
+                },
+                "email": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "luigi@sam.com"
+                },
+                "role": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "USER"
+                },
+                "email_verified": {
+                    "type": "boolean",
+                    "x-order": "4",
+                    "example": true
+                },
+                "banned": {
+                    "type": "boolean",
+                    "x-order": "5",
+                    "example": false
+                },
+                "ban_reason": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "Spam"
+                },
+                "ban_expires_at": {
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "2030-01-01T00:00:00Z"
+                },
+                "password_reset_required": {
+                    "type": "boolean",
+                    "x-order": "8",
+                    "example": false
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "9",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "response.AuditEventResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "actor_id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 1
+                },
+                "impersonator_id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 1
+                },
+                "request_id": {
+                    "type": "string",
+                    "x-order": "10",
+                    "example": "4f1c2a..."
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "11",
+                    "example": "2022-01-01T00:00:00Z"
+                },
+                "action": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "car.update"
+                },
+                "target_type": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "car"
+                },
+                "target_id": {
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 2
+                },
+                "before": {
+                    "type": "object",
+                    "x-order": "5"
+                },
+                "after": {
+                    "type": "object",
+                    "x-order": "6"
+                },
+                "diff": {
+                    "type": "object",
+                    "x-order": "7"
+                },
+                "ip": {
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "127.0.0.1"
+                },
+                "user_agent": {
+                    "type": "string",
+                    "x-order": "9",
+                    "example": "Mozilla/5.0"
+                }
+            }
+        },
+        "response.BrandResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "x-order": "1"
+                }
+            }
+        },
+        "response.CarExportResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "brand_id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 2
+                },
+                "brand_name": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "Toyota"
+                },
+                "transmission": {
+                    "type": "string",
+                    "x-order": "10",
+                    "example": "Manual"
+                },
+                "acceleration": {
+                    "type": "number",
+                    "x-order": "11",
+                    "example": 5.6
+                },
+                "horse_power": {
+                    "type": "integer",
+                    "x-order": "12",
+                    "example": 265
+                },
+                "breaking_system_front": {
+                    "type": "string",
+                    "x-order": "13",
+                    "example": "Ventilated Disc"
+                },
+                "breaking_system_back": {
+                    "type": "string",
+                    "x-order": "14",
+                    "example": "Disc"
+                },
+                "fuel": {
+                    "type": "string",
+                    "x-order": "15",
+                    "example": "Electric"
+                },
+                "name": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "Yaris"
+                },
+                "model": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "SUV"
+                },
+                "year": {
+                    "type": "integer",
+                    "x-order": "3",
+                    "example": 2020
+                },
+                "image_url": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "image url"
+                },
+                "media_id": {
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 1
+                },
+                "width": {
+                    "type": "integer",
+                    "x-order": "5",
+                    "example": 462
+                },
+                "height": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 184
+                },
+                "length": {
+                    "type": "integer",
+                    "x-order": "7",
+                    "example": 137
+                },
+                "engine": {
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "2.0L EA113 CDLA TFSI In-Line 4 + Mild Hybrid 48V"
+                },
+                "torque": {
+                    "type": "integer",
+                    "x-order": "9",
+                    "example": 370
+                }
+            }
+        },
+        "response.CarResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "brand_name": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "Toyota"
+                },
+                "brand_id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 2
+                },
+                "transmission": {
+                    "type": "string",
+                    "x-order": "10",
+                    "example": "Manual"
+                },
+                "acceleration": {
+                    "type": "number",
+                    "x-order": "11",
+                    "example": 5.6
+                },
+                "horse_power": {
+                    "type": "integer",
+                    "x-order": "12",
+                    "example": 265
+                },
+                "breaking_system_front": {
+                    "type": "string",
+                    "x-order": "13",
+                    "example": "Ventilated Disc"
+                },
+                "breaking_system_back": {
+                    "type": "string",
+                    "x-order": "14",
+                    "example": "Disc"
+                },
+                "fuel": {
+                    "type": "string",
+                    "x-order": "15",
+                    "example": "Electric"
+                },
+                "gallery": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.GalleryImageResponse"
+                    },
+                    "x-order": "16"
+                },
+                "name": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "Yaris"
+                },
+                "model": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "SUV"
+                },
+                "year": {
+                    "type": "integer",
+                    "x-order": "3",
+                    "example": 2020
+                },
+                "media_id": {
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 1
+                },
+                "image_url": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "image url"
+                },
+                "width": {
+                    "type": "integer",
+                    "x-order": "5",
+                    "example": 462
+                },
+                "height": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 184
+                },
+                "length": {
+                    "type": "integer",
+                    "x-order": "7",
+                    "example": 137
+                },
+                "engine": {
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "2.0L EA113 CDLA TFSI In-Line 4 + Mild Hybrid 48V"
+                },
+                "torque": {
+                    "type": "integer",
+                    "x-order": "9",
+                    "example": 370
+                }
+            }
+        },
+        "response.CollectionItemResponse": {
+            "type": "object",
+            "properties": {
+                "car_id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "brand": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "Honda"
+                },
+                "name": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "Civic"
+                },
+                "model": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "TYPE R"
+                },
+                "image_url": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "image url"
+                },
+                "note": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "Test drive in spring"
+                },
+                "position": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 0
+                },
+                "added_at": {
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "response.CollectionResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "user_id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 2
+                },
+                "username": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "luigi"
+                },
+                "name": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "Family SUVs"
+                },
+                "slug": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "family-suvs-k3x9q2"
+                },
+                "visibility": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "unlisted"
+                },
+                "is_default": {
+                    "type": "boolean",
+                    "x-order": "6",
+                    "example": false
+                },
+                "item_count": {
+                    "type": "integer",
+                    "x-order": "7",
+                    "example": 3
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "2022-01-01T00:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "x-order": "9",
+                    "example": "2022-01-02T00:00:00Z"
+                }
+            }
+        },
+        "response.CommentExportResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "review_id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 2
+                },
+                "user_id": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 3
+                },
+                "username": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "luigi"
+                },
+                "content": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "Lorem ipsum dolor sit amet"
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "2022-01-01T00:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "response.CommentReactionResponse": {
+            "type": "object",
+            "properties": {
+                "emoji": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "👍"
+                },
+                "count": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 3
+                },
+                "reacted": {
+                    "type": "boolean",
+                    "x-order": "2",
+                    "example": true
+                }
+            }
+        },
+        "response.CommentResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "review_id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 2
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 4
+                },
+                "user": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.CommentUserResponse"
+                        }
+                    ],
+                    "x-order": "2"
+                },
+                "content": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "Lorem ipsum dolor sit amet"
+                },
+                "status": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "published"
+                },
+                "mentions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.MentionResponse"
+                    },
+                    "x-order": "5"
+                },
+                "reactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommentReactionResponse"
+                    },
+                    "x-order": "5"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2022-01-01T00:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "response.CommentUserResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "username": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "luigi"
+                }
+            }
+        },
+        "response.FavouriteResponse": {
+            "type": "object",
+            "properties": {
+                "car_id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "brand": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "Honda"
+                },
+                "model": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "Civic"
+                },
+                "image_url": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "image url"
+                }
+            }
+        },
+        "response.FindReviewResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "title": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "Title"
+                },
+                "content": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "Lorem ipsum dolor sit amet"
+                },
+                "image_url": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "image url"
+                },
+                "media_id": {
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 1
+                },
+                "status": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "published"
+                },
+                "mentions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.MentionResponse"
+                    },
+                    "x-order": "5"
+                },
+                "car": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.ReviewCarResponse"
+                        }
+                    ],
+                    "x-order": "5"
+                },
+                "gallery": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.GalleryImageResponse"
+                    },
+                    "x-order": "5"
+                },
+                "user": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.ReviewUserResponse"
+                        }
+                    ],
+                    "x-order": "5"
+                },
+                "unhelpful_count": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 5
+                },
+                "edited": {
+                    "type": "boolean",
+                    "x-order": "6",
+                    "example": true
+                },
+                "edited_at": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "2022-01-02T00:00:00Z"
+                },
+                "helpful_score": {
+                    "type": "number",
+                    "x-order": "6",
+                    "example": 0.887
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "2022-01-01T00:00:00Z"
+                },
+                "helpful_count": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 95
+                },
+                "updated_at": {
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "response.FollowResponse": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "users"
+                },
+                "id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "luigi"
+                },
+                "followed_at": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "response.ForgotPasswordResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "token"
+                }
+            }
+        },
+        "response.GalleryImageResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "image_url": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "image url"
+                },
+                "media_id": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 1
+                },
+                "caption": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "Engine bay"
+                },
+                "position": {
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 0
+                },
+                "is_cover": {
+                    "type": "boolean",
+                    "x-order": "5",
+                    "example": true
+                }
+            }
+        },
+        "response.GetUserCurrentResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "username": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "luigi"
+                },
+                "email": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "luigi@sam.com"
+                },
+                "role": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "USER"
+                }
+            }
+        },
+        "response.ImportJobResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "kind": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "cars"
+                },
+                "error": {
+                    "type": "string",
+                    "x-order": "10"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ImportRowReport"
+                    },
+                    "x-order": "11"
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "12",
+                    "example": "2022-01-01T00:00:00Z"
+                },
+                "finished_at": {
+                    "type": "string",
+                    "x-order": "13",
+                    "example": "2022-01-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "completed"
+                },
+                "format": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "csv"
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "x-order": "4",
+                    "example": false
+                },
+                "total_rows": {
+                    "type": "integer",
+                    "x-order": "5",
+                    "example": 120
+                },
+                "processed": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 120
+                },
+                "created": {
+                    "type": "integer",
+                    "x-order": "7",
+                    "example": 100
+                },
+                "updated": {
+                    "type": "integer",
+                    "x-order": "8",
+                    "example": 18
+                },
+                "failed": {
+                    "type": "integer",
+                    "x-order": "9",
+                    "example": 2
+                }
+            }
+        },
+        "response.ImportRowReport": {
+            "type": "object",
+            "properties": {
+                "row": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 2
+                },
+                "action": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "create"
+                },
+                "id": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 1
+                },
+                "errors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "x-order": "3"
+                }
+            }
+        },
+        "response.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "username": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "luigi"
+                },
+                "email": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "luigi@sam.com"
+                },
+                "role": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "USER"
+                },
+                "token": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "token"
+                }
+            }
+        },
+        "response.MediaResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "owner_id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 2
+                },
+                "content_type": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "image/jpeg"
+                },
+                "size": {
+                    "type": "integer",
+                    "x-order": "3",
+                    "example": 204800
+                },
+                "width": {
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 1920
+                },
+                "height": {
+                    "type": "integer",
+                    "x-order": "5",
+                    "example": 1080
+                },
+                "url": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "https://example.com/api/media/1/original"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.MediaVariantResponse"
+                    },
+                    "x-order": "7"
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "response.MediaVariantResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "thumb_480"
+                },
+                "content_type": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "image/jpeg"
+                },
+                "width": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 480
+                },
+                "height": {
+                    "type": "integer",
+                    "x-order": "3",
+                    "example": 270
+                },
+                "size": {
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 20480
+                },
+                "url": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "https://example.com/api/media/1/thumb_480"
+                }
+            }
+        },
+        "response.MentionResponse": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 3
+                },
+                "username": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "luigi"
+                },
+                "start": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 6
+                },
+                "end": {
+                    "type": "integer",
+                    "x-order": "3",
+                    "example": 12
+                }
+            }
+        },
+        "response.ModerationDecisionResponse": {
+            "type": "object",
+            "properties": {
+                "target_type": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "reviews"
+                },
+                "target_id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 2
+                },
+                "status": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "hidden"
+                },
+                "resolved_reports": {
+                    "type": "integer",
+                    "x-order": "3",
+                    "example": 3
+                }
+            }
+        },
+        "response.ModerationQueueItemResponse": {
+            "type": "object",
+            "properties": {
+                "target_type": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "reviews"
+                },
+                "target_id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 2
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "10",
+                    "example": "2022-01-01T00:00:00Z"
+                },
+                "author_id": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 3
+                },
+                "author_username": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "luigi"
+                },
+                "status": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "hidden"
+                },
+                "excerpt": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "Best car ever"
+                },
+                "reports": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 3
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "7",
+                    "example": [
+                        "spam",
+                        "off_topic"
+                    ]
+                },
+                "last_reported_at": {
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "2022-01-02T00:00:00Z"
+                },
+                "screening": {
+                    "type": "object",
+                    "x-order": "9"
+                }
+            }
+        },
+        "response.NotificationPreferenceResponse": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "mention"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "x-order": "1",
+                    "example": true
+                }
+            }
+        },
+        "response.NotificationReadAllResponse": {
+            "type": "object",
+            "properties": {
+                "read": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 5
+                }
+            }
+        },
+        "response.NotificationResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "type": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "mention"
+                },
+                "actor_id": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 2
+                },
+                "actor_username": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "luigi"
+                },
+                "target_type": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "comments"
+                },
+                "target_id": {
+                    "type": "integer",
+                    "x-order": "5",
+                    "example": 5
+                },
+                "message": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "luigi mentioned you in a comment"
+                },
+                "read_at": {
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "2022-01-02T00:00:00Z"
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "response.RegisterResponse": {
+            "type": "object",
+            "properties": {
+                "username": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "luigi"
+                },
+                "email": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "luigi@sam.com"
+                },
+                "role": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "USER"
+                }
+            }
+        },
+        "response.ReportResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "target_type": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "reviews"
+                },
+                "target_id": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 2
+                },
+                "reason": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "spam"
+                },
+                "details": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "Links to a scam shop"
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "response.ReviewCarResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                }
+            }
+        },
+        "response.ReviewExportResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "car_id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 2
+                },
+                "user_id": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 3
+                },
+                "username": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "luigi"
+                },
+                "title": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "Title"
+                },
+                "content": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "Lorem ipsum dolor sit amet"
+                },
+                "media_id": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 1
+                },
+                "image_url": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "image url"
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "2022-01-01T00:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "response.ReviewResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "car_id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 2
+                },
+                "user_id": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 3
+                },
+                "title": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "Title"
+                },
+                "content": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "Lorem ipsum dolor sit amet"
+                },
+                "image_url": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "image url"
+                },
+                "media_id": {
+                    "type": "integer",
+                    "x-order": "5",
+                    "example": 1
+                },
+                "status": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "published"
+                },
+                "mentions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.MentionResponse"
+                    },
+                    "x-order": "5"
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "2022-01-01T00:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "response.ReviewUserResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "integer",
-                    "x-order": "0"
+                    "x-order": "0",
+                    "example": 1
                 },
-                "model": {
+                "username": {
                     "type": "string",
-                    "x-order": "1"
+                    "x-order": "1",
+                    "example": "John Doe"
+                }
+            }
+        },
+        "response.ReviewVoteResponse": {
+            "type": "object",
+            "properties": {
+                "review_id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "helpful": {
+                    "type": "boolean",
+                    "x-order": "1",
+                    "example": true
+                },
+                "helpful_count": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 95
+                },
+                "unhelpful_count": {
+                    "type": "integer",
+                    "x-order": "3",
+                    "example": 5
+                },
+                "helpful_score": {
+                    "type": "number",
+                    "x-order": "4",
+                    "example": 0.887
+                }
+            }
+        },
+        "response.RevisionDiffResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 0
+                },
+                "to": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 2
+                },
+                "diff": {
+                    "type": "object",
+                    "x-order": "2"
+                }
+            }
+        },
+        "response.RevisionResponse": {
+            "type": "object",
+            "properties": {
+                "number": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "editor_id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 3
+                },
+                "editor_username": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "luigi"
+                },
+                "before": {
+                    "type": "object",
+                    "x-order": "3"
+                },
+                "after": {
+                    "type": "object",
+                    "x-order": "4"
+                },
+                "diff": {
+                    "type": "object",
+                    "x-order": "5"
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "response.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "user_agent": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "Mozilla/5.0"
+                },
+                "ip": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "127.0.0.1"
+                },
+                "current": {
+                    "type": "boolean",
+                    "x-order": "3",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "2022-01-01T00:00:00Z"
+                },
+                "last_seen_at": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "2022-01-01T00:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "response.TrashItemResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "label": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "Yaris"
+                },
+                "deleted_at": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "2022-01-01T00:00:00Z"
+                },
+                "purge_at": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "2022-01-31T00:00:00Z"
+                }
+            }
+        },
+        "response.UpdateUserProfileResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "username": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "luigi"
+                },
+                "role": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "USER"
+                },
+                "email": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "luigi@sam.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "Luigi Di Caprio"
+                },
+                "bio": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "I am Luigi"
+                },
+                "age": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 18
+                },
+                "gender": {
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "MALE"
+                }
+            }
+        },
+        "response.UserProfileResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "username": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "luigi"
+                },
+                "role": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "USER"
+                },
+                "email": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "luigi@sam.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "Luigi Di Caprio"
+                },
+                "bio": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "I am Luigi"
+                },
+                "age": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 18
+                },
+                "gender": {
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "MALE"
+                },
+                "follower_count": {
+                    "type": "integer",
+                    "x-order": "8",
+                    "example": 12
+                },
+                "following_count": {
+                    "type": "integer",
+                    "x-order": "9",
+                    "example": 3
+                }
+            }
+        },
+        "response.WatchDigestResponse": {
+            "type": "object",
+            "properties": {
+                "frequency": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "weekly"
+                },
+                "last_sent_at": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "response.WatchResponse": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "cars"
+                },
+                "id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 1
                 },
                 "name": {
                     "type": "string",
-                    "x-order": "1"
+                    "x-order": "2",
+                    "example": "Corolla Cross Hybrid"
+                },
+                "watched_at": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "response.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
                 },
-                "acceleration": {
-                    "type": "number",
-                    "x-order": "10"
+                "webhook_id": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 1
                 },
-                "horse_power": {
+                "redelivery_of": {
                     "type": "integer",
+                    "x-order": "10",
+                    "example": 1
+                },
+                "payload": {
+                    "type": "object",
                     "x-order": "11"
                 },
-                "breaking_system_front": {
+                "created_at": {
                     "type": "string",
-                    "x-order": "12"
+                    "x-order": "12",
+                    "example": "2022-01-01T00:00:00Z"
                 },
-                "breaking_system_back": {
+                "event": {
                     "type": "string",
-                    "x-order": "13"
+                    "x-order": "2",
+                    "example": "car.created"
                 },
-                "fuel": {
+                "status": {
                     "type": "string",
-                    "x-order": "14"
+                    "x-order": "3",
+                    "example": "failed"
                 },
-                "year": {
+                "attempts": {
                     "type": "integer",
-                    "minimum": 1878,
-                    "x-order": "2"
+                    "x-order": "4",
+                    "example": 8
                 },
-                "image_url": {
+                "next_attempt_at": {
                     "type": "string",
-                    "x-order": "3"
-                },
-                "width": {
-                    "type": "integer",
-                    "x-order": "4"
+                    "x-order": "5",
+                    "example": "2022-01-01T00:01:00Z"
                 },
-                "height": {
-                    "type": "integer",
-                    "x-order": "5"
+                "last_attempt_at": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "2022-01-01T00:00:30Z"
                 },
-                "length": {
+                "response_status": {
                     "type": "integer",
-                    "x-order": "6"
+                    "x-order": "7",
+                    "example": 503
                 },
-                "engine": {
+                "response_body": {
                     "type": "string",
-                    "x-order": "7"
-                },
-                "torque": {
-                    "type": "integer",
-                    "x-order": "8"
+                    "x-order": "8",
+                    "example": "Service Unavailable"
                 },
-                "transmission": {
+                "error": {
                     "type": "string",
-                    "x-order": "9"
+                    "x-order": "9",
+                    "example": ""
                 }
             }
         },
-        "request.CarUpdateRequest": {
+        "response.WebhookResponse": {
             "type": "object",
             "properties": {
-                "brand_id": {
+                "id": {
                     "type": "integer",
-                    "x-order": "0"
-                },
-                "name": {
-                    "type": "string",
-                    "x-order": "1"
+                    "x-order": "0",
+                    "example": 1
                 },
-                "model": {
+                "url": {
                     "type": "string",
-                    "x-order": "1"
-                },
-                "acceleration": {
-                    "type": "number",
-                    "x-order": "10"
+                    "x-order": "1",
+                    "example": "https://partner.example.com/hooks/carreview"
                 },
-                "horse_power": {
-                    "type": "integer",
-                    "x-order": "11"
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "2",
+                    "example": [
+                        "car.created",
+                        "review.published"
+                    ]
                 },
-                "breaking_system_front": {
+                "description": {
                     "type": "string",
-                    "x-order": "12"
+                    "x-order": "3",
+                    "example": "Partner catalogue sync"
                 },
-                "breaking_system_back": {
-                    "type": "string",
-                    "x-order": "13"
+                "active": {
+                    "type": "boolean",
+                    "x-order": "4",
+                    "example": true
                 },
-                "fuel": {
+                "secret": {
                     "type": "string",
-                    "x-order": "14"
-                },
-                "year": {
-                    "type": "integer",
-                    "minimum": 1878,
-                    "x-order": "2"
+                    "x-order": "5",
+                    "example": "0123456789abcdef0123456789abcdef"
                 },
-                "image_url": {
+                "created_at": {
                     "type": "string",
-                    "x-order": "3"
+                    "x-order": "6",
+                    "example": "2022-01-01T00:00:00Z"
                 },
-                "width": {
+                "updated_at": {
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "2022-01-01T00:00:00Z"
+                }
+            }
+        },
+        "web.Metadata": {
+            "type": "object",
+            "properties": {
+                "page": {
                     "type": "integer",
-                    "x-order": "4"
+                    "x-order": "0"
                 },
-                "height": {
+                "limit": {
                     "type": "integer",
-                    "x-order": "5"
+                    "x-order": "1"
                 },
-                "length": {
+                "total_pages": {
                     "type": "integer",
-                    "x-order": "6"
-                },
-                "engine": {
-                    "type": "string",
-                    "x-order": "7"
+                    "x-order": "2"
                 },
-                "torque": {
+                "total_data": {
                     "type": "integer",
-                    "x-order": "8"
+                    "x-order": "3"
                 },
-                "transmission": {
+                "next_cursor": {
                     "type": "string",
-                    "x-order": "9"
+                    "x-order": "4"
                 }
             }
         },
-        "request.CommentCreateRequest": {
+        "web.WebBadRequestError": {
             "type": "object",
-            "required": [
-                "content",
-                "review_id"
-            ],
             "properties": {
-                "review_id": {
+                "code": {
                     "type": "integer",
-                    "x-order": "0"
+                    "example": 400
                 },
-                "content": {
+                "errors": {
                     "type": "string",
-                    "x-order": "1"
+                    "example": "Bad Request"
                 }
             }
         },
-        "request.CommentUpdateRequest": {
+        "web.WebForbiddenError": {
             "type": "object",
-            "required": [
-                "content"
-            ],
             "properties": {
-                "content": {
+                "code": {
+                    "type": "integer",
+                    "example": 403
+                },
+                "errors": {
                     "type": "string",
-                    "x-order": "0"
+                    "example": "Forbidden"
                 }
             }
         },
-        "request.ForgotPasswordRequest": {
+        "web.WebInternalServerError": {
             "type": "object",
-            "required": [
-                "email",
-                "username"
-            ],
             "properties": {
-                "username": {
-                    "type": "string",
-                    "maxLength": 20,
-                    "minLength": 3,
-                    "x-order": "0"
+                "code": {
+                    "type": "integer",
+                    "example": 500
                 },
-                "email": {
-                    "type": "string"
+                "errors": {
+                    "type": "string",
+                    "example": "Internal Server Error"
                 }
             }
         },
-        "request.LoginRequest": {
+        "web.WebNotFoundError": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "x-order": "0"
+                "code": {
+                    "type": "integer",
+                    "example": 404
                 },
-                "password": {
+                "errors": {
                     "type": "string",
-                    "x-order": "1",
-                    "example": "password"
+                    "example": "Not Found"
                 }
             }
         },
-        "request.RegisterRequest": {
+        "web.WebSuccess-array_response_AdminUserResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "password",
-                "username"
-            ],
             "properties": {
-                "username": {
-                    "type": "string",
-                    "maxLength": 20,
-                    "minLength": 3,
-                    "x-order": "0"
+                "code": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 200
                 },
-                "email": {
+                "message": {
                     "type": "string",
-                    "x-order": "1"
+                    "x-order": "1",
+                    "example": "success"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8,
-                    "x-order": "2",
-                    "example": "password"
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AdminUserResponse"
+                    },
+                    "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "request.ResetPasswordRequest": {
+        "web.WebSuccess-array_response_AuditEventResponse": {
             "type": "object",
-            "required": [
-                "new_password",
-                "token"
-            ],
             "properties": {
-                "token": {
-                    "type": "string",
+                "code": {
+                    "type": "integer",
                     "x-order": "0",
-                    "example": "token"
+                    "example": 200
                 },
-                "new_password": {
+                "message": {
                     "type": "string",
-                    "minLength": 8,
                     "x-order": "1",
-                    "example": "new_password"
+                    "example": "success"
+                },
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AuditEventResponse"
+                    },
+                    "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "request.ReviewCreateRequest": {
+        "web.WebSuccess-array_response_BrandResponse": {
             "type": "object",
-            "required": [
-                "car_id",
-                "content",
-                "image_url",
-                "title"
-            ],
             "properties": {
-                "car_id": {
+                "code": {
                     "type": "integer",
-                    "x-order": "0"
+                    "x-order": "0",
+                    "example": 200
                 },
-                "title": {
+                "message": {
                     "type": "string",
-                    "maxLength": 100,
-                    "x-order": "1"
+                    "x-order": "1",
+                    "example": "success"
                 },
-                "content": {
-                    "type": "string",
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.BrandResponse"
+                    },
                     "x-order": "2"
                 },
-                "image_url": {
-                    "type": "string",
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
                     "x-order": "3"
                 }
             }
         },
-        "request.ReviewUpdateRequest": {
+        "web.WebSuccess-array_response_CarResponse": {
             "type": "object",
             "properties": {
-                "title": {
-                    "type": "string",
-                    "x-order": "0"
+                "code": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 200
                 },
-                "content": {
+                "message": {
                     "type": "string",
-                    "x-order": "1"
+                    "x-order": "1",
+                    "example": "success"
                 },
-                "image_url": {
-                    "type": "string",
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CarResponse"
+                    },
                     "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "request.UpdatePasswordRequest": {
+        "web.WebSuccess-array_response_CollectionItemResponse": {
             "type": "object",
-            "required": [
-                "password"
-            ],
             "properties": {
-                "password": {
+                "code": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 200
+                },
+                "message": {
                     "type": "string",
-                    "minLength": 3
+                    "x-order": "1",
+                    "example": "success"
+                },
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CollectionItemResponse"
+                    },
+                    "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "request.UpdateUserProfileRequest": {
+        "web.WebSuccess-array_response_CollectionResponse": {
             "type": "object",
             "properties": {
-                "username": {
-                    "type": "string",
-                    "maxLength": 20,
-                    "minLength": 3,
-                    "x-order": "0"
+                "code": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 200
                 },
-                "email": {
+                "message": {
                     "type": "string",
-                    "x-order": "1"
+                    "x-order": "1",
+                    "example": "success"
                 },
-                "full_name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3,
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CollectionResponse"
+                    },
                     "x-order": "2"
                 },
-                "bio": {
-                    "type": "string",
-                    "maxLength": 500,
-                    "x-order": "3"
-                },
-                "age": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "x-order": "4"
-                },
-                "gender": {
-                    "type": "string",
-                    "enum": [
-                        "MALE",
-                        "FEMALE"
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
                     ],
-                    "x-order": "5"
+                    "x-order": "3"
                 }
             }
         },
-        "response.BrandResponse": {
+        "web.WebSuccess-array_response_CommentReactionResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "code": {
                     "type": "integer",
                     "x-order": "0",
-                    "example": 1
+                    "example": 200
                 },
-                "name": {
+                "message": {
                     "type": "string",
-                    "x-order": "1"
+                    "x-order": "1",
+                    "example": "success"
+                },
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommentReactionResponse"
+                    },
+                    "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "response.CarResponse": {
+        "web.WebSuccess-array_response_CommentResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "code": {
                     "type": "integer",
                     "x-order": "0",
-                    "example": 1
+                    "example": 200
                 },
-                "brand_name": {
+                "message": {
                     "type": "string",
                     "x-order": "1",
-                    "example": "Toyota"
-                },
-                "brand_id": {
-                    "type": "integer",
-                    "x-order": "1",
-                    "example": 2
-                },
-                "transmission": {
-                    "type": "string",
-                    "x-order": "10",
-                    "example": "Manual"
-                },
-                "acceleration": {
-                    "type": "number",
-                    "x-order": "11",
-                    "example": 5.6
-                },
-                "horse_power": {
-                    "type": "integer",
-                    "x-order": "12",
-                    "example": 265
-                },
-                "breaking_system_front": {
-                    "type": "string",
-                    "x-order": "13",
-                    "example": "Ventilated Disc"
-                },
-                "breaking_system_back": {
-                    "type": "string",
-                    "x-order": "14",
-                    "example": "Disc"
-                },
-                "fuel": {
-                    "type": "string",
-                    "x-order": "15",
-                    "example": "Electric"
-                },
-                "name": {
-                    "type": "string",
-                    "x-order": "2",
-                    "example": "Yaris"
-                },
-                "model": {
-                    "type": "string",
-                    "x-order": "2",
-                    "example": "SUV"
-                },
-                "year": {
-                    "type": "integer",
-                    "x-order": "3",
-                    "example": 2020
-                },
-                "image_url": {
-                    "type": "string",
-                    "x-order": "4",
-                    "example": "image url"
-                },
-                "width": {
-                    "type": "integer",
-                    "x-order": "5",
-                    "example": 462
+                    "example": "success"
                 },
-                "height": {
-                    "type": "integer",
-                    "x-order": "6",
-                    "example": 184
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommentResponse"
+                    },
+                    "x-order": "2"
                 },
-                "length": {
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
+                }
+            }
+        },
+        "web.WebSuccess-array_response_FavouriteResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
                     "type": "integer",
-                    "x-order": "7",
-                    "example": 137
+                    "x-order": "0",
+                    "example": 200
                 },
-                "engine": {
+                "message": {
                     "type": "string",
-                    "x-order": "8",
-                    "example": "2.0L EA113 CDLA TFSI In-Line 4 + Mild Hybrid 48V"
+                    "x-order": "1",
+                    "example": "success"
                 },
-                "torque": {
-                    "type": "integer",
-                    "x-order": "9",
-                    "example": 370
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.FavouriteResponse"
+                    },
+                    "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "response.CommentResponse": {
+        "web.WebSuccess-array_response_FindReviewResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "code": {
                     "type": "integer",
                     "x-order": "0",
-                    "example": 1
+                    "example": 200
                 },
-                "review_id": {
-                    "type": "integer",
+                "message": {
+                    "type": "string",
                     "x-order": "1",
-                    "example": 2
+                    "example": "success"
                 },
-                "user": {
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.FindReviewResponse"
+                    },
+                    "x-order": "2"
+                },
+                "metadata": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/response.CommentUserResponse"
+                            "$ref": "#/definitions/web.Metadata"
                         }
                     ],
-                    "x-order": "2"
+                    "x-order": "3"
+                }
+            }
+        },
+        "web.WebSuccess-array_response_FollowResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 200
                 },
-                "content": {
+                "message": {
                     "type": "string",
-                    "x-order": "3",
-                    "example": "Lorem ipsum dolor sit amet"
+                    "x-order": "1",
+                    "example": "success"
                 },
-                "created_at": {
-                    "type": "string",
-                    "example": "2022-01-01T00:00:00Z"
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.FollowResponse"
+                    },
+                    "x-order": "2"
                 },
-                "updated_at": {
-                    "type": "string",
-                    "example": "2022-01-01T00:00:00Z"
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "response.CommentUserResponse": {
+        "web.WebSuccess-array_response_GalleryImageResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "code": {
                     "type": "integer",
                     "x-order": "0",
-                    "example": 1
+                    "example": 200
                 },
-                "username": {
+                "message": {
                     "type": "string",
                     "x-order": "1",
-                    "example": "luigi"
+                    "example": "success"
+                },
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.GalleryImageResponse"
+                    },
+                    "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "response.FavouriteResponse": {
+        "web.WebSuccess-array_response_ModerationQueueItemResponse": {
             "type": "object",
             "properties": {
-                "car_id": {
+                "code": {
                     "type": "integer",
                     "x-order": "0",
-                    "example": 1
+                    "example": 200
                 },
-                "brand": {
+                "message": {
                     "type": "string",
                     "x-order": "1",
-                    "example": "Honda"
+                    "example": "success"
                 },
-                "model": {
-                    "type": "string",
-                    "x-order": "2",
-                    "example": "Civic"
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.ModerationQueueItemResponse"
+                    },
+                    "x-order": "2"
                 },
-                "image_url": {
-                    "type": "string",
-                    "x-order": "3",
-                    "example": "image url"
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "response.FindReviewResponse": {
+        "web.WebSuccess-array_response_NotificationPreferenceResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "code": {
                     "type": "integer",
                     "x-order": "0",
-                    "example": 1
+                    "example": 200
                 },
-                "title": {
+                "message": {
                     "type": "string",
                     "x-order": "1",
-                    "example": "Title"
+                    "example": "success"
                 },
-                "content": {
-                    "type": "string",
-                    "x-order": "2",
-                    "example": "Lorem ipsum dolor sit amet"
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.NotificationPreferenceResponse"
+                    },
+                    "x-order": "2"
                 },
-                "image_url": {
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
+                }
+            }
+        },
+        "web.WebSuccess-array_response_NotificationResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 200
+                },
+                "message": {
                     "type": "string",
-                    "x-order": "3",
-                    "example": "image url"
+                    "x-order": "1",
+                    "example": "success"
                 },
-                "car": {
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.NotificationResponse"
+                    },
+                    "x-order": "2"
+                },
+                "metadata": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/response.ReviewCarResponse"
+                            "$ref": "#/definitions/web.Metadata"
                         }
                     ],
-                    "x-order": "5"
+                    "x-order": "3"
+                }
+            }
+        },
+        "web.WebSuccess-array_response_RevisionResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 200
                 },
-                "user": {
+                "message": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "success"
+                },
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.RevisionResponse"
+                    },
+                    "x-order": "2"
+                },
+                "metadata": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/response.ReviewUserResponse"
+                            "$ref": "#/definitions/web.Metadata"
                         }
                     ],
-                    "x-order": "5"
+                    "x-order": "3"
+                }
+            }
+        },
+        "web.WebSuccess-array_response_SessionResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 200
                 },
-                "created_at": {
+                "message": {
                     "type": "string",
-                    "x-order": "6",
-                    "example": "2022-01-01T00:00:00Z"
+                    "x-order": "1",
+                    "example": "success"
                 },
-                "updated_at": {
-                    "type": "string",
-                    "x-order": "7",
-                    "example": "2022-01-01T00:00:00Z"
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.SessionResponse"
+                    },
+                    "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "response.ForgotPasswordResponse": {
+        "web.WebSuccess-array_response_TrashItemResponse": {
             "type": "object",
             "properties": {
-                "token": {
+                "code": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 200
+                },
+                "message": {
                     "type": "string",
-                    "example": "token"
+                    "x-order": "1",
+                    "example": "success"
+                },
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.TrashItemResponse"
+                    },
+                    "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "response.GetUserCurrentResponse": {
+        "web.WebSuccess-array_response_WatchResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "code": {
                     "type": "integer",
                     "x-order": "0",
-                    "example": 1
+                    "example": 200
                 },
-                "username": {
+                "message": {
                     "type": "string",
-                    "x-order": "2",
-                    "example": "luigi"
+                    "x-order": "1",
+                    "example": "success"
                 },
-                "email": {
-                    "type": "string",
-                    "x-order": "3",
-                    "example": "luigi@sam.com"
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.WatchResponse"
+                    },
+                    "x-order": "2"
                 },
-                "role": {
-                    "type": "string",
-                    "x-order": "4",
-                    "example": "USER"
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "response.LoginResponse": {
+        "web.WebSuccess-array_response_WebhookDeliveryResponse": {
             "type": "object",
             "properties": {
-                "username": {
-                    "type": "string",
+                "code": {
+                    "type": "integer",
                     "x-order": "0",
-                    "example": "luigi"
+                    "example": 200
                 },
-                "email": {
+                "message": {
                     "type": "string",
                     "x-order": "1",
-                    "example": "luigi@sam.com"
+                    "example": "success"
                 },
-                "role": {
-                    "type": "string",
-                    "x-order": "2",
-                    "example": "USER"
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.WebhookDeliveryResponse"
+                    },
+                    "x-order": "2"
                 },
-                "token": {
-                    "type": "string",
-                    "x-order": "3",
-                    "example": "token"
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "response.RegisterResponse": {
+        "web.WebSuccess-array_response_WebhookResponse": {
             "type": "object",
             "properties": {
-                "username": {
-                    "type": "string",
+                "code": {
+                    "type": "integer",
                     "x-order": "0",
-                    "example": "luigi"
+                    "example": 200
                 },
-                "email": {
+                "message": {
                     "type": "string",
                     "x-order": "1",
-                    "example": "luigi@sam.com"
+                    "example": "success"
                 },
-                "role": {
-                    "type": "string",
-                    "x-order": "2",
-                    "example": "USER"
+                "payload": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.WebhookResponse"
+                    },
+                    "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "response.ReviewCarResponse": {
+        "web.WebSuccess-response_AdminUserResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "code": {
                     "type": "integer",
                     "x-order": "0",
-                    "example": 1
+                    "example": 200
+                },
+                "message": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "success"
+                },
+                "payload": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.AdminUserResponse"
+                        }
+                    ],
+                    "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "response.ReviewResponse": {
+        "web.WebSuccess-response_BrandResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "code": {
                     "type": "integer",
                     "x-order": "0",
-                    "example": 1
-                },
-                "car_id": {
-                    "type": "integer",
-                    "x-order": "1",
-                    "example": 2
-                },
-                "user_id": {
-                    "type": "integer",
-                    "x-order": "2",
-                    "example": 3
-                },
-                "title": {
-                    "type": "string",
-                    "x-order": "3",
-                    "example": "Title"
-                },
-                "content": {
-                    "type": "string",
-                    "x-order": "4",
-                    "example": "Lorem ipsum dolor sit amet"
+                    "example": 200
                 },
-                "image_url": {
+                "message": {
                     "type": "string",
-                    "x-order": "5",
-                    "example": "image url"
+                    "x-order": "1",
+                    "example": "success"
                 },
-                "created_at": {
-                    "type": "string",
-                    "x-order": "6",
-                    "example": "2022-01-01T00:00:00Z"
+                "payload": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.BrandResponse"
+                        }
+                    ],
+                    "x-order": "2"
                 },
-                "updated_at": {
-                    "type": "string",
-                    "x-order": "7",
-                    "example": "2022-01-01T00:00:00Z"
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "response.ReviewUserResponse": {
+        "web.WebSuccess-response_CarResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "code": {
                     "type": "integer",
                     "x-order": "0",
-                    "example": 1
+                    "example": 200
                 },
-                "username": {
+                "message": {
                     "type": "string",
                     "x-order": "1",
-                    "example": "John Doe"
+                    "example": "success"
+                },
+                "payload": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.CarResponse"
+                        }
+                    ],
+                    "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "response.UpdateUserProfileResponse": {
+        "web.WebSuccess-response_CollectionItemResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "code": {
                     "type": "integer",
                     "x-order": "0",
-                    "example": 1
+                    "example": 200
                 },
-                "username": {
+                "message": {
                     "type": "string",
                     "x-order": "1",
-                    "example": "luigi"
-                },
-                "role": {
-                    "type": "string",
-                    "x-order": "2",
-                    "example": "USER"
-                },
-                "email": {
-                    "type": "string",
-                    "x-order": "3",
-                    "example": "luigi@sam.com"
-                },
-                "full_name": {
-                    "type": "string",
-                    "x-order": "4",
-                    "example": "Luigi Di Caprio"
-                },
-                "bio": {
-                    "type": "string",
-                    "x-order": "5",
-                    "example": "I am Luigi"
+                    "example": "success"
                 },
-                "age": {
-                    "type": "integer",
-                    "x-order": "6",
-                    "example": 18
+                "payload": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.CollectionItemResponse"
+                        }
+                    ],
+                    "x-order": "2"
                 },
-                "gender": {
-                    "type": "string",
-                    "x-order": "7",
-                    "example": "MALE"
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "response.UserProfileResponse": {
+        "web.WebSuccess-response_CollectionResponse": {
             "type": "object",
             "properties": {
-                "id": {
+                "code": {
                     "type": "integer",
                     "x-order": "0",
-                    "example": 1
+                    "example": 200
                 },
-                "username": {
+                "message": {
                     "type": "string",
                     "x-order": "1",
-                    "example": "luigi"
-                },
-                "role": {
-                    "type": "string",
-                    "x-order": "2",
-                    "example": "USER"
-                },
-                "email": {
-                    "type": "string",
-                    "x-order": "3",
-                    "example": "luigi@sam.com"
-                },
-                "full_name": {
-                    "type": "string",
-                    "x-order": "4",
-                    "example": "Luigi Di Caprio"
-                },
-                "bio": {
-                    "type": "string",
-                    "x-order": "5",
-                    "example": "I am Luigi"
+                    "example": "success"
                 },
-                "age": {
-                    "type": "integer",
-                    "x-order": "6",
-                    "example": 18
+                "payload": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.CollectionResponse"
+                        }
+                    ],
+                    "x-order": "2"
                 },
-                "gender": {
-                    "type": "string",
-                    "x-order": "7",
-                    "example": "MALE"
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "web.Metadata": {
+        "web.WebSuccess-response_CommentResponse": {
             "type": "object",
             "properties": {
-                "page": {
+                "code": {
                     "type": "integer",
-                    "x-order": "0"
+                    "x-order": "0",
+                    "example": 200
                 },
-                "limit": {
-                    "type": "integer",
-                    "x-order": "1"
+                "message": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "success"
                 },
-                "total_pages": {
-                    "type": "integer",
+                "payload": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.CommentResponse"
+                        }
+                    ],
                     "x-order": "2"
                 },
-                "total_data": {
-                    "type": "integer",
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
                     "x-order": "3"
                 }
             }
         },
-        "web.WebBadRequestError": {
+        "web.WebSuccess-response_FindReviewResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
-                    "example": 400
+                    "x-order": "0",
+                    "example": 200
                 },
-                "errors": {
+                "message": {
                     "type": "string",
-                    "example": "Bad Request"
+                    "x-order": "1",
+                    "example": "success"
+                },
+                "payload": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.FindReviewResponse"
+                        }
+                    ],
+                    "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "web.WebForbiddenError": {
+        "web.WebSuccess-response_ForgotPasswordResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
-                    "example": 403
+                    "x-order": "0",
+                    "example": 200
                 },
-                "errors": {
+                "message": {
                     "type": "string",
-                    "example": "Forbidden"
+                    "x-order": "1",
+                    "example": "success"
+                },
+                "payload": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.ForgotPasswordResponse"
+                        }
+                    ],
+                    "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "web.WebInternalServerError": {
+        "web.WebSuccess-response_GetUserCurrentResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
-                    "example": 500
+                    "x-order": "0",
+                    "example": 200
                 },
-                "errors": {
+                "message": {
                     "type": "string",
-                    "example": "Internal Server Error"
+                    "x-order": "1",
+                    "example": "success"
+                },
+                "payload": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.GetUserCurrentResponse"
+                        }
+                    ],
+                    "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "web.WebNotFoundError": {
+        "web.WebSuccess-response_ImportJobResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
-                    "example": 404
+                    "x-order": "0",
+                    "example": 200
                 },
-                "errors": {
+                "message": {
                     "type": "string",
-                    "example": "Not Found"
+                    "x-order": "1",
+                    "example": "success"
+                },
+                "payload": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.ImportJobResponse"
+                        }
+                    ],
+                    "x-order": "2"
+                },
+                "metadata": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/web.Metadata"
+                        }
+                    ],
+                    "x-order": "3"
                 }
             }
         },
-        "web.WebSuccess-array_response_BrandResponse": {
+        "web.WebSuccess-response_LoginResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2645,10 +11934,11 @@ const docTemplate = `{
                     "example": "success"
                 },
                 "payload": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.BrandResponse"
-                    },
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.LoginResponse"
+                        }
+                    ],
                     "x-order": "2"
                 },
                 "metadata": {
@@ -2661,7 +11951,7 @@ const docTemplate = `{
                 }
             }
         },
-        "web.WebSuccess-array_response_CarResponse": {
+        "web.WebSuccess-response_MediaResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2675,10 +11965,11 @@ const docTemplate = `{
                     "example": "success"
                 },
                 "payload": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.CarResponse"
-                    },
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.MediaResponse"
+                        }
+                    ],
                     "x-order": "2"
                 },
                 "metadata": {
@@ -2691,7 +11982,7 @@ const docTemplate = `{
                 }
             }
         },
-        "web.WebSuccess-array_response_CommentResponse": {
+        "web.WebSuccess-response_ModerationDecisionResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2705,10 +11996,11 @@ const docTemplate = `{
                     "example": "success"
                 },
                 "payload": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.CommentResponse"
-                    },
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.ModerationDecisionResponse"
+                        }
+                    ],
                     "x-order": "2"
                 },
                 "metadata": {
@@ -2721,7 +12013,7 @@ const docTemplate = `{
                 }
             }
         },
-        "web.WebSuccess-array_response_FindReviewResponse": {
+        "web.WebSuccess-response_NotificationReadAllResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2735,10 +12027,11 @@ const docTemplate = `{
                     "example": "success"
                 },
                 "payload": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.FindReviewResponse"
-                    },
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/response.NotificationReadAllResponse"
+                        }
+                    ],
                     "x-order": "2"
                 },
                 "metadata": {
@@ -2751,7 +12044,7 @@ const docTemplate = `{
                 }
             }
         },
-        "web.WebSuccess-response_BrandResponse": {
+        "web.WebSuccess-response_NotificationResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2767,7 +12060,7 @@ const docTemplate = `{
                 "payload": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/response.BrandResponse"
+                            "$ref": "#/definitions/response.NotificationResponse"
                         }
                     ],
                     "x-order": "2"
@@ -2782,7 +12075,7 @@ const docTemplate = `{
                 }
             }
         },
-        "web.WebSuccess-response_CarResponse": {
+        "web.WebSuccess-response_RegisterResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2798,7 +12091,7 @@ const docTemplate = `{
                 "payload": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/response.CarResponse"
+                            "$ref": "#/definitions/response.RegisterResponse"
                         }
                     ],
                     "x-order": "2"
@@ -2813,7 +12106,7 @@ const docTemplate = `{
                 }
             }
         },
-        "web.WebSuccess-response_CommentResponse": {
+        "web.WebSuccess-response_ReportResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2829,7 +12122,7 @@ const docTemplate = `{
                 "payload": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/response.CommentResponse"
+                            "$ref": "#/definitions/response.ReportResponse"
                         }
                     ],
                     "x-order": "2"
@@ -2844,7 +12137,7 @@ const docTemplate = `{
                 }
             }
         },
-        "web.WebSuccess-response_FavouriteResponse": {
+        "web.WebSuccess-response_ReviewResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2860,7 +12153,7 @@ const docTemplate = `{
                 "payload": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/response.FavouriteResponse"
+                            "$ref": "#/definitions/response.ReviewResponse"
                         }
                     ],
                     "x-order": "2"
@@ -2875,7 +12168,7 @@ const docTemplate = `{
                 }
             }
         },
-        "web.WebSuccess-response_FindReviewResponse": {
+        "web.WebSuccess-response_ReviewVoteResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2891,7 +12184,7 @@ const docTemplate = `{
                 "payload": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/response.FindReviewResponse"
+                            "$ref": "#/definitions/response.ReviewVoteResponse"
                         }
                     ],
                     "x-order": "2"
@@ -2906,7 +12199,7 @@ const docTemplate = `{
                 }
             }
         },
-        "web.WebSuccess-response_ForgotPasswordResponse": {
+        "web.WebSuccess-response_RevisionDiffResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2922,7 +12215,7 @@ const docTemplate = `{
                 "payload": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/response.ForgotPasswordResponse"
+                            "$ref": "#/definitions/response.RevisionDiffResponse"
                         }
                     ],
                     "x-order": "2"
@@ -2937,7 +12230,7 @@ const docTemplate = `{
                 }
             }
         },
-        "web.WebSuccess-response_GetUserCurrentResponse": {
+        "web.WebSuccess-response_RevisionResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2953,7 +12246,7 @@ const docTemplate = `{
                 "payload": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/response.GetUserCurrentResponse"
+                            "$ref": "#/definitions/response.RevisionResponse"
                         }
                     ],
                     "x-order": "2"
@@ -2968,7 +12261,7 @@ const docTemplate = `{
                 }
             }
         },
-        "web.WebSuccess-response_LoginResponse": {
+        "web.WebSuccess-response_UpdateUserProfileResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -2984,7 +12277,7 @@ const docTemplate = `{
                 "payload": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/response.LoginResponse"
+                            "$ref": "#/definitions/response.UpdateUserProfileResponse"
                         }
                     ],
                     "x-order": "2"
@@ -2999,7 +12292,7 @@ const docTemplate = `{
                 }
             }
         },
-        "web.WebSuccess-response_RegisterResponse": {
+        "web.WebSuccess-response_UserProfileResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -3015,7 +12308,7 @@ const docTemplate = `{
                 "payload": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/response.RegisterResponse"
+                            "$ref": "#/definitions/response.UserProfileResponse"
                         }
                     ],
                     "x-order": "2"
@@ -3030,7 +12323,7 @@ const docTemplate = `{
                 }
             }
         },
-        "web.WebSuccess-response_ReviewResponse": {
+        "web.WebSuccess-response_WatchDigestResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -3046,7 +12339,7 @@ const docTemplate = `{
                 "payload": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/response.ReviewResponse"
+                            "$ref": "#/definitions/response.WatchDigestResponse"
                         }
                     ],
                     "x-order": "2"
@@ -3061,7 +12354,7 @@ const docTemplate = `{
                 }
             }
         },
-        "web.WebSuccess-response_UpdateUserProfileResponse": {
+        "web.WebSuccess-response_WebhookDeliveryResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -3077,7 +12370,7 @@ const docTemplate = `{
                 "payload": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/response.UpdateUserProfileResponse"
+                            "$ref": "#/definitions/response.WebhookDeliveryResponse"
                         }
                     ],
                     "x-order": "2"
@@ -3092,7 +12385,7 @@ const docTemplate = `{
                 }
             }
         },
-        "web.WebSuccess-response_UserProfileResponse": {
+        "web.WebSuccess-response_WebhookResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -3108,7 +12401,7 @@ const docTemplate = `{
                 "payload": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/response.UserProfileResponse"
+                            "$ref": "#/definitions/response.WebhookResponse"
                         }
                     ],
                     "x-order": "2"
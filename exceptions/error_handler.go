@@ -22,6 +22,11 @@ func GlobalErrorHandler(c *gin.Context) {
 					Code:   e.Code,
 					Errors: e.Errors,
 				})
+			case *fieldError:
+				c.AbortWithStatusJSON(e.Code, &web.WebError{
+					Code:   e.Code,
+					Errors: e.Errors,
+				})
 			case validator.ValidationErrors:
 				HandleValidationErrors(c, e)
 			default:
@@ -0,0 +1,23 @@
+package exceptions
+
+import "strings"
+
+type fieldError struct {
+	Code   int            `json:"code"`
+	Errors map[string]any `json:"errors"`
+}
+
+func (e *fieldError) Error() string {
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	return "invalid fields: " + strings.Join(fields, ", ")
+}
+
+func NewFieldError(code int, errors map[string]any) *fieldError {
+	return &fieldError{
+		Code:   code,
+		Errors: errors,
+	}
+}
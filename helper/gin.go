@@ -1,6 +1,10 @@
 package helper
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -9,3 +13,19 @@ import (
 func GetDBAndLogger(c *gin.Context) (*gorm.DB, *zap.Logger) {
 	return c.MustGet("db").(*gorm.DB), c.MustGet("logger").(*zap.Logger)
 }
+
+// DetachContext returns a context for work that outlives the request. It
+// keeps the values of c, such as the db and the logger, and the request
+// headers, but is not cancelled with the request and writes nowhere.
+func DetachContext(c *gin.Context) *gin.Context {
+	detached, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	detached.Request = c.Request.Clone(context.Background())
+	detached.Request.Body = http.NoBody
+
+	for key, value := range c.Keys {
+		detached.Set(key, value)
+	}
+
+	return detached
+}
@@ -28,6 +28,7 @@ func ToResponseJSON[T any](c *gin.Context, code int, data T, pagination *web.Met
 			Limit:      pagination.Limit,
 			TotalPages: pagination.TotalPages,
 			TotalData:  pagination.TotalData,
+			NextCursor: pagination.NextCursor,
 		},
 	})
 }
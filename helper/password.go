@@ -1,15 +1,184 @@
 package helper
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrPasswordMismatch = errors.New("password does not match")
+
+// PasswordHasher hashes passwords into self-describing strings so that hashes
+// produced by different algorithms or parameters can live side by side.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password, encoded string) error
+	// Identifies reports whether the encoded hash was produced by this algorithm.
+	Identifies(encoded string) bool
+	// NeedsRehash reports whether the encoded hash is weaker than the hasher's
+	// current parameters or was produced by another algorithm.
+	NeedsRehash(encoded string) bool
+}
+
+// NewPasswordHasher returns the hasher configured with PASSWORD_HASH_ALGORITHM
+// (bcrypt or argon2id) and its PASSWORD_BCRYPT_* / PASSWORD_ARGON2_* parameters.
+func NewPasswordHasher() PasswordHasher {
+	switch strings.ToLower(GetEnv("PASSWORD_HASH_ALGORITHM", "bcrypt")) {
+	case "argon2id":
+		return &argon2idHasher{
+			memory:  uint32(GetEnvInt("PASSWORD_ARGON2_MEMORY", 64*1024)),
+			time:    uint32(GetEnvInt("PASSWORD_ARGON2_TIME", 3)),
+			threads: uint8(GetEnvInt("PASSWORD_ARGON2_THREADS", 2)),
+			keyLen:  32,
+			saltLen: 16,
+		}
+	default:
+		return &bcryptHasher{cost: GetEnvInt("PASSWORD_BCRYPT_COST", bcrypt.DefaultCost)}
+	}
+}
+
+func passwordHashers() []PasswordHasher {
+	return []PasswordHasher{&bcryptHasher{cost: bcrypt.DefaultCost}, &argon2idHasher{}}
+}
 
 func VerifyPassword(password, hashedPassword string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	for _, hasher := range passwordHashers() {
+		if hasher.Identifies(hashedPassword) {
+			return hasher.Verify(password, hashedPassword)
+		}
+	}
+	return ErrPasswordMismatch
 }
 
 func HashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	return NewPasswordHasher().Hash(password)
+}
+
+// PasswordNeedsRehash should be checked after a successful VerifyPassword, the
+// password is then rehashed with the configured algorithm and parameters.
+func PasswordNeedsRehash(hashedPassword string) bool {
+	return NewPasswordHasher().NeedsRehash(hashedPassword)
+}
+
+type bcryptHasher struct {
+	cost int
+}
+
+func (h *bcryptHasher) Hash(password string) (string, error) {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
 	if err != nil {
 		return "", err
 	}
 	return string(hashedPassword), nil
 }
+
+func (h *bcryptHasher) Verify(password, encoded string) error {
+	if err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)); err != nil {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+func (h *bcryptHasher) Identifies(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
+
+func (h *bcryptHasher) NeedsRehash(encoded string) bool {
+	if !h.Identifies(encoded) {
+		return true
+	}
+	cost, err := bcrypt.Cost([]byte(encoded))
+	return err != nil || cost < h.cost
+}
+
+// argon2idHasher encodes hashes in the PHC string format:
+// $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
+type argon2idHasher struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	keyLen  uint32
+	saltLen uint32
+}
+
+type argon2idParams struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+func (h *argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.time, h.memory, h.threads, h.keyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.memory, h.time, h.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *argon2idHasher) Verify(password, encoded string) error {
+	params, err := decodeArgon2id(encoded)
+	if err != nil {
+		return err
+	}
+
+	key := argon2.IDKey([]byte(password), params.salt, params.time, params.memory, params.threads, uint32(len(params.key)))
+
+	if subtle.ConstantTimeCompare(key, params.key) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+func (h *argon2idHasher) Identifies(encoded string) bool {
+	return strings.HasPrefix(encoded, "$argon2id$")
+}
+
+func (h *argon2idHasher) NeedsRehash(encoded string) bool {
+	params, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+	return params.memory < h.memory || params.time < h.time || params.threads < h.threads || uint32(len(params.key)) < h.keyLen
+}
+
+func decodeArgon2id(encoded string) (*argon2idParams, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, errors.New("invalid argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, errors.New("unsupported argon2id version")
+	}
+
+	params := &argon2idParams{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return nil, errors.New("invalid argon2id parameters")
+	}
+
+	var err error
+	if params.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, errors.New("invalid argon2id salt")
+	}
+	if params.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return nil, errors.New("invalid argon2id hash")
+	}
+
+	return params, nil
+}
@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMiddleware keeps the caller supplied request id or generates one,
+// exposing it as "request_id" in the context and echoing it in the response.
+func RequestIDMiddleware(c *gin.Context) {
+	requestID := c.GetHeader(RequestIDHeader)
+	if requestID == "" || len(requestID) > 64 {
+		b := make([]byte, 16)
+		rand.Read(b)
+		requestID = hex.EncodeToString(b)
+	}
+
+	c.Set("request_id", requestID)
+	c.Header(RequestIDHeader, requestID)
+
+	c.Next()
+}
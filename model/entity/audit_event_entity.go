@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// AuditEvent is append-only, updates and deletes are rejected by a database
+// trigger created in app.Migrate. ActorID and ImpersonatorID
+// deliberately have no foreign key so that events outlive the accounts they
+// mention.
+type AuditEvent struct {
+	ID             uint      `gorm:"primaryKey;autoIncrement"`
+	ActorID        *uint     `gorm:"index"`
+	ImpersonatorID *uint     `gorm:"index"`
+	Action         string    `gorm:"not null;type:varchar(100);index"`
+	TargetType     string    `gorm:"not null;type:varchar(50);index:idx_audit_target"`
+	TargetID       uint      `gorm:"index:idx_audit_target"`
+	Before         *string   `gorm:"type:jsonb"`
+	After          *string   `gorm:"type:jsonb"`
+	Diff           *string   `gorm:"type:jsonb"`
+	IP             string    `gorm:"type:varchar(45)"`
+	UserAgent      string    `gorm:"type:varchar(255)"`
+	RequestID      string    `gorm:"type:varchar(64);index"`
+	CreatedAt      time.Time `gorm:"index"`
+}
@@ -1,6 +1,9 @@
 package entity
 
+import "gorm.io/gorm"
+
 type Brand struct {
-	ID   uint   `gorm:"primaryKey;autoIncrement"`
-	Name string `gorm:"not null;type:varchar(50);unique"`
+	ID        uint           `gorm:"primaryKey;autoIncrement"`
+	Name      string         `gorm:"not null;type:varchar(50);index:idx_brand_name,unique,where:deleted_at IS NULL"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Car struct {
 	ID               uint             `gorm:"primaryKey;autoIncrement"`
@@ -9,8 +13,12 @@ type Car struct {
 	Model            string           `gorm:"not null;type:varchar(50)"`
 	Year             int16            `gorm:"not null;type:smallint;index:idx_year"`
 	ImageUrl         string           `gorm:"not null;type:varchar(255)"`
+	MediaID          *uint            `gorm:"index"`
 	CarSpecification CarSpecification `gorm:"foreignKey:CarID"`
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
-	Brand            Brand `gorm:"foreignKey:BrandID"`
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
+	Brand            Brand          `gorm:"foreignKey:BrandID"`
+	Media            *Media         `gorm:"foreignKey:MediaID"`
+	Gallery          []GalleryImage `gorm:"polymorphic:Owner;polymorphicValue:cars"`
 }
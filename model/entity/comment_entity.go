@@ -1,14 +1,24 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Comment struct {
-	ID        uint   `gorm:"primaryKey;autoIncrement"`
-	ReviewID  uint   `gorm:"not null"`
-	UserID    uint   `gorm:"not null"`
-	Content   string `gorm:"not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Review    Review `gorm:"foreignKey:ReviewID"`
-	User      User   `gorm:"foreignKey:UserID"`
+	ID          uint    `gorm:"primaryKey;autoIncrement"`
+	ReviewID    uint    `gorm:"not null"`
+	ParentID    *uint   `gorm:"index"`
+	UserID      uint    `gorm:"not null"`
+	Content     string  `gorm:"not null"`
+	Status      string  `gorm:"not null;type:varchar(20);default:published;index"`
+	ContentHash string  `gorm:"type:varchar(64);index"`
+	Screening   *string `gorm:"type:jsonb"`
+	Reactions   string  `gorm:"not null;type:jsonb;default:'{}'"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	Review      Review         `gorm:"foreignKey:ReviewID"`
+	User        User           `gorm:"foreignKey:UserID"`
 }
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// CommentReaction is one emoji of one user on a comment, the counts are kept
+// on the comment itself.
+type CommentReaction struct {
+	CommentID uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"primaryKey;index"`
+	Emoji     string `gorm:"primaryKey;type:varchar(32)"`
+	CreatedAt time.Time
+	Comment   Comment `gorm:"foreignKey:CommentID"`
+	User      User    `gorm:"foreignKey:UserID"`
+}
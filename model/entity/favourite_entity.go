@@ -1,8 +1,39 @@
 package entity
 
+import "time"
+
+var (
+	CollectionPublic   = "public"
+	CollectionUnlisted = "unlisted"
+	CollectionPrivate  = "private"
+)
+
+// Favourite is a car in one of the collections of a user, the same car can be
+// in several collections. UserID is the owner of the collection.
 type Favourite struct {
-	UserID uint `gorm:"index:idx_favourite,unique"`
-	CarID  uint `gorm:"index:idx_favourite,unique"`
-	User   User `gorm:"foreignKey:UserID"`
-	Car    Car  `gorm:"foreignKey:CarID"`
+	UserID       uint   `gorm:"not null;index"`
+	CollectionID uint   `gorm:"index:idx_favourite_item,unique"`
+	CarID        uint   `gorm:"index:idx_favourite_item,unique"`
+	Note         string `gorm:"not null;type:varchar(500);default:''"`
+	Position     int    `gorm:"not null;default:0"`
+	CreatedAt    time.Time
+	User         User                `gorm:"foreignKey:UserID"`
+	Car          Car                 `gorm:"foreignKey:CarID"`
+	Collection   FavouriteCollection `gorm:"foreignKey:CollectionID"`
+}
+
+// FavouriteCollection is a named list of favourite cars. Public collections
+// are listed on the profile of their owner, unlisted ones are only found by
+// their slug and private ones are only seen by their owner. Every user with
+// favourites has one default collection, the one POST /api/favourites adds to.
+type FavouriteCollection struct {
+	ID         uint   `gorm:"primaryKey;autoIncrement"`
+	UserID     uint   `gorm:"not null;index;index:idx_collection_default,unique,where:is_default"`
+	Name       string `gorm:"not null;type:varchar(100)"`
+	Slug       string `gorm:"not null;type:varchar(120);uniqueIndex"`
+	Visibility string `gorm:"not null;type:varchar(10);default:private"`
+	IsDefault  bool   `gorm:"not null;default:false"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	User       User `gorm:"foreignKey:UserID"`
 }
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+var (
+	FollowTargetUser  = "users"
+	FollowTargetBrand = "brands"
+	FollowTargetCar   = "cars"
+)
+
+// Follow is a user following the user, brand or car named by TargetType and
+// TargetID. The reviews of what a user follows make up their feed. A brand or
+// car followed with Alerts is watched: the follower is also notified of its
+// new reviews and spec changes.
+type Follow struct {
+	FollowerID uint   `gorm:"primaryKey;autoIncrement:false"`
+	TargetType string `gorm:"primaryKey;type:varchar(10);index:idx_follow_target"`
+	TargetID   uint   `gorm:"primaryKey;autoIncrement:false;index:idx_follow_target"`
+	Alerts     bool   `gorm:"not null;default:false"`
+	CreatedAt  time.Time
+	Follower   User `gorm:"foreignKey:FollowerID"`
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+var (
+	GalleryOwnerCar    = "cars"
+	GalleryOwnerReview = "reviews"
+)
+
+// GalleryImage belongs to the car or the review named by OwnerType and
+// OwnerID. At most one image of a gallery is the cover, enforced by a partial
+// unique index created in app.Migrate, and the owner's image_url
+// mirrors it.
+type GalleryImage struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement"`
+	OwnerType string `gorm:"not null;type:varchar(20);index:idx_gallery_owner"`
+	OwnerID   uint   `gorm:"not null;index:idx_gallery_owner"`
+	MediaID   *uint  `gorm:"index"`
+	ImageUrl  string `gorm:"not null;type:varchar(255)"`
+	Caption   string `gorm:"not null;type:varchar(255);default:''"`
+	Position  int    `gorm:"not null;default:0"`
+	IsCover   bool   `gorm:"not null;default:false"`
+	CreatedAt time.Time
+	Media     *Media `gorm:"foreignKey:MediaID"`
+}
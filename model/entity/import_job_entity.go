@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+var (
+	ImportJobPending   = "pending"
+	ImportJobRunning   = "running"
+	ImportJobCompleted = "completed"
+	ImportJobFailed    = "failed"
+)
+
+type ImportJob struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement"`
+	Kind        string `gorm:"not null;type:varchar(20)"`
+	Status      string `gorm:"not null;type:varchar(20);index"`
+	Format      string `gorm:"not null;type:varchar(10)"`
+	CreatedByID *uint  `gorm:"index"`
+	TotalRows   int    `gorm:"not null;default:0"`
+	Processed   int    `gorm:"not null;default:0"`
+	Created     int    `gorm:"not null;default:0"`
+	Updated     int    `gorm:"not null;default:0"`
+	Failed      int    `gorm:"not null;default:0"`
+	Error       *string
+	Report      *string `gorm:"type:jsonb"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	FinishedAt  *time.Time
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+type Media struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement"`
+	OwnerID     uint   `gorm:"not null;index"`
+	ContentType string `gorm:"not null;type:varchar(50)"`
+	Size        int64  `gorm:"not null"`
+	Width       int    `gorm:"not null"`
+	Height      int    `gorm:"not null"`
+	SHA256      string `gorm:"not null;type:char(64);index"`
+	CreatedAt   time.Time
+	Owner       User           `gorm:"foreignKey:OwnerID"`
+	Variants    []MediaVariant `gorm:"foreignKey:MediaID;constraint:OnDelete:CASCADE"`
+}
+
+type MediaVariant struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement"`
+	MediaID     uint   `gorm:"not null;uniqueIndex:idx_media_variant"`
+	Name        string `gorm:"not null;type:varchar(20);uniqueIndex:idx_media_variant"`
+	ContentType string `gorm:"not null;type:varchar(50)"`
+	Width       int    `gorm:"not null"`
+	Height      int    `gorm:"not null"`
+	Size        int64  `gorm:"not null"`
+	StorageKey  string `gorm:"not null;type:varchar(255)"`
+}
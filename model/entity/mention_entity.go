@@ -0,0 +1,19 @@
+package entity
+
+var (
+	MentionOwnerReview  = "reviews"
+	MentionOwnerComment = "comments"
+)
+
+// Mention links an @username in the content of the review or the comment
+// named by OwnerType and OwnerID to the user. Start and End are the positions
+// of the mention in characters, they are recomputed on every edit.
+type Mention struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement"`
+	OwnerType string `gorm:"not null;type:varchar(20);index:idx_mention_owner"`
+	OwnerID   uint   `gorm:"not null;index:idx_mention_owner"`
+	UserID    uint   `gorm:"not null;index"`
+	Start     int    `gorm:"not null"`
+	End       int    `gorm:"not null"`
+	User      User   `gorm:"foreignKey:UserID"`
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+var (
+	NotificationReviewComment   = "review_comment"
+	NotificationCommentReply    = "comment_reply"
+	NotificationMention         = "mention"
+	NotificationFavouriteReview = "favourite_review"
+	NotificationModeration      = "moderation"
+	NotificationWatchReview     = "watch_review"
+	NotificationWatchSpecs      = "watch_specs"
+)
+
+// NotificationTypes lists every type a user can turn off in their
+// preferences.
+var NotificationTypes = []string{NotificationReviewComment, NotificationCommentReply, NotificationMention, NotificationFavouriteReview, NotificationModeration, NotificationWatchReview, NotificationWatchSpecs}
+
+// NotificationWatchTypes are the types sent again in the watch digest.
+var NotificationWatchTypes = []string{NotificationFavouriteReview, NotificationWatchReview, NotificationWatchSpecs}
+
+// Notification tells the user that the actor did something to the target,
+// for example mentioned them in the comment TargetID.
+type Notification struct {
+	ID         uint   `gorm:"primaryKey;autoIncrement"`
+	UserID     uint   `gorm:"not null;index"`
+	Type       string `gorm:"not null;type:varchar(40)"`
+	ActorID    *uint  `gorm:"index"`
+	TargetType string `gorm:"not null;type:varchar(20)"`
+	TargetID   uint   `gorm:"not null"`
+	Message    string `gorm:"not null"`
+	ReadAt     *time.Time
+	CreatedAt  time.Time
+	User       User  `gorm:"foreignKey:UserID"`
+	Actor      *User `gorm:"foreignKey:ActorID"`
+}
+
+// NotificationPreference turns a notification type on or off for a user,
+// every type is on without a row.
+type NotificationPreference struct {
+	UserID    uint   `gorm:"primaryKey"`
+	Type      string `gorm:"primaryKey;type:varchar(40)"`
+	Enabled   bool   `gorm:"not null"`
+	UpdatedAt time.Time
+	User      User `gorm:"foreignKey:UserID"`
+}
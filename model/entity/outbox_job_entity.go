@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+const (
+	OutboxJobPending = "pending"
+	OutboxJobDone    = "done"
+	OutboxJobDead    = "dead"
+)
+
+// OutboxJob is a side effect written in the transaction of the change that
+// caused it and run after the commit by the handler registered for Kind. A
+// job that keeps failing is dead-lettered until it is replayed.
+type OutboxJob struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	Kind        string    `gorm:"not null;type:varchar(60);index"`
+	Payload     string    `gorm:"not null;type:jsonb"`
+	Status      string    `gorm:"not null;type:varchar(20);default:pending;index:idx_outbox_job_due"`
+	Attempts    int       `gorm:"not null;default:0"`
+	RunAt       time.Time `gorm:"not null;index:idx_outbox_job_due"`
+	LastError   string    `gorm:"not null;default:''"`
+	ProcessedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
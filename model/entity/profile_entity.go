@@ -1,6 +1,7 @@
 package entity
 
 var RoleAdmin = "ADMIN"
+var RoleModerator = "MODERATOR"
 var RoleUser = "USER"
 
 type Profile struct {
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// The moderation status of reviews and comments, only published ones are
+// shown to everyone.
+var (
+	StatusPending   = "pending"
+	StatusPublished = "published"
+	StatusHidden    = "hidden"
+	StatusRejected  = "rejected"
+)
+
+var (
+	ReportTargetReview  = "reviews"
+	ReportTargetComment = "comments"
+)
+
+// Report flags the review or the comment named by TargetType and TargetID,
+// once per reporter. A report stays open until a moderator decides on the
+// item.
+type Report struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement"`
+	TargetType string     `gorm:"not null;type:varchar(20);uniqueIndex:idx_report_target_reporter"`
+	TargetID   uint       `gorm:"not null;uniqueIndex:idx_report_target_reporter"`
+	ReporterID uint       `gorm:"not null;uniqueIndex:idx_report_target_reporter"`
+	Reason     string     `gorm:"not null;type:varchar(20)"`
+	Details    string     `gorm:"not null;type:varchar(500);default:''"`
+	ResolvedAt *time.Time `gorm:"index"`
+	CreatedAt  time.Time
+	Reporter   User `gorm:"foreignKey:ReporterID"`
+}
@@ -1,16 +1,31 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Review struct {
-	ID        uint   `gorm:"primaryKey;autoIncrement"`
-	CarID     uint   `gorm:"not null;index:idx_car_id_user_id,unique"`
-	UserID    uint   `gorm:"not null;index:idx_car_id_user_id,unique"`
-	Title     string `gorm:"not null;type:varchar(100)"`
-	Content   string `gorm:"not null"`
-	ImageUrl  string `gorm:"not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	User      User `gorm:"foreignKey:UserID"`
-	Car       Car  `gorm:"foreignKey:CarID"`
+	ID             uint      `gorm:"primaryKey;autoIncrement"`
+	CarID          uint      `gorm:"not null;index:idx_review_car_user,unique,where:deleted_at IS NULL;index:idx_review_car_created,priority:1"`
+	UserID         uint      `gorm:"not null;index:idx_review_car_user,unique,where:deleted_at IS NULL;index:idx_review_user_created,priority:1"`
+	Title          string    `gorm:"not null;type:varchar(100)"`
+	Content        string    `gorm:"not null"`
+	ImageUrl       string    `gorm:"not null"`
+	MediaID        *uint     `gorm:"index"`
+	Status         string    `gorm:"not null;type:varchar(20);default:published;index"`
+	ContentHash    string    `gorm:"type:varchar(64);index"`
+	Screening      *string   `gorm:"type:jsonb"`
+	HelpfulCount   int       `gorm:"not null;default:0"`
+	UnhelpfulCount int       `gorm:"not null;default:0"`
+	HelpfulScore   float64   `gorm:"not null;default:0;index"`
+	CreatedAt      time.Time `gorm:"index:idx_review_car_created,priority:2;index:idx_review_user_created,priority:2"`
+	UpdatedAt      time.Time
+	EditedAt       *time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+	User           User           `gorm:"foreignKey:UserID"`
+	Car            Car            `gorm:"foreignKey:CarID"`
+	Media          *Media         `gorm:"foreignKey:MediaID"`
+	Gallery        []GalleryImage `gorm:"polymorphic:Owner;polymorphicValue:reviews"`
 }
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// ReviewVote is one user's verdict on a review, the tallies are kept on the
+// review itself.
+type ReviewVote struct {
+	ReviewID  uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"primaryKey;index"`
+	Helpful   bool `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Review    Review `gorm:"foreignKey:ReviewID"`
+	User      User   `gorm:"foreignKey:UserID"`
+}
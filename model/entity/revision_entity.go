@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+var (
+	RevisionOwnerCar    = "cars"
+	RevisionOwnerReview = "reviews"
+)
+
+// Revision records one edit of the car or the review named by OwnerType and
+// OwnerID, numbered from 1 per owner. Revisions are never updated, enforced
+// by a trigger created in app.Migrate, and only deleted together with
+// their owner. EditorID has no foreign key so that revisions outlive the
+// accounts that made them, and is nil for edits made without a token.
+type Revision struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	OwnerType string    `gorm:"not null;type:varchar(20);uniqueIndex:idx_revision_owner_number"`
+	OwnerID   uint      `gorm:"not null;uniqueIndex:idx_revision_owner_number"`
+	Number    int       `gorm:"not null;uniqueIndex:idx_revision_owner_number"`
+	EditorID  *uint     `gorm:"index"`
+	Before    string    `gorm:"not null;type:jsonb"`
+	After     string    `gorm:"not null;type:jsonb"`
+	Diff      string    `gorm:"not null;type:jsonb"`
+	CreatedAt time.Time `gorm:"index"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type Session struct {
+	ID             uint   `gorm:"primaryKey;autoIncrement"`
+	TokenID        string `gorm:"not null;type:varchar(64);unique"`
+	UserID         uint   `gorm:"not null;index"`
+	ImpersonatorID *uint
+	UserAgent      string `gorm:"type:varchar(255)"`
+	IP             string `gorm:"type:varchar(45)"`
+	CreatedAt      time.Time
+	LastSeenAt     time.Time
+	ExpiresAt      time.Time `gorm:"not null;index"`
+	User           User      `gorm:"foreignKey:UserID"`
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// StreamEvent is an event pushed to the clients subscribed to its topic, for
+// example a new comment on the topic review:12. The events are kept for a
+// while so that clients can resume with the ID of the last event they got.
+type StreamEvent struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	Topic     string    `gorm:"not null;type:varchar(60);index"`
+	Event     string    `gorm:"not null;type:varchar(40)"`
+	Data      string    `gorm:"not null;type:jsonb"`
+	CreatedAt time.Time `gorm:"index"`
+}
@@ -3,12 +3,25 @@ package entity
 import "time"
 
 type User struct {
-	ID        uint   `gorm:"primaryKey;autoIncrement"`
-	Username  string `gorm:"unique;not null;type:varchar(20)"`
-	Email     string `gorm:"unique;not null;type:varchar(50)"`
-	Password  string `gorm:"not null"`
-	Role      string `sql:"type:enum('ADMIN', 'USER')" gorm:"default:'USER'"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Profile   Profile `gorm:"foreignKey:UserID"`
+	ID                    uint   `gorm:"primaryKey;autoIncrement"`
+	Username              string `gorm:"unique;not null;type:varchar(20)"`
+	Email                 string `gorm:"unique;not null;type:varchar(50)"`
+	Password              string `gorm:"not null"`
+	Role                  string `sql:"type:enum('ADMIN', 'MODERATOR', 'USER')" gorm:"default:'USER'"`
+	EmailVerifiedAt       *time.Time
+	BannedAt              *time.Time `gorm:"index"`
+	BanReason             *string    `gorm:"type:varchar(255)"`
+	BanExpiresAt          *time.Time
+	PasswordResetRequired bool `gorm:"not null;default:false"`
+	FollowerCount         int  `gorm:"not null;default:0"`
+	FollowingCount        int  `gorm:"not null;default:0"`
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	Profile               Profile `gorm:"foreignKey:UserID"`
+}
+
+// IsBanned reports whether the user is banned at the given time, a ban
+// without expiry is permanent.
+func (user *User) IsBanned(now time.Time) bool {
+	return user.BannedAt != nil && (user.BanExpiresAt == nil || user.BanExpiresAt.After(now))
 }
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+type UserIdentity struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement"`
+	UserID    uint   `gorm:"not null;index"`
+	Provider  string `gorm:"not null;type:varchar(50);index:idx_provider_subject,unique"`
+	Subject   string `gorm:"not null;type:varchar(255);index:idx_provider_subject,unique"`
+	Email     string `gorm:"not null;type:varchar(50)"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	User      User `gorm:"foreignKey:UserID"`
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+var (
+	WatchDigestDaily  = "daily"
+	WatchDigestWeekly = "weekly"
+)
+
+// WatchDigest emails a user their watch notifications once a day or once a
+// week, users without a row get no digest. LastSentAt is when the last digest
+// was due, the next one covers the notifications since then.
+type WatchDigest struct {
+	UserID     uint      `gorm:"primaryKey;autoIncrement:false"`
+	Frequency  string    `gorm:"not null;type:varchar(10)"`
+	LastSentAt time.Time `gorm:"not null;index"`
+	UpdatedAt  time.Time
+	User       User `gorm:"foreignKey:UserID"`
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook posts the events it subscribes to, a JSON array of event names, to
+// URL signed with Secret.
+type Webhook struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement"`
+	URL         string `gorm:"not null;type:varchar(2048)"`
+	Secret      string `gorm:"not null;type:varchar(255)"`
+	Events      string `gorm:"not null;type:jsonb"`
+	Description string `gorm:"not null;type:varchar(255);default:''"`
+	Active      bool   `gorm:"not null;default:true"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Deliveries  []WebhookDelivery `gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE"`
+}
+
+// WebhookDelivery is one event to post to a webhook and the outcome of its
+// last attempt. A redelivery is a new delivery of the same payload.
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primaryKey;autoIncrement"`
+	WebhookID      uint       `gorm:"not null;index"`
+	Event          string     `gorm:"not null;type:varchar(40)"`
+	Payload        string     `gorm:"not null;type:jsonb"`
+	Status         string     `gorm:"not null;type:varchar(20);default:pending;index:idx_webhook_delivery_due"`
+	Attempts       int        `gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time `gorm:"index:idx_webhook_delivery_due"`
+	LastAttemptAt  *time.Time
+	ResponseStatus *int
+	ResponseBody   string `gorm:"not null;default:''"`
+	Error          string `gorm:"not null;default:''"`
+	RedeliveryOf   *uint
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
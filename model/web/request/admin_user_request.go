@@ -0,0 +1,21 @@
+package request
+
+import "time"
+
+type AdminUserQueryRequest struct {
+	Search      *string    `form:"search" extensions:"x-order=0"`
+	Role        *string    `form:"role" binding:"omitempty,oneof=ADMIN MODERATOR USER" extensions:"x-order=1"`
+	Verified    *bool      `form:"verified" extensions:"x-order=2"`
+	Banned      *bool      `form:"banned" extensions:"x-order=3"`
+	CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00" extensions:"x-order=4"`
+	CreatedTo   *time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00" extensions:"x-order=5"`
+}
+
+type AdminUpdateRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=ADMIN MODERATOR USER" example:"ADMIN"`
+}
+
+type AdminBanUserRequest struct {
+	Reason    string     `json:"reason" binding:"required,max=255" example:"Spam" extensions:"x-order=0"`
+	ExpiresAt *time.Time `json:"expires_at" example:"2030-01-01T00:00:00Z" extensions:"x-order=1"`
+}
@@ -0,0 +1,12 @@
+package request
+
+import "time"
+
+type AuditQueryRequest struct {
+	Actor      *uint      `form:"actor" extensions:"x-order=0"`
+	Action     *string    `form:"action" extensions:"x-order=1"`
+	TargetType *string    `form:"target_type" extensions:"x-order=2"`
+	TargetID   *uint      `form:"target_id" extensions:"x-order=3"`
+	From       *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00" extensions:"x-order=4"`
+	To         *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00" extensions:"x-order=5"`
+}
@@ -0,0 +1,7 @@
+package request
+
+type CarImportRequest struct {
+	Format       string `form:"format" binding:"omitempty,oneof=csv ndjson" extensions:"x-order=0"`
+	DryRun       bool   `form:"dry_run" extensions:"x-order=1"`
+	CreateBrands bool   `form:"create_brands" extensions:"x-order=2"`
+}
@@ -13,7 +13,8 @@ type CarCreateRequest struct {
 	Name                string  `json:"name" binding:"required" extensions:"x-order=1"`
 	Model               string  `json:"model" binding:"required" extensions:"x-order=1"`
 	Year                int16   `json:"year" binding:"required,min=1878" extensions:"x-order=2"`
-	ImageUrl            string  `json:"image_url" binding:"required,url" extensions:"x-order=3"`
+	ImageUrl            string  `json:"image_url" binding:"required_without=MediaID,omitempty,url" extensions:"x-order=3"`
+	MediaID             *uint   `json:"media_id" extensions:"x-order=3"`
 	Width               int16   `json:"width" binding:"required" extensions:"x-order=4"`
 	Height              int16   `json:"height" binding:"required" extensions:"x-order=5"`
 	Length              int16   `json:"length" binding:"required" extensions:"x-order=6"`
@@ -33,6 +34,7 @@ type CarUpdateRequest struct {
 	Model               string  `json:"model" extensions:"x-order=1"`
 	Year                int16   `json:"year" binding:"omitempty,min=1878" extensions:"x-order=2"`
 	ImageUrl            string  `json:"image_url" binding:"omitempty,url" extensions:"x-order=3"`
+	MediaID             *uint   `json:"media_id" extensions:"x-order=3"`
 	Width               int16   `json:"width" extensions:"x-order=4"`
 	Height              int16   `json:"height" extensions:"x-order=5"`
 	Length              int16   `json:"length" extensions:"x-order=6"`
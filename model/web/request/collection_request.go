@@ -0,0 +1,24 @@
+package request
+
+type CollectionCreateRequest struct {
+	Name       string `json:"name" binding:"required,max=100" example:"Family SUVs" extensions:"x-order=0"`
+	Visibility string `json:"visibility" binding:"omitempty,oneof=public unlisted private" example:"unlisted" extensions:"x-order=1"`
+}
+
+type CollectionUpdateRequest struct {
+	Name       string `json:"name" binding:"omitempty,max=100" example:"Dream garage" extensions:"x-order=0"`
+	Visibility string `json:"visibility" binding:"omitempty,oneof=public unlisted private" example:"public" extensions:"x-order=1"`
+}
+
+type CollectionItemCreateRequest struct {
+	CarID uint   `json:"car_id" binding:"required" example:"1" extensions:"x-order=0"`
+	Note  string `json:"note" binding:"max=500" example:"Test drive in spring" extensions:"x-order=1"`
+}
+
+type CollectionItemUpdateRequest struct {
+	Note string `json:"note" binding:"max=500" example:"Too small for the dog" extensions:"x-order=0"`
+}
+
+type CollectionReorderRequest struct {
+	CarIDs []uint `json:"car_ids" binding:"required,min=1" extensions:"x-order=0"`
+}
@@ -3,8 +3,14 @@ package request
 type CommentCreateRequest struct {
 	ReviewID uint   `json:"review_id" binding:"required" extensions:"x-order=0"`
 	Content  string `json:"content" binding:"required" extensions:"x-order=1"`
+	ParentID *uint  `json:"parent_id" extensions:"x-order=2"`
 }
 
 type CommentUpdateRequest struct {
 	Content string `json:"content" binding:"required" extensions:"x-order=0"`
 }
+
+type CommentQueryRequest struct {
+	ReviewID *uint `form:"review_id" extensions:"x-order=0"`
+	UserID   *uint `form:"user_id" extensions:"x-order=1"`
+}
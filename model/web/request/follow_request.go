@@ -0,0 +1,10 @@
+package request
+
+type FollowingQueryRequest struct {
+	Type string `form:"type" binding:"omitempty,oneof=users brands cars" extensions:"x-order=0"`
+}
+
+type FeedRequest struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=50" extensions:"x-order=0"`
+	Cursor string `form:"cursor" extensions:"x-order=1"`
+}
@@ -0,0 +1,17 @@
+package request
+
+type GalleryImageCreateRequest struct {
+	ImageUrl string `json:"image_url" binding:"required_without=MediaID,omitempty,url" extensions:"x-order=0"`
+	MediaID  *uint  `json:"media_id" extensions:"x-order=1"`
+	Caption  string `json:"caption" binding:"max=255" extensions:"x-order=2"`
+	IsCover  bool   `json:"is_cover" extensions:"x-order=3"`
+}
+
+type GalleryImageUpdateRequest struct {
+	Caption *string `json:"caption" binding:"omitempty,max=255" extensions:"x-order=0"`
+	IsCover *bool   `json:"is_cover" extensions:"x-order=1"`
+}
+
+type GalleryReorderRequest struct {
+	ImageIDs []uint `json:"image_ids" binding:"required,min=1" extensions:"x-order=0"`
+}
@@ -0,0 +1,16 @@
+package request
+
+type ReportCreateRequest struct {
+	Reason  string `json:"reason" binding:"required,oneof=spam harassment hate_speech misinformation off_topic other" example:"spam" extensions:"x-order=0"`
+	Details string `json:"details" binding:"max=500" example:"Links to a scam shop" extensions:"x-order=1"`
+}
+
+type ModerationQueueRequest struct {
+	Type   *string `form:"type" binding:"omitempty,oneof=reviews comments" extensions:"x-order=0"`
+	Status *string `form:"status" binding:"omitempty,oneof=pending published hidden rejected" extensions:"x-order=1"`
+}
+
+type ModerationDecisionRequest struct {
+	Action string `json:"action" binding:"required,oneof=approve hide reject" example:"hide" extensions:"x-order=0"`
+	Note   string `json:"note" binding:"max=500" example:"Advertising" extensions:"x-order=1"`
+}
@@ -0,0 +1,9 @@
+package request
+
+type NotificationQueryRequest struct {
+	Unread *bool `form:"unread" extensions:"x-order=0"`
+}
+
+type NotificationPreferenceRequest struct {
+	Preferences map[string]bool `json:"preferences" binding:"required" extensions:"x-order=0"`
+}
@@ -0,0 +1,5 @@
+package request
+
+type ReviewVoteRequest struct {
+	Helpful *bool `json:"helpful" binding:"required" extensions:"x-order=0"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type RevisionDiffRequest struct {
+	From int `form:"from" binding:"min=0" extensions:"x-order=0"`
+	To   int `form:"to" binding:"required,min=1" extensions:"x-order=1"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type StreamRequest struct {
+	Topics      string `form:"topics" binding:"required" example:"review:12,user:me" extensions:"x-order=0"`
+	LastEventID uint64 `form:"last_event_id" example:"42" extensions:"x-order=1"`
+}
@@ -3,7 +3,7 @@ package request
 type RegisterRequest struct {
 	Username string `json:"username" binding:"required,min=3,max=20,no_space,lowercase" extensions:"x-order=0"`
 	Email    string `json:"email" binding:"required,email" extensions:"x-order=1"`
-	Password string `json:"password" binding:"required,min=8,no_space" example:"password" extensions:"x-order=2"`
+	Password string `json:"password" binding:"required" example:"password" extensions:"x-order=2"`
 }
 
 type ForgotPasswordRequest struct {
@@ -13,7 +13,7 @@ type ForgotPasswordRequest struct {
 
 type ResetPasswordRequest struct {
 	Token       string `json:"token" binding:"required" example:"token" extensions:"x-order=0"`
-	NewPassword string `json:"new_password" binding:"required,min=8" example:"new_password" extensions:"x-order=1"`
+	NewPassword string `json:"new_password" binding:"required" example:"new_password" extensions:"x-order=1"`
 }
 
 type LoginRequest struct {
@@ -22,7 +22,8 @@ type LoginRequest struct {
 }
 
 type UpdatePasswordRequest struct {
-	Password string `json:"password" binding:"required,min=3,no_space"`
+	CurrentPassword string `json:"current_password" binding:"required" example:"password" extensions:"x-order=0"`
+	Password        string `json:"password" binding:"required" example:"new_password" extensions:"x-order=1"`
 }
 
 type UpdateUserProfileRequest struct {
@@ -0,0 +1,9 @@
+package request
+
+type WatchQueryRequest struct {
+	Type string `form:"type" binding:"omitempty,oneof=cars brands" extensions:"x-order=0"`
+}
+
+type WatchDigestRequest struct {
+	Frequency string `json:"frequency" binding:"required,oneof=off daily weekly" example:"weekly" extensions:"x-order=0"`
+}
@@ -0,0 +1,21 @@
+package request
+
+type WebhookCreateRequest struct {
+	URL         string   `json:"url" binding:"required,url,max=2048" example:"https://partner.example.com/hooks/carreview" extensions:"x-order=0"`
+	Secret      string   `json:"secret" binding:"omitempty,min=16,max=255" example:"0123456789abcdef0123456789abcdef" extensions:"x-order=1"`
+	Events      []string `json:"events" binding:"required,min=1" example:"car.created,review.published" extensions:"x-order=2"`
+	Description string   `json:"description" binding:"max=255" example:"Partner catalogue sync" extensions:"x-order=3"`
+	Active      *bool    `json:"active" example:"true" extensions:"x-order=4"`
+}
+
+type WebhookUpdateRequest struct {
+	URL         string   `json:"url" binding:"omitempty,url,max=2048" example:"https://partner.example.com/hooks/carreview" extensions:"x-order=0"`
+	Secret      string   `json:"secret" binding:"omitempty,min=16,max=255" example:"0123456789abcdef0123456789abcdef" extensions:"x-order=1"`
+	Events      []string `json:"events" binding:"omitempty,min=1" example:"car.created,car.updated" extensions:"x-order=2"`
+	Description *string  `json:"description" binding:"omitempty,max=255" example:"Partner catalogue sync" extensions:"x-order=3"`
+	Active      *bool    `json:"active" example:"false" extensions:"x-order=4"`
+}
+
+type WebhookDeliveryQueryRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending delivered failed" extensions:"x-order=0"`
+}
@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type AdminUserResponse struct {
+	ID                    uint       `json:"id" example:"1" extensions:"x-order=0"`
+	Username              string     `json:"username" example:"luigi" extensions:"x-order=1"`
+	Email                 string     `json:"email" example:"luigi@sam.com" extensions:"x-order=2"`
+	Role                  string     `json:"role" example:"USER" extensions:"x-order=3"`
+	EmailVerified         bool       `json:"email_verified" example:"true" extensions:"x-order=4"`
+	Banned                bool       `json:"banned" example:"false" extensions:"x-order=5"`
+	BanReason             *string    `json:"ban_reason" example:"Spam" extensions:"x-order=6"`
+	BanExpiresAt          *time.Time `json:"ban_expires_at" example:"2030-01-01T00:00:00Z" extensions:"x-order=7"`
+	PasswordResetRequired bool       `json:"password_reset_required" example:"false" extensions:"x-order=8"`
+	CreatedAt             time.Time  `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=9"`
+}
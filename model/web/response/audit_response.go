@@ -0,0 +1,22 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditEventResponse struct {
+	ID             uint            `json:"id" example:"1" extensions:"x-order=0"`
+	ActorID        *uint           `json:"actor_id" example:"1" extensions:"x-order=1"`
+	ImpersonatorID *uint           `json:"impersonator_id" example:"1" extensions:"x-order=1"`
+	Action         string          `json:"action" example:"car.update" extensions:"x-order=2"`
+	TargetType     string          `json:"target_type" example:"car" extensions:"x-order=3"`
+	TargetID       uint            `json:"target_id" example:"2" extensions:"x-order=4"`
+	Before         json.RawMessage `json:"before" swaggertype:"object" extensions:"x-order=5"`
+	After          json.RawMessage `json:"after" swaggertype:"object" extensions:"x-order=6"`
+	Diff           json.RawMessage `json:"diff" swaggertype:"object" extensions:"x-order=7"`
+	IP             string          `json:"ip" example:"127.0.0.1" extensions:"x-order=8"`
+	UserAgent      string          `json:"user_agent" example:"Mozilla/5.0" extensions:"x-order=9"`
+	RequestID      string          `json:"request_id" example:"4f1c2a..." extensions:"x-order=10"`
+	CreatedAt      time.Time       `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=11"`
+}
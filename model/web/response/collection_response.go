@@ -0,0 +1,27 @@
+package response
+
+import "time"
+
+type CollectionResponse struct {
+	ID         uint      `json:"id" example:"1" extensions:"x-order=0"`
+	UserID     uint      `json:"user_id" example:"2" extensions:"x-order=1"`
+	Username   string    `json:"username" example:"luigi" extensions:"x-order=2"`
+	Name       string    `json:"name" example:"Family SUVs" extensions:"x-order=3"`
+	Slug       string    `json:"slug" example:"family-suvs-k3x9q2" extensions:"x-order=4"`
+	Visibility string    `json:"visibility" example:"unlisted" extensions:"x-order=5"`
+	IsDefault  bool      `json:"is_default" example:"false" extensions:"x-order=6"`
+	ItemCount  int64     `json:"item_count" example:"3" extensions:"x-order=7"`
+	CreatedAt  time.Time `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=8"`
+	UpdatedAt  time.Time `json:"updated_at" example:"2022-01-02T00:00:00Z" extensions:"x-order=9"`
+}
+
+type CollectionItemResponse struct {
+	CarID    uint      `json:"car_id" example:"1" extensions:"x-order=0"`
+	Brand    string    `json:"brand" example:"Honda" extensions:"x-order=1"`
+	Name     string    `json:"name" example:"Civic" extensions:"x-order=2"`
+	Model    string    `json:"model" example:"TYPE R" extensions:"x-order=3"`
+	ImageUrl string    `json:"image_url" example:"image url" extensions:"x-order=4"`
+	Note     string    `json:"note" example:"Test drive in spring" extensions:"x-order=5"`
+	Position int       `json:"position" example:"0" extensions:"x-order=6"`
+	AddedAt  time.Time `json:"added_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=7"`
+}
@@ -3,15 +3,35 @@ package response
 import "time"
 
 type CommentResponse struct {
-	ID        uint                `json:"id" example:"1" extensions:"x-order=0"`
-	ReviewID  uint                `json:"review_id" example:"2" extensions:"x-order=1"`
-	User      CommentUserResponse `json:"user" extensions:"x-order=2"`
-	Content   string              `json:"content" example:"Lorem ipsum dolor sit amet" extensions:"x-order=3"`
-	CreatedAt time.Time           `json:"created_at" example:"2022-01-01T00:00:00Z"`
-	UpdatedAt time.Time           `json:"updated_at" example:"2022-01-01T00:00:00Z"`
+	ID        uint                      `json:"id" example:"1" extensions:"x-order=0"`
+	ReviewID  uint                      `json:"review_id" example:"2" extensions:"x-order=1"`
+	ParentID  *uint                     `json:"parent_id" example:"4" extensions:"x-order=1"`
+	User      CommentUserResponse       `json:"user" extensions:"x-order=2"`
+	Content   string                    `json:"content" example:"Lorem ipsum dolor sit amet" extensions:"x-order=3"`
+	Status    string                    `json:"status" example:"published" extensions:"x-order=4"`
+	Mentions  []MentionResponse         `json:"mentions" extensions:"x-order=5"`
+	Reactions []CommentReactionResponse `json:"reactions" extensions:"x-order=5"`
+	CreatedAt time.Time                 `json:"created_at" example:"2022-01-01T00:00:00Z"`
+	UpdatedAt time.Time                 `json:"updated_at" example:"2022-01-01T00:00:00Z"`
+}
+
+type CommentReactionResponse struct {
+	Emoji   string `json:"emoji" example:"👍" extensions:"x-order=0"`
+	Count   int    `json:"count" example:"3" extensions:"x-order=1"`
+	Reacted bool   `json:"reacted" example:"true" extensions:"x-order=2"`
 }
 
 type CommentUserResponse struct {
 	ID       uint   `json:"id" example:"1" extensions:"x-order=0"`
 	Username string `json:"username" example:"luigi" extensions:"x-order=1"`
 }
+
+type CommentExportResponse struct {
+	ID        uint      `json:"id" example:"1" extensions:"x-order=0"`
+	ReviewID  uint      `json:"review_id" example:"2" extensions:"x-order=1"`
+	UserID    uint      `json:"user_id" example:"3" extensions:"x-order=2"`
+	Username  string    `json:"username" example:"luigi" extensions:"x-order=3"`
+	Content   string    `json:"content" example:"Lorem ipsum dolor sit amet" extensions:"x-order=4"`
+	CreatedAt time.Time `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=5"`
+	UpdatedAt time.Time `json:"updated_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=6"`
+}
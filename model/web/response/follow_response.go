@@ -0,0 +1,12 @@
+package response
+
+import "time"
+
+// FollowResponse is a followed user, brand or car, or a follower. Name is the
+// username, the brand name or the car name and model.
+type FollowResponse struct {
+	Type       string    `json:"type" example:"users" extensions:"x-order=0"`
+	ID         uint      `json:"id" example:"1" extensions:"x-order=1"`
+	Name       string    `json:"name" example:"luigi" extensions:"x-order=2"`
+	FollowedAt time.Time `json:"followed_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=3"`
+}
@@ -0,0 +1,10 @@
+package response
+
+type GalleryImageResponse struct {
+	ID       uint   `json:"id" example:"1" extensions:"x-order=0"`
+	ImageUrl string `json:"image_url" example:"image url" extensions:"x-order=1"`
+	MediaID  *uint  `json:"media_id" example:"1" extensions:"x-order=2"`
+	Caption  string `json:"caption" example:"Engine bay" extensions:"x-order=3"`
+	Position int    `json:"position" example:"0" extensions:"x-order=4"`
+	IsCover  bool   `json:"is_cover" example:"true" extensions:"x-order=5"`
+}
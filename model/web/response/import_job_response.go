@@ -0,0 +1,27 @@
+package response
+
+import "time"
+
+type ImportJobResponse struct {
+	ID         uint              `json:"id,omitempty" example:"1" extensions:"x-order=0"`
+	Kind       string            `json:"kind" example:"cars" extensions:"x-order=1"`
+	Status     string            `json:"status" example:"completed" extensions:"x-order=2"`
+	Format     string            `json:"format" example:"csv" extensions:"x-order=3"`
+	DryRun     bool              `json:"dry_run" example:"false" extensions:"x-order=4"`
+	TotalRows  int               `json:"total_rows" example:"120" extensions:"x-order=5"`
+	Processed  int               `json:"processed" example:"120" extensions:"x-order=6"`
+	Created    int               `json:"created" example:"100" extensions:"x-order=7"`
+	Updated    int               `json:"updated" example:"18" extensions:"x-order=8"`
+	Failed     int               `json:"failed" example:"2" extensions:"x-order=9"`
+	Error      *string           `json:"error" extensions:"x-order=10"`
+	Rows       []ImportRowReport `json:"rows" extensions:"x-order=11"`
+	CreatedAt  time.Time         `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=12"`
+	FinishedAt *time.Time        `json:"finished_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=13"`
+}
+
+type ImportRowReport struct {
+	Row    int               `json:"row" example:"2" extensions:"x-order=0"`
+	Action string            `json:"action" example:"create" extensions:"x-order=1"`
+	ID     *uint             `json:"id,omitempty" example:"1" extensions:"x-order=2"`
+	Errors map[string]string `json:"errors,omitempty" extensions:"x-order=3"`
+}
@@ -0,0 +1,24 @@
+package response
+
+import "time"
+
+type MediaResponse struct {
+	ID          uint                   `json:"id" example:"1" extensions:"x-order=0"`
+	OwnerID     uint                   `json:"owner_id" example:"2" extensions:"x-order=1"`
+	ContentType string                 `json:"content_type" example:"image/jpeg" extensions:"x-order=2"`
+	Size        int64                  `json:"size" example:"204800" extensions:"x-order=3"`
+	Width       int                    `json:"width" example:"1920" extensions:"x-order=4"`
+	Height      int                    `json:"height" example:"1080" extensions:"x-order=5"`
+	URL         string                 `json:"url" example:"https://example.com/api/media/1/original" extensions:"x-order=6"`
+	Variants    []MediaVariantResponse `json:"variants" extensions:"x-order=7"`
+	CreatedAt   time.Time              `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=8"`
+}
+
+type MediaVariantResponse struct {
+	Name        string `json:"name" example:"thumb_480" extensions:"x-order=0"`
+	ContentType string `json:"content_type" example:"image/jpeg" extensions:"x-order=1"`
+	Width       int    `json:"width" example:"480" extensions:"x-order=2"`
+	Height      int    `json:"height" example:"270" extensions:"x-order=3"`
+	Size        int64  `json:"size" example:"20480" extensions:"x-order=4"`
+	URL         string `json:"url" example:"https://example.com/api/media/1/thumb_480" extensions:"x-order=5"`
+}
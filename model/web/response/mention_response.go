@@ -0,0 +1,8 @@
+package response
+
+type MentionResponse struct {
+	UserID   uint   `json:"user_id" example:"3" extensions:"x-order=0"`
+	Username string `json:"username" example:"luigi" extensions:"x-order=1"`
+	Start    int    `json:"start" example:"6" extensions:"x-order=2"`
+	End      int    `json:"end" example:"12" extensions:"x-order=3"`
+}
@@ -0,0 +1,36 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type ReportResponse struct {
+	ID         uint      `json:"id" example:"1" extensions:"x-order=0"`
+	TargetType string    `json:"target_type" example:"reviews" extensions:"x-order=1"`
+	TargetID   uint      `json:"target_id" example:"2" extensions:"x-order=2"`
+	Reason     string    `json:"reason" example:"spam" extensions:"x-order=3"`
+	Details    string    `json:"details" example:"Links to a scam shop" extensions:"x-order=4"`
+	CreatedAt  time.Time `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=5"`
+}
+
+type ModerationQueueItemResponse struct {
+	TargetType     string          `json:"target_type" example:"reviews" extensions:"x-order=0"`
+	TargetID       uint            `json:"target_id" example:"2" extensions:"x-order=1"`
+	AuthorID       uint            `json:"author_id" example:"3" extensions:"x-order=2"`
+	AuthorUsername string          `json:"author_username" example:"luigi" extensions:"x-order=3"`
+	Status         string          `json:"status" example:"hidden" extensions:"x-order=4"`
+	Excerpt        string          `json:"excerpt" example:"Best car ever" extensions:"x-order=5"`
+	Reports        int64           `json:"reports" example:"3" extensions:"x-order=6"`
+	Reasons        []string        `json:"reasons" example:"spam,off_topic" extensions:"x-order=7"`
+	LastReportedAt *time.Time      `json:"last_reported_at" example:"2022-01-02T00:00:00Z" extensions:"x-order=8"`
+	Screening      json.RawMessage `json:"screening" swaggertype:"object" extensions:"x-order=9"`
+	CreatedAt      time.Time       `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=10"`
+}
+
+type ModerationDecisionResponse struct {
+	TargetType      string `json:"target_type" example:"reviews" extensions:"x-order=0"`
+	TargetID        uint   `json:"target_id" example:"2" extensions:"x-order=1"`
+	Status          string `json:"status" example:"hidden" extensions:"x-order=2"`
+	ResolvedReports int64  `json:"resolved_reports" example:"3" extensions:"x-order=3"`
+}
@@ -0,0 +1,24 @@
+package response
+
+import "time"
+
+type NotificationResponse struct {
+	ID            uint       `json:"id" example:"1" extensions:"x-order=0"`
+	Type          string     `json:"type" example:"mention" extensions:"x-order=1"`
+	ActorID       *uint      `json:"actor_id" example:"2" extensions:"x-order=2"`
+	ActorUsername *string    `json:"actor_username" example:"luigi" extensions:"x-order=3"`
+	TargetType    string     `json:"target_type" example:"comments" extensions:"x-order=4"`
+	TargetID      uint       `json:"target_id" example:"5" extensions:"x-order=5"`
+	Message       string     `json:"message" example:"luigi mentioned you in a comment" extensions:"x-order=6"`
+	ReadAt        *time.Time `json:"read_at" example:"2022-01-02T00:00:00Z" extensions:"x-order=7"`
+	CreatedAt     time.Time  `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=8"`
+}
+
+type NotificationReadAllResponse struct {
+	Read int64 `json:"read" example:"5" extensions:"x-order=0"`
+}
+
+type NotificationPreferenceResponse struct {
+	Type    string `json:"type" example:"mention" extensions:"x-order=0"`
+	Enabled bool   `json:"enabled" example:"true" extensions:"x-order=1"`
+}
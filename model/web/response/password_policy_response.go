@@ -0,0 +1,6 @@
+package response
+
+type PasswordPolicyViolation struct {
+	Rule    string `json:"rule" example:"min_length" extensions:"x-order=0"`
+	Message string `json:"message" example:"Password must be at least 8 characters" extensions:"x-order=1"`
+}
@@ -3,25 +3,37 @@ package response
 import "time"
 
 type ReviewResponse struct {
-	ID        uint      `json:"id" example:"1" extensions:"x-order=0"`
-	CarID     uint      `json:"car_id" example:"2" extensions:"x-order=1"`
-	UserID    uint      `json:"user_id" example:"3" extensions:"x-order=2"`
-	Title     string    `json:"title" example:"Title" extensions:"x-order=3"`
-	Content   string    `json:"content" example:"Lorem ipsum dolor sit amet" extensions:"x-order=4"`
-	ImageUrl  string    `json:"image_url" example:"image url" extensions:"x-order=5"`
-	CreatedAt time.Time `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=6"`
-	UpdatedAt time.Time `json:"updated_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=7"`
+	ID        uint              `json:"id" example:"1" extensions:"x-order=0"`
+	CarID     uint              `json:"car_id" example:"2" extensions:"x-order=1"`
+	UserID    uint              `json:"user_id" example:"3" extensions:"x-order=2"`
+	Title     string            `json:"title" example:"Title" extensions:"x-order=3"`
+	Content   string            `json:"content" example:"Lorem ipsum dolor sit amet" extensions:"x-order=4"`
+	ImageUrl  string            `json:"image_url" example:"image url" extensions:"x-order=5"`
+	MediaID   *uint             `json:"media_id" example:"1" extensions:"x-order=5"`
+	Status    string            `json:"status" example:"published" extensions:"x-order=5"`
+	Mentions  []MentionResponse `json:"mentions" extensions:"x-order=5"`
+	CreatedAt time.Time         `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=6"`
+	UpdatedAt time.Time         `json:"updated_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=7"`
 }
 
 type FindReviewResponse struct {
-	ID        uint               `json:"id" example:"1" extensions:"x-order=0"`
-	Title     string             `json:"title" example:"Title" extensions:"x-order=1"`
-	Content   string             `json:"content" example:"Lorem ipsum dolor sit amet" extensions:"x-order=2"`
-	ImageUrl  string             `json:"image_url" example:"image url" extensions:"x-order=3"`
-	Car       ReviewCarResponse  `json:"car" extensions:"x-order=5"`
-	User      ReviewUserResponse `json:"user" extensions:"x-order=5"`
-	CreatedAt time.Time          `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=6"`
-	UpdatedAt time.Time          `json:"updated_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=7"`
+	ID             uint                   `json:"id" example:"1" extensions:"x-order=0"`
+	Title          string                 `json:"title" example:"Title" extensions:"x-order=1"`
+	Content        string                 `json:"content" example:"Lorem ipsum dolor sit amet" extensions:"x-order=2"`
+	ImageUrl       string                 `json:"image_url" example:"image url" extensions:"x-order=3"`
+	MediaID        *uint                  `json:"media_id" example:"1" extensions:"x-order=4"`
+	Status         string                 `json:"status" example:"published" extensions:"x-order=4"`
+	Car            ReviewCarResponse      `json:"car" extensions:"x-order=5"`
+	User           ReviewUserResponse     `json:"user" extensions:"x-order=5"`
+	Gallery        []GalleryImageResponse `json:"gallery" extensions:"x-order=5"`
+	Mentions       []MentionResponse      `json:"mentions" extensions:"x-order=5"`
+	HelpfulCount   int                    `json:"helpful_count" example:"95" extensions:"x-order=6"`
+	UnhelpfulCount int                    `json:"unhelpful_count" example:"5" extensions:"x-order=6"`
+	HelpfulScore   float64                `json:"helpful_score" example:"0.887" extensions:"x-order=6"`
+	Edited         bool                   `json:"edited" example:"true" extensions:"x-order=6"`
+	EditedAt       *time.Time             `json:"edited_at" example:"2022-01-02T00:00:00Z" extensions:"x-order=6"`
+	CreatedAt      time.Time              `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=6"`
+	UpdatedAt      time.Time              `json:"updated_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=7"`
 }
 
 type ReviewUserResponse struct {
@@ -32,3 +44,16 @@ type ReviewUserResponse struct {
 type ReviewCarResponse struct {
 	ID uint `json:"id" example:"1" extensions:"x-order=0"`
 }
+
+type ReviewExportResponse struct {
+	ID        uint      `json:"id" example:"1" extensions:"x-order=0"`
+	CarID     uint      `json:"car_id" example:"2" extensions:"x-order=1"`
+	UserID    uint      `json:"user_id" example:"3" extensions:"x-order=2"`
+	Username  string    `json:"username" example:"luigi" extensions:"x-order=3"`
+	Title     string    `json:"title" example:"Title" extensions:"x-order=4"`
+	Content   string    `json:"content" example:"Lorem ipsum dolor sit amet" extensions:"x-order=5"`
+	ImageUrl  string    `json:"image_url" example:"image url" extensions:"x-order=6"`
+	MediaID   *uint     `json:"media_id" example:"1" extensions:"x-order=6"`
+	CreatedAt time.Time `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=7"`
+	UpdatedAt time.Time `json:"updated_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=8"`
+}
@@ -0,0 +1,9 @@
+package response
+
+type ReviewVoteResponse struct {
+	ReviewID       uint    `json:"review_id" example:"1" extensions:"x-order=0"`
+	Helpful        *bool   `json:"helpful" example:"true" extensions:"x-order=1"`
+	HelpfulCount   int     `json:"helpful_count" example:"95" extensions:"x-order=2"`
+	UnhelpfulCount int     `json:"unhelpful_count" example:"5" extensions:"x-order=3"`
+	HelpfulScore   float64 `json:"helpful_score" example:"0.887" extensions:"x-order=4"`
+}
@@ -0,0 +1,24 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type RevisionResponse struct {
+	Number         int             `json:"number" example:"1" extensions:"x-order=0"`
+	EditorID       *uint           `json:"editor_id" example:"3" extensions:"x-order=1"`
+	EditorUsername *string         `json:"editor_username" example:"luigi" extensions:"x-order=2"`
+	Before         json.RawMessage `json:"before" swaggertype:"object" extensions:"x-order=3"`
+	After          json.RawMessage `json:"after" swaggertype:"object" extensions:"x-order=4"`
+	Diff           json.RawMessage `json:"diff" swaggertype:"object" extensions:"x-order=5"`
+	CreatedAt      time.Time       `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=6"`
+}
+
+// RevisionDiffResponse compares the state after revision From with the state
+// after revision To, revision 0 being the state before the first edit.
+type RevisionDiffResponse struct {
+	From int                       `json:"from" example:"0" extensions:"x-order=0"`
+	To   int                       `json:"to" example:"2" extensions:"x-order=1"`
+	Diff map[string]map[string]any `json:"diff" swaggertype:"object" extensions:"x-order=2"`
+}
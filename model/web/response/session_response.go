@@ -0,0 +1,13 @@
+package response
+
+import "time"
+
+type SessionResponse struct {
+	ID         uint      `json:"id" example:"1" extensions:"x-order=0"`
+	UserAgent  string    `json:"user_agent" example:"Mozilla/5.0" extensions:"x-order=1"`
+	IP         string    `json:"ip" example:"127.0.0.1" extensions:"x-order=2"`
+	Current    bool      `json:"current" example:"true" extensions:"x-order=3"`
+	CreatedAt  time.Time `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=4"`
+	LastSeenAt time.Time `json:"last_seen_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=5"`
+	ExpiresAt  time.Time `json:"expires_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=6"`
+}
@@ -0,0 +1,17 @@
+package response
+
+import "time"
+
+type TrashItemResponse struct {
+	ID        uint      `json:"id" example:"1" extensions:"x-order=0"`
+	Label     string    `json:"label" example:"Yaris" extensions:"x-order=1"`
+	DeletedAt time.Time `json:"deleted_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=2"`
+	PurgeAt   time.Time `json:"purge_at" example:"2022-01-31T00:00:00Z" extensions:"x-order=3"`
+}
+
+type TrashPurgeResponse struct {
+	Comments int64 `json:"comments" example:"3" extensions:"x-order=0"`
+	Reviews  int64 `json:"reviews" example:"2" extensions:"x-order=1"`
+	Cars     int64 `json:"cars" example:"1" extensions:"x-order=2"`
+	Brands   int64 `json:"brands" example:"0" extensions:"x-order=3"`
+}
@@ -0,0 +1,17 @@
+package response
+
+import "time"
+
+// WatchResponse is a watched car or brand. Name is the brand name or the car
+// name and model.
+type WatchResponse struct {
+	Type      string    `json:"type" example:"cars" extensions:"x-order=0"`
+	ID        uint      `json:"id" example:"1" extensions:"x-order=1"`
+	Name      string    `json:"name" example:"Corolla Cross Hybrid" extensions:"x-order=2"`
+	WatchedAt time.Time `json:"watched_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=3"`
+}
+
+type WatchDigestResponse struct {
+	Frequency  string     `json:"frequency" example:"weekly" extensions:"x-order=0"`
+	LastSentAt *time.Time `json:"last_sent_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=1"`
+}
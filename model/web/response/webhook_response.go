@@ -0,0 +1,33 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookResponse struct {
+	ID          uint      `json:"id" example:"1" extensions:"x-order=0"`
+	URL         string    `json:"url" example:"https://partner.example.com/hooks/carreview" extensions:"x-order=1"`
+	Events      []string  `json:"events" example:"car.created,review.published" extensions:"x-order=2"`
+	Description string    `json:"description" example:"Partner catalogue sync" extensions:"x-order=3"`
+	Active      bool      `json:"active" example:"true" extensions:"x-order=4"`
+	Secret      string    `json:"secret,omitempty" example:"0123456789abcdef0123456789abcdef" extensions:"x-order=5"`
+	CreatedAt   time.Time `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=6"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=7"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uint            `json:"id" example:"1" extensions:"x-order=0"`
+	WebhookID      uint            `json:"webhook_id" example:"1" extensions:"x-order=1"`
+	Event          string          `json:"event" example:"car.created" extensions:"x-order=2"`
+	Status         string          `json:"status" example:"failed" extensions:"x-order=3"`
+	Attempts       int             `json:"attempts" example:"8" extensions:"x-order=4"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at" example:"2022-01-01T00:01:00Z" extensions:"x-order=5"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at" example:"2022-01-01T00:00:30Z" extensions:"x-order=6"`
+	ResponseStatus *int            `json:"response_status" example:"503" extensions:"x-order=7"`
+	ResponseBody   string          `json:"response_body" example:"Service Unavailable" extensions:"x-order=8"`
+	Error          string          `json:"error" example:"" extensions:"x-order=9"`
+	RedeliveryOf   *uint           `json:"redelivery_of" example:"1" extensions:"x-order=10"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object" extensions:"x-order=11"`
+	CreatedAt      time.Time       `json:"created_at" example:"2022-01-01T00:00:00Z" extensions:"x-order=12"`
+}
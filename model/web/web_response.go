@@ -8,10 +8,11 @@ type WebSuccess[T any] struct {
 }
 
 type Metadata struct {
-	Page       *int   `json:"page" form:"limit" extensions:"x-order=0"`
-	Limit      *int   `json:"limit" form:"page" extensions:"x-order=1"`
-	TotalPages *int   `json:"total_pages" extensions:"x-order=2"`
-	TotalData  *int64 `json:"total_data" extensions:"x-order=3"`
+	Page       *int    `json:"page" form:"limit" extensions:"x-order=0"`
+	Limit      *int    `json:"limit" form:"page" extensions:"x-order=1"`
+	TotalPages *int    `json:"total_pages" extensions:"x-order=2"`
+	TotalData  *int64  `json:"total_data" extensions:"x-order=3"`
+	NextCursor *string `json:"next_cursor,omitempty" extensions:"x-order=4"`
 }
 
 type WebError struct {
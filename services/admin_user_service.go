@@ -0,0 +1,302 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type AdminUserService interface {
+	FindAll(*gin.Context, *request.AdminUserQueryRequest, *web.PaginationRequest) (*[]response.AdminUserResponse, *web.Metadata, error)
+	FindByID(*gin.Context, uint) (*response.AdminUserResponse, error)
+	UpdateRole(*gin.Context, uint, *request.AdminUpdateRoleRequest) (*response.AdminUserResponse, error)
+	Ban(*gin.Context, uint, *request.AdminBanUserRequest) (*response.AdminUserResponse, error)
+	Unban(*gin.Context, uint) (*response.AdminUserResponse, error)
+	ForcePasswordReset(*gin.Context, uint) (*response.AdminUserResponse, error)
+	Impersonate(*gin.Context, uint) (*response.LoginResponse, error)
+}
+
+type adminUserServiceImpl struct{}
+
+func NewAdminUserService() AdminUserService {
+	return &adminUserServiceImpl{}
+}
+
+func (service *adminUserServiceImpl) FindAll(c *gin.Context, userQueryReq *request.AdminUserQueryRequest, paging *web.PaginationRequest) (*[]response.AdminUserResponse, *web.Metadata, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	var users []entity.User
+
+	query := db.Model(&entity.User{})
+
+	if userQueryReq.Search != nil {
+		search := "%" + *userQueryReq.Search + "%"
+		query = query.Where("username ILIKE ? OR email ILIKE ?", search, search)
+	}
+
+	if userQueryReq.Role != nil {
+		query = query.Where("role = ?", *userQueryReq.Role)
+	}
+
+	if userQueryReq.Verified != nil {
+		if *userQueryReq.Verified {
+			query = query.Where("email_verified_at IS NOT NULL")
+		} else {
+			query = query.Where("email_verified_at IS NULL")
+		}
+	}
+
+	if userQueryReq.Banned != nil {
+		bannedCondition := "banned_at IS NOT NULL AND (ban_expires_at IS NULL OR ban_expires_at > ?)"
+		if *userQueryReq.Banned {
+			query = query.Where(bannedCondition, time.Now())
+		} else {
+			query = query.Not(bannedCondition, time.Now())
+		}
+	}
+
+	if userQueryReq.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *userQueryReq.CreatedFrom)
+	}
+
+	if userQueryReq.CreatedTo != nil {
+		query = query.Where("created_at <= ?", *userQueryReq.CreatedTo)
+	}
+
+	query.Count(&paging.TotalData)
+
+	offset := (paging.Page - 1) * paging.Limit
+
+	if err := query.Order("id").Limit(paging.Limit).Offset(offset).Find(&users).Error; err != nil {
+		return nil, nil, err
+	}
+
+	paging.TotalPages = int((paging.TotalData + int64(paging.Limit) - 1) / int64(paging.Limit))
+
+	responseUsers := []response.AdminUserResponse{}
+	for _, user := range users {
+		responseUsers = append(responseUsers, *toAdminUserResponse(&user))
+	}
+
+	metadata := web.Metadata{
+		Page:       &paging.Page,
+		Limit:      &paging.Limit,
+		TotalPages: &paging.TotalPages,
+		TotalData:  &paging.TotalData,
+	}
+
+	return &responseUsers, &metadata, nil
+}
+
+func (service *adminUserServiceImpl) FindByID(c *gin.Context, userID uint) (*response.AdminUserResponse, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	user, err := service.findUser(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAdminUserResponse(user), nil
+}
+
+func (service *adminUserServiceImpl) UpdateRole(c *gin.Context, userID uint, updateRoleReq *request.AdminUpdateRoleRequest) (*response.AdminUserResponse, error) {
+	// Without a token the change comes from the command line, not an admin.
+	if adminID, _, err := utils.ExtractTokenClaims(c); err == nil && adminID == userID && updateRoleReq.Role != entity.RoleAdmin {
+		return nil, exceptions.NewCustomError(http.StatusBadRequest, "You cannot demote yourself")
+	}
+
+	return service.update(c, userID, AuditUserRoleUpdate, func(tx *gorm.DB, user *entity.User) error {
+		if user.Role == updateRoleReq.Role {
+			return nil
+		}
+
+		if err := tx.Model(user).Update("role", updateRoleReq.Role).Error; err != nil {
+			return err
+		}
+
+		// Tokens carry the role, so the user has to log in again to pick it up.
+		return revokeUserSessions(tx, user.ID, "")
+	})
+}
+
+func (service *adminUserServiceImpl) Ban(c *gin.Context, userID uint, banReq *request.AdminBanUserRequest) (*response.AdminUserResponse, error) {
+	adminID, _, err := utils.ExtractTokenClaims(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if adminID == userID {
+		return nil, exceptions.NewCustomError(http.StatusBadRequest, "You cannot ban yourself")
+	}
+
+	if banReq.ExpiresAt != nil && !banReq.ExpiresAt.After(time.Now()) {
+		return nil, exceptions.NewCustomError(http.StatusBadRequest, "Ban expiry must be in the future")
+	}
+
+	return service.update(c, userID, AuditUserBan, func(tx *gorm.DB, user *entity.User) error {
+		if err := tx.Model(user).Updates(map[string]any{
+			"banned_at":      time.Now(),
+			"ban_reason":     banReq.Reason,
+			"ban_expires_at": banReq.ExpiresAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		return revokeUserSessions(tx, user.ID, "")
+	})
+}
+
+func (service *adminUserServiceImpl) Unban(c *gin.Context, userID uint) (*response.AdminUserResponse, error) {
+	return service.update(c, userID, AuditUserUnban, func(tx *gorm.DB, user *entity.User) error {
+		return tx.Model(user).Updates(map[string]any{
+			"banned_at":      nil,
+			"ban_reason":     nil,
+			"ban_expires_at": nil,
+		}).Error
+	})
+}
+
+// ForcePasswordReset blocks logins until the user sets a new password
+// through the forgot password flow and logs the user out everywhere.
+func (service *adminUserServiceImpl) ForcePasswordReset(c *gin.Context, userID uint) (*response.AdminUserResponse, error) {
+	return service.update(c, userID, AuditUserForcePasswordReset, func(tx *gorm.DB, user *entity.User) error {
+		if err := tx.Model(user).Update("password_reset_required", true).Error; err != nil {
+			return err
+		}
+
+		return revokeUserSessions(tx, user.ID, "")
+	})
+}
+
+// Impersonate opens a session as the user for support purposes. The session
+// remembers the admin, so every audited action taken with it is attributed
+// to both of them.
+func (service *adminUserServiceImpl) Impersonate(c *gin.Context, userID uint) (*response.LoginResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	adminID, _, err := utils.ExtractTokenClaims(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if adminID == userID {
+		return nil, exceptions.NewCustomError(http.StatusBadRequest, "You cannot impersonate yourself")
+	}
+
+	var loginResponse *response.LoginResponse
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		user, err := service.findUser(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		if user.Role == entity.RoleAdmin {
+			return exceptions.NewCustomError(http.StatusForbidden, "Admins cannot be impersonated")
+		}
+
+		token, err := issueSessionToken(c, tx, user, &adminID)
+		if err != nil {
+			return err
+		}
+
+		loginResponse = &response.LoginResponse{
+			Username: user.Username,
+			Email:    user.Email,
+			Role:     user.Role,
+			Token:    token,
+		}
+
+		return recordAudit(c, tx, &entity.AuditEvent{
+			Action:     AuditUserImpersonate,
+			TargetType: AuditTargetUser,
+			TargetID:   user.ID,
+		}, nil, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("user impersonated", zap.Uint("adminID", adminID), zap.Uint("userID", userID))
+
+	return loginResponse, nil
+}
+
+// update loads the user, applies the change and records it in the audit log,
+// all inside one transaction.
+func (service *adminUserServiceImpl) update(c *gin.Context, userID uint, action string, apply func(*gorm.DB, *entity.User) error) (*response.AdminUserResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	var after *response.AdminUserResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		user, err := service.findUser(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		before := toAdminUserResponse(user)
+
+		if err := apply(tx, user); err != nil {
+			return err
+		}
+
+		if user, err = service.findUser(tx, userID); err != nil {
+			return err
+		}
+
+		after = toAdminUserResponse(user)
+
+		return recordAudit(c, tx, &entity.AuditEvent{
+			Action:     action,
+			TargetType: AuditTargetUser,
+			TargetID:   user.ID,
+		}, before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("user updated by admin", zap.String("action", action), zap.Uint("userID", userID))
+
+	return after, nil
+}
+
+func (service *adminUserServiceImpl) findUser(db *gorm.DB, userID uint) (*entity.User, error) {
+	var user entity.User
+
+	if err := db.Take(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exceptions.NewCustomError(http.StatusNotFound, "User not found")
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func toAdminUserResponse(user *entity.User) *response.AdminUserResponse {
+	return &response.AdminUserResponse{
+		ID:                    user.ID,
+		Username:              user.Username,
+		Email:                 user.Email,
+		Role:                  user.Role,
+		EmailVerified:         user.EmailVerifiedAt != nil,
+		Banned:                user.IsBanned(time.Now()),
+		BanReason:             user.BanReason,
+		BanExpiresAt:          user.BanExpiresAt,
+		PasswordResetRequired: user.PasswordResetRequired,
+		CreatedAt:             user.CreatedAt,
+	}
+}
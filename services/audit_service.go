@@ -0,0 +1,292 @@
+package services
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	AuditCarCreate              = "car.create"
+	AuditCarUpdate              = "car.update"
+	AuditCarDelete              = "car.delete"
+	AuditCarGalleryUpdate       = "car.gallery_update"
+	AuditCarRestore             = "car.restore"
+	AuditBrandCreate            = "brand.create"
+	AuditBrandUpdate            = "brand.update"
+	AuditBrandDelete            = "brand.delete"
+	AuditBrandRestore           = "brand.restore"
+	AuditReviewModerate         = "review.moderate"
+	AuditReviewRestore          = "review.restore"
+	AuditCommentModerate        = "comment.moderate"
+	AuditCommentRestore         = "comment.restore"
+	AuditPasswordUpdate         = "user.password_update"
+	AuditPasswordReset          = "user.password_reset"
+	AuditUserDelete             = "user.delete"
+	AuditUserRoleUpdate         = "user.role_update"
+	AuditUserBan                = "user.ban"
+	AuditUserUnban              = "user.unban"
+	AuditUserForcePasswordReset = "user.force_password_reset"
+	AuditUserImpersonate        = "user.impersonate"
+	AuditWebhookCreate          = "webhook.create"
+	AuditWebhookUpdate          = "webhook.update"
+	AuditWebhookDelete          = "webhook.delete"
+	AuditWebhookRedeliver       = "webhook.redeliver"
+	AuditTargetCar              = "car"
+	AuditTargetBrand            = "brand"
+	AuditTargetReview           = "review"
+	AuditTargetComment          = "comment"
+	AuditTargetUser             = "user"
+	AuditTargetWebhook          = "webhook"
+)
+
+type AuditService interface {
+	FindAll(*gin.Context, *request.AuditQueryRequest, *web.PaginationRequest) (*[]response.AuditEventResponse, *web.Metadata, error)
+	Export(*gin.Context, *request.AuditQueryRequest, io.Writer) error
+}
+
+type auditServiceImpl struct{}
+
+func NewAuditService() AuditService {
+	return &auditServiceImpl{}
+}
+
+func (service *auditServiceImpl) FindAll(c *gin.Context, auditQueryReq *request.AuditQueryRequest, paging *web.PaginationRequest) (*[]response.AuditEventResponse, *web.Metadata, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	var events []entity.AuditEvent
+
+	query := service.filter(db.Model(&entity.AuditEvent{}), auditQueryReq)
+
+	query.Count(&paging.TotalData)
+
+	offset := (paging.Page - 1) * paging.Limit
+
+	if err := query.Order("id desc").Limit(paging.Limit).Offset(offset).Find(&events).Error; err != nil {
+		return nil, nil, err
+	}
+
+	paging.TotalPages = int((paging.TotalData + int64(paging.Limit) - 1) / int64(paging.Limit))
+
+	responseEvents := []response.AuditEventResponse{}
+	for _, event := range events {
+		responseEvents = append(responseEvents, *service.toAuditEventResponse(&event))
+	}
+
+	metadata := web.Metadata{
+		Page:       &paging.Page,
+		Limit:      &paging.Limit,
+		TotalPages: &paging.TotalPages,
+		TotalData:  &paging.TotalData,
+	}
+
+	return &responseEvents, &metadata, nil
+}
+
+// Export writes every matching event as newline delimited JSON, reading the
+// rows one at a time so the export does not depend on the table size.
+func (service *auditServiceImpl) Export(c *gin.Context, auditQueryReq *request.AuditQueryRequest, w io.Writer) error {
+	db, _ := helper.GetDBAndLogger(c)
+
+	rows, err := service.filter(db.Model(&entity.AuditEvent{}), auditQueryReq).Order("id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	encoder := json.NewEncoder(w)
+
+	for rows.Next() {
+		var event entity.AuditEvent
+		if err := db.ScanRows(rows, &event); err != nil {
+			return err
+		}
+
+		if err := encoder.Encode(service.toAuditEventResponse(&event)); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (service *auditServiceImpl) filter(query *gorm.DB, auditQueryReq *request.AuditQueryRequest) *gorm.DB {
+	if auditQueryReq.Actor != nil {
+		query = query.Where("actor_id = ?", *auditQueryReq.Actor)
+	}
+
+	if auditQueryReq.Action != nil {
+		query = query.Where("action = ?", *auditQueryReq.Action)
+	}
+
+	if auditQueryReq.TargetType != nil {
+		query = query.Where("target_type = ?", *auditQueryReq.TargetType)
+	}
+
+	if auditQueryReq.TargetID != nil {
+		query = query.Where("target_id = ?", *auditQueryReq.TargetID)
+	}
+
+	if auditQueryReq.From != nil {
+		query = query.Where("created_at >= ?", *auditQueryReq.From)
+	}
+
+	if auditQueryReq.To != nil {
+		query = query.Where("created_at <= ?", *auditQueryReq.To)
+	}
+
+	return query
+}
+
+func (service *auditServiceImpl) toAuditEventResponse(event *entity.AuditEvent) *response.AuditEventResponse {
+	rawJSON := func(value *string) json.RawMessage {
+		if value == nil {
+			return nil
+		}
+		return json.RawMessage(*value)
+	}
+
+	return &response.AuditEventResponse{
+		ID:             event.ID,
+		ActorID:        event.ActorID,
+		ImpersonatorID: event.ImpersonatorID,
+		Action:         event.Action,
+		TargetType:     event.TargetType,
+		TargetID:       event.TargetID,
+		Before:         rawJSON(event.Before),
+		After:          rawJSON(event.After),
+		Diff:           rawJSON(event.Diff),
+		IP:             event.IP,
+		UserAgent:      event.UserAgent,
+		RequestID:      event.RequestID,
+		CreatedAt:      event.CreatedAt,
+	}
+}
+
+// recordAudit appends an audit event inside the caller's transaction, so the
+// event only exists when the audited change is committed. before and after
+// are snapshots of the target (nil when not applicable) and the diff is their
+// snapshotDiff. When the event has no actor the one from the request token is
+// used.
+func recordAudit(c *gin.Context, tx *gorm.DB, event *entity.AuditEvent, before, after any) error {
+	if event.ActorID == nil {
+		if actorID, _, err := utils.ExtractTokenClaims(c); err == nil {
+			event.ActorID = &actorID
+		}
+	}
+
+	if event.ImpersonatorID == nil {
+		if tokenID := utils.ExtractTokenID(c); tokenID != "" {
+			var session entity.Session
+			if err := tx.Select("impersonator_id").Take(&session, "token_id = ?", tokenID).Error; err == nil {
+				event.ImpersonatorID = session.ImpersonatorID
+			}
+		}
+	}
+
+	beforeMap, err := auditSnapshot(before)
+	if err != nil {
+		return err
+	}
+
+	afterMap, err := auditSnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	if event.Before, err = auditJSON(beforeMap); err != nil {
+		return err
+	}
+
+	if event.After, err = auditJSON(afterMap); err != nil {
+		return err
+	}
+
+	if beforeMap != nil || afterMap != nil {
+		if event.Diff, err = auditJSON(snapshotDiff(beforeMap, afterMap)); err != nil {
+			return err
+		}
+	}
+
+	userAgent := c.Request.UserAgent()
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	event.IP = c.ClientIP()
+	event.UserAgent = userAgent
+	event.RequestID = c.GetString("request_id")
+
+	return tx.Create(event).Error
+}
+
+func auditSnapshot(value any) (map[string]any, error) {
+	if isNilValue(value) {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot map[string]any
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// snapshotDiff lists the top-level fields that differ between two snapshots
+// as {"field": {"from": ..., "to": ...}}.
+func snapshotDiff(before, after map[string]any) map[string]map[string]any {
+	diff := map[string]map[string]any{}
+	for field, value := range after {
+		if !reflect.DeepEqual(before[field], value) {
+			diff[field] = map[string]any{"from": before[field], "to": value}
+		}
+	}
+	for field, value := range before {
+		if _, ok := after[field]; !ok {
+			diff[field] = map[string]any{"from": value, "to": nil}
+		}
+	}
+	return diff
+}
+
+func auditJSON[T any](value T) (*string, error) {
+	if isNilValue(value) {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	result := string(raw)
+	return &result, nil
+}
+
+func isNilValue(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
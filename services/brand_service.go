@@ -21,10 +21,13 @@ type BrandService interface {
 	FindAll(*gin.Context) (*[]response.BrandResponse, error)
 }
 
-type brandServiceImpl struct{}
+type brandServiceImpl struct {
+	events EventBus
+}
 
-func NewBrandService() BrandService {
-	return &brandServiceImpl{}
+// NewBrandService emits the created, updated and deleted brands as events.
+func NewBrandService(events EventBus) BrandService {
+	return &brandServiceImpl{events: events}
 }
 
 func (service *brandServiceImpl) Create(c *gin.Context, brandCreateRequest *request.BrandRequest) (*response.BrandResponse, error) {
@@ -32,13 +35,25 @@ func (service *brandServiceImpl) Create(c *gin.Context, brandCreateRequest *requ
 
 	newBrand := service.toBrandEntity(brandCreateRequest)
 
-	if err := db.Create(newBrand).Error; err != nil {
-		if pgErr, ok := err.(*pgconn.PgError); ok {
-			// Handle duplicate key error
-			if pgErr.Code == "23505" {
-				return nil, exceptions.NewCustomError(http.StatusConflict, "Name already exist")
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(newBrand).Error; err != nil {
+			if pgErr, ok := err.(*pgconn.PgError); ok {
+				// Handle duplicate key error
+				if pgErr.Code == "23505" {
+					return exceptions.NewCustomError(http.StatusConflict, "Name already exist")
+				}
 			}
+			return err
+		}
+
+		if err := recordAudit(c, tx, &entity.AuditEvent{Action: AuditBrandCreate, TargetType: AuditTargetBrand, TargetID: newBrand.ID}, nil, service.toBrandResponse(newBrand)); err != nil {
+			return err
 		}
+
+		return service.events.Publish(tx, &BrandChangedEvent{Name: EventBrandCreated, Brand: service.toBrandResponse(newBrand)})
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
@@ -55,7 +70,15 @@ func (service *brandServiceImpl) Update(c *gin.Context, brandRequest *request.Br
 	var brand entity.Brand
 
 	err := db.Transaction(func(tx *gorm.DB) error {
-		result := db.Model(&entity.Brand{}).Where("id = ?", brandID).Updates(updateBrand)
+		var before entity.Brand
+		if err := tx.Take(&before, "id = ?", brandID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return exceptions.NewCustomError(http.StatusNotFound, "Brand not found")
+			}
+			return err
+		}
+
+		result := tx.Model(&entity.Brand{}).Where("id = ?", brandID).Updates(updateBrand)
 
 		if result.Error != nil {
 			if pgErr, ok := result.Error.(*pgconn.PgError); ok {
@@ -75,7 +98,11 @@ func (service *brandServiceImpl) Update(c *gin.Context, brandRequest *request.Br
 			return err
 		}
 
-		return nil
+		if err := recordAudit(c, tx, &entity.AuditEvent{Action: AuditBrandUpdate, TargetType: AuditTargetBrand, TargetID: brandID}, service.toBrandResponse(&before), service.toBrandResponse(&brand)); err != nil {
+			return err
+		}
+
+		return service.events.Publish(tx, &BrandChangedEvent{Name: EventBrandUpdated, Brand: service.toBrandResponse(&brand)})
 	})
 
 	if err != nil {
@@ -90,14 +117,37 @@ func (service *brandServiceImpl) Update(c *gin.Context, brandRequest *request.Br
 func (service *brandServiceImpl) Delete(c *gin.Context, brandID uint) error {
 	db, logger := helper.GetDBAndLogger(c)
 
-	result := db.Where("id = ?", brandID).Delete(&entity.Brand{})
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var before entity.Brand
+		if err := tx.Take(&before, "id = ?", brandID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return exceptions.NewCustomError(http.StatusNotFound, "Brand not found")
+			}
+			return err
+		}
+
+		var cars int64
+		if err := tx.Model(&entity.Car{}).Where("brand_id = ?", brandID).Count(&cars).Error; err != nil {
+			return err
+		}
 
-	if result.Error != nil {
-		return result.Error
-	}
+		if cars > 0 {
+			return exceptions.NewCustomError(http.StatusConflict, "Brand still has cars")
+		}
 
-	if result.RowsAffected == 0 {
-		return exceptions.NewCustomError(http.StatusNotFound, "Brand not found")
+		if err := tx.Where("id = ?", brandID).Delete(&entity.Brand{}).Error; err != nil {
+			return err
+		}
+
+		if err := recordAudit(c, tx, &entity.AuditEvent{Action: AuditBrandDelete, TargetType: AuditTargetBrand, TargetID: brandID}, service.toBrandResponse(&before), nil); err != nil {
+			return err
+		}
+
+		return service.events.Publish(tx, &BrandChangedEvent{Name: EventBrandDeleted, Brand: service.toBrandResponse(&before)})
+	})
+
+	if err != nil {
+		return err
 	}
 
 	logger.Info("brand deleted successfully", zap.Uint("brandID", brandID))
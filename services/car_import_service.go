@@ -0,0 +1,543 @@
+package services
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	ImportActionCreate = "create"
+	ImportActionUpdate = "update"
+	ImportActionError  = "error"
+)
+
+type CarImportService interface {
+	Import(*gin.Context, *request.CarImportRequest, io.Reader) (*response.ImportJobResponse, error)
+	FindJob(*gin.Context, uint) (*response.ImportJobResponse, error)
+}
+
+type carImportServiceImpl struct {
+	carService   CarService
+	brandService BrandService
+	asyncRows    int
+}
+
+// NewCarImportService returns the service importing cars in bulk. Imports
+// with more than CAR_IMPORT_ASYNC_ROWS rows are processed in the background.
+func NewCarImportService(carService CarService, brandService BrandService) CarImportService {
+	return &carImportServiceImpl{
+		carService:   carService,
+		brandService: brandService,
+		asyncRows:    helper.GetEnvInt("CAR_IMPORT_ASYNC_ROWS", 200),
+	}
+}
+
+// carImportRow is one parsed row of an import. Brand is the brand name and
+// takes precedence over the brand_id column when both are given.
+type carImportRow struct {
+	request.CarCreateRequest
+	Brand string `json:"brand"`
+
+	line   int
+	errors map[string]string
+}
+
+// Import parses the whole file first, so a malformed file is rejected before
+// anything is written. Rows are then validated and, unless it is a dry run,
+// upserted on (brand, name, model, year), the idx_car_identity key, through
+// the car service.
+func (service *carImportServiceImpl) Import(c *gin.Context, importReq *request.CarImportRequest, file io.Reader) (*response.ImportJobResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	var rows []*carImportRow
+	var err error
+
+	switch importReq.Format {
+	case "ndjson":
+		rows, err = parseCarNDJSON(file)
+	default:
+		importReq.Format = "csv"
+		rows, err = parseCarCSV(file)
+	}
+	if err != nil {
+		return nil, exceptions.NewCustomError(http.StatusBadRequest, err.Error())
+	}
+
+	if len(rows) == 0 {
+		return nil, exceptions.NewCustomError(http.StatusBadRequest, "File has no rows")
+	}
+
+	if importReq.DryRun {
+		brands, err := service.loadBrands(db)
+		if err != nil {
+			return nil, err
+		}
+
+		job := &entity.ImportJob{Kind: "cars", Status: entity.ImportJobCompleted, Format: importReq.Format, TotalRows: len(rows), CreatedAt: time.Now()}
+		report := make([]response.ImportRowReport, 0, len(rows))
+
+		for _, row := range rows {
+			rowReport := service.validateRow(db, row, brands, importReq.CreateBrands)
+			service.count(job, &rowReport)
+			report = append(report, rowReport)
+		}
+
+		job.FinishedAt = &job.CreatedAt
+
+		jobResponse := toImportJobResponse(job, report)
+		jobResponse.DryRun = true
+
+		return jobResponse, nil
+	}
+
+	job := &entity.ImportJob{Kind: "cars", Status: entity.ImportJobPending, Format: importReq.Format, TotalRows: len(rows)}
+
+	if userID, _, err := utils.ExtractTokenClaims(c); err == nil {
+		job.CreatedByID = &userID
+	}
+
+	if err := db.Create(job).Error; err != nil {
+		return nil, err
+	}
+
+	logger.Info("car import started", zap.Uint("jobID", job.ID), zap.Int("rows", len(rows)))
+
+	if len(rows) > service.asyncRows {
+		// the request is cancelled and its context recycled once the handler
+		// returns, the job keeps the db, the logger and the token only
+		go service.run(helper.DetachContext(c), job, rows, importReq.CreateBrands)
+
+		return toImportJobResponse(job, nil), nil
+	}
+
+	service.run(c, job, rows, importReq.CreateBrands)
+
+	return service.FindJob(c, job.ID)
+}
+
+func (service *carImportServiceImpl) FindJob(c *gin.Context, jobID uint) (*response.ImportJobResponse, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	var job entity.ImportJob
+
+	if err := db.Take(&job, "id = ? AND kind = ?", jobID, "cars").Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exceptions.NewCustomError(http.StatusNotFound, "Import job not found")
+		}
+		return nil, err
+	}
+
+	var report []response.ImportRowReport
+	if job.Report != nil {
+		if err := json.Unmarshal([]byte(*job.Report), &report); err != nil {
+			return nil, err
+		}
+	}
+
+	return toImportJobResponse(&job, report), nil
+}
+
+// run processes the rows one by one, each in its own transaction, so a bad
+// row does not roll back the others. Progress is saved every 100 rows for
+// the status endpoint.
+func (service *carImportServiceImpl) run(c *gin.Context, job *entity.ImportJob, rows []*carImportRow, createBrands bool) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	report := make([]response.ImportRowReport, 0, len(rows))
+
+	finish := func(status string, jobErr error) {
+		now := time.Now()
+		job.Status = status
+		job.FinishedAt = &now
+
+		if jobErr != nil {
+			message := jobErr.Error()
+			job.Error = &message
+		}
+
+		var err error
+		if job.Report, err = auditJSON(report); err != nil {
+			logger.Error("failed to encode import report", zap.Uint("jobID", job.ID), zap.Error(err))
+		}
+
+		if err := db.Save(job).Error; err != nil {
+			logger.Error("failed to save import job", zap.Uint("jobID", job.ID), zap.Error(err))
+		}
+
+		logger.Info("car import finished", zap.Uint("jobID", job.ID), zap.String("status", status),
+			zap.Int("created", job.Created), zap.Int("updated", job.Updated), zap.Int("failed", job.Failed))
+	}
+
+	defer func() {
+		if err := recover(); err != nil {
+			finish(entity.ImportJobFailed, fmt.Errorf("%v", err))
+		}
+	}()
+
+	job.Status = entity.ImportJobRunning
+	db.Model(job).Update("status", job.Status)
+
+	brands, err := service.loadBrands(db)
+	if err != nil {
+		finish(entity.ImportJobFailed, err)
+		return
+	}
+
+	for i, row := range rows {
+		rowReport := service.validateRow(db, row, brands, createBrands)
+
+		if rowReport.Action != ImportActionError {
+			if err := service.applyRow(c, row, &rowReport, brands); err != nil {
+				rowReport.Action = ImportActionError
+				rowReport.ID = nil
+				rowReport.Errors = map[string]string{"row": err.Error()}
+			}
+		}
+
+		service.count(job, &rowReport)
+		report = append(report, rowReport)
+
+		if (i+1)%100 == 0 {
+			db.Model(job).Updates(map[string]any{"processed": job.Processed, "created": job.Created, "updated": job.Updated, "failed": job.Failed})
+		}
+	}
+
+	finish(entity.ImportJobCompleted, nil)
+}
+
+// validateRow resolves the brand, validates the row like the create endpoint
+// would and looks up the car it would update.
+func (service *carImportServiceImpl) validateRow(db *gorm.DB, row *carImportRow, brands map[string]uint, createBrands bool) response.ImportRowReport {
+	rowReport := response.ImportRowReport{Row: row.line, Action: ImportActionCreate}
+
+	rowErrors := map[string]string{}
+	for field, message := range row.errors {
+		rowErrors[field] = message
+	}
+
+	pendingBrand := false
+
+	if row.Brand != "" {
+		if brandID, ok := brands[strings.ToLower(row.Brand)]; ok {
+			row.BrandID = brandID
+		} else if createBrands {
+			pendingBrand = true
+		} else {
+			rowErrors["brand"] = fmt.Sprintf("Brand '%s' not found", row.Brand)
+		}
+	} else if row.BrandID != 0 && !containsBrandID(brands, row.BrandID) {
+		rowErrors["brand_id"] = "Brand not found"
+	}
+
+	validated := row.CarCreateRequest
+	if pendingBrand {
+		// the brand gets an id once it is created
+		validated.BrandID = ^uint(0)
+	}
+
+	if err := binding.Validator.ValidateStruct(&validated); err != nil {
+		var validationErrors validator.ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			rowErrors["row"] = err.Error()
+		}
+		for _, fe := range validationErrors {
+			field := carImportColumns[fe.Field()]
+			if _, ok := rowErrors[field]; !ok {
+				rowErrors[field] = "Field validation for '" + field + "' failed on the '" + fe.Tag() + "' tag"
+			}
+		}
+	}
+
+	if row.Brand != "" {
+		delete(rowErrors, "brand_id")
+	} else if row.BrandID == 0 {
+		rowErrors["brand"] = "Either brand or brand_id is required"
+		delete(rowErrors, "brand_id")
+	}
+
+	if len(rowErrors) > 0 {
+		rowReport.Action = ImportActionError
+		rowReport.Errors = rowErrors
+		return rowReport
+	}
+
+	if !pendingBrand {
+		if carID, found := service.findExisting(db, row); found {
+			rowReport.Action = ImportActionUpdate
+			rowReport.ID = &carID
+		}
+	}
+
+	return rowReport
+}
+
+func (service *carImportServiceImpl) applyRow(c *gin.Context, row *carImportRow, rowReport *response.ImportRowReport, brands map[string]uint) error {
+	db, _ := helper.GetDBAndLogger(c)
+
+	if row.Brand != "" {
+		if _, ok := brands[strings.ToLower(row.Brand)]; !ok {
+			brand, err := service.brandService.Create(c, &request.BrandRequest{Name: row.Brand})
+			if err != nil {
+				return err
+			}
+			brands[strings.ToLower(brand.Name)] = brand.ID
+		}
+		row.BrandID = brands[strings.ToLower(row.Brand)]
+	}
+
+	if rowReport.Action == ImportActionCreate {
+		car, err := service.carService.Create(c, &row.CarCreateRequest)
+		if err == nil {
+			rowReport.ID = &car.ID
+			return nil
+		}
+
+		// an earlier row of the file or another import created the car since
+		// the row was validated
+		if !errors.Is(err, errCarExists) {
+			return err
+		}
+
+		carID, found := service.findExisting(db, row)
+		if !found {
+			return err
+		}
+
+		rowReport.Action = ImportActionUpdate
+		rowReport.ID = &carID
+	}
+
+	carUpdateReq := request.CarUpdateRequest(row.CarCreateRequest)
+
+	car, err := service.carService.Update(c, &carUpdateReq, *rowReport.ID)
+	if err != nil {
+		return err
+	}
+	rowReport.ID = &car.ID
+
+	return nil
+}
+
+func (service *carImportServiceImpl) findExisting(db *gorm.DB, row *carImportRow) (uint, bool) {
+	var car entity.Car
+
+	err := db.Select("id").
+		Where("brand_id = ? AND name = ? AND model = ? AND year = ?", row.BrandID, row.Name, strings.ToUpper(row.Model), row.Year).
+		Take(&car).Error
+
+	return car.ID, err == nil
+}
+
+func (service *carImportServiceImpl) loadBrands(db *gorm.DB) (map[string]uint, error) {
+	var brands []entity.Brand
+
+	if err := db.Find(&brands).Error; err != nil {
+		return nil, err
+	}
+
+	brandIDs := map[string]uint{}
+	for _, brand := range brands {
+		brandIDs[strings.ToLower(brand.Name)] = brand.ID
+	}
+
+	return brandIDs, nil
+}
+
+func (service *carImportServiceImpl) count(job *entity.ImportJob, rowReport *response.ImportRowReport) {
+	job.Processed++
+
+	switch rowReport.Action {
+	case ImportActionCreate:
+		job.Created++
+	case ImportActionUpdate:
+		job.Updated++
+	default:
+		job.Failed++
+	}
+}
+
+func containsBrandID(brands map[string]uint, brandID uint) bool {
+	for _, id := range brands {
+		if id == brandID {
+			return true
+		}
+	}
+	return false
+}
+
+func toImportJobResponse(job *entity.ImportJob, report []response.ImportRowReport) *response.ImportJobResponse {
+	return &response.ImportJobResponse{
+		ID:         job.ID,
+		Kind:       job.Kind,
+		Status:     job.Status,
+		Format:     job.Format,
+		TotalRows:  job.TotalRows,
+		Processed:  job.Processed,
+		Created:    job.Created,
+		Updated:    job.Updated,
+		Failed:     job.Failed,
+		Error:      job.Error,
+		Rows:       report,
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+}
+
+// carImportColumns maps the CarCreateRequest fields to their column names.
+var carImportColumns = func() map[string]string {
+	columns := map[string]string{}
+
+	carType := reflect.TypeOf(request.CarCreateRequest{})
+	for i := 0; i < carType.NumField(); i++ {
+		columns[carType.Field(i).Name] = carType.Field(i).Tag.Get("json")
+	}
+
+	return columns
+}()
+
+// parseCarCSV reads a CSV file whose header names the columns like the JSON
+// body of the create endpoint, plus an optional brand column with the brand
+// name. Cells that do not parse are reported on the row, not the file.
+func parseCarCSV(file io.Reader) ([]*carImportRow, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	fieldIndexes := map[string]int{}
+	carType := reflect.TypeOf(request.CarCreateRequest{})
+	for i := 0; i < carType.NumField(); i++ {
+		fieldIndexes[carType.Field(i).Tag.Get("json")] = i
+	}
+
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+		if _, ok := fieldIndexes[header[i]]; !ok && header[i] != "brand" {
+			return nil, fmt.Errorf("unknown column '%s'", header[i])
+		}
+	}
+
+	var rows []*carImportRow
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		row := &carImportRow{line: line, errors: map[string]string{}}
+		value := reflect.ValueOf(&row.CarCreateRequest).Elem()
+
+		for i, column := range header {
+			if i >= len(record) {
+				break
+			}
+
+			cell := strings.TrimSpace(record[i])
+			if cell == "" {
+				continue
+			}
+
+			if column == "brand" {
+				row.Brand = cell
+				continue
+			}
+
+			field := value.Field(fieldIndexes[column])
+
+			var parseErr error
+			switch field.Kind() {
+			case reflect.String:
+				field.SetString(cell)
+			case reflect.Int16:
+				var n int64
+				if n, parseErr = strconv.ParseInt(cell, 10, 16); parseErr == nil {
+					field.SetInt(n)
+				}
+			case reflect.Uint:
+				var n uint64
+				if n, parseErr = strconv.ParseUint(cell, 10, 32); parseErr == nil {
+					field.SetUint(n)
+				}
+			case reflect.Float32:
+				var n float64
+				if n, parseErr = strconv.ParseFloat(cell, 32); parseErr == nil {
+					field.SetFloat(n)
+				}
+			case reflect.Pointer:
+				var n uint64
+				if n, parseErr = strconv.ParseUint(cell, 10, 32); parseErr == nil {
+					id := uint(n)
+					field.Set(reflect.ValueOf(&id))
+				}
+			}
+
+			if parseErr != nil {
+				row.errors[column] = fmt.Sprintf("'%s' is not a valid %s", cell, strings.TrimPrefix(field.Type().String(), "*"))
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// parseCarNDJSON reads one JSON object per line with the same keys as the
+// create endpoint, plus an optional brand key with the brand name.
+func parseCarNDJSON(file io.Reader) ([]*carImportRow, error) {
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var rows []*carImportRow
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		row := &carImportRow{line: line, errors: map[string]string{}}
+		if err := json.Unmarshal([]byte(text), row); err != nil {
+			row.errors["row"] = "Invalid JSON: " + err.Error()
+		}
+
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid NDJSON: %w", err)
+	}
+
+	return rows, nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -12,8 +13,10 @@ import (
 	"github.com/raihanmd/fp-superbootcamp-go/model/web"
 	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
 	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CarService interface {
@@ -24,10 +27,23 @@ type CarService interface {
 	FindByID(*gin.Context, uint) (*response.CarResponse, error)
 }
 
-type carServiceImpl struct{}
+// carIdentityConflict skips the insert of a car that already exists, a car
+// being identified by its brand, name, model and year (idx_car_identity).
+var carIdentityConflict = clause.OnConflict{
+	Columns:     []clause.Column{{Name: "brand_id"}, {Name: "name"}, {Name: "model"}, {Name: "year"}},
+	TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL"}}},
+	DoNothing:   true,
+}
+
+var errCarExists = exceptions.NewCustomError(http.StatusConflict, "A car with this brand, name, model and year already exists")
+
+type carServiceImpl struct {
+	events EventBus
+}
 
-func NewCarService() CarService {
-	return &carServiceImpl{}
+// NewCarService emits the created, updated and deleted cars as events.
+func NewCarService(events EventBus) CarService {
+	return &carServiceImpl{events: events}
 }
 
 func (service *carServiceImpl) Create(c *gin.Context, carCreateReq *request.CarCreateRequest) (*response.CarResponse, error) {
@@ -35,13 +51,52 @@ func (service *carServiceImpl) Create(c *gin.Context, carCreateReq *request.CarC
 
 	newCar := service.toCarEntity(carCreateReq)
 
-	if err := db.Create(newCar).Error; err != nil {
-		if pgErr, ok := err.(*pgconn.PgError); ok {
-			// violation foreign key brand_id
-			if pgErr.Code == "23503" {
-				return nil, exceptions.NewCustomError(http.StatusNotFound, "Brand not found")
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if newCar.MediaID != nil {
+			imageURL, err := mediaImageURL(tx, *newCar.MediaID, nil)
+			if err != nil {
+				return err
 			}
+			newCar.ImageUrl = imageURL
 		}
+
+		// the specification and the gallery are only created once the car is
+		result := tx.Omit(clause.Associations).Clauses(carIdentityConflict).Create(newCar)
+		if result.Error != nil {
+			if pgErr, ok := result.Error.(*pgconn.PgError); ok {
+				// violation foreign key brand_id
+				if pgErr.Code == "23503" {
+					return exceptions.NewCustomError(http.StatusNotFound, "Brand not found")
+				}
+			}
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errCarExists
+		}
+
+		newCar.CarSpecification.CarID = newCar.ID
+		if err := tx.Create(&newCar.CarSpecification).Error; err != nil {
+			return err
+		}
+
+		newCar.Gallery = newGallery(newCar.ImageUrl, newCar.MediaID)
+		for i := range newCar.Gallery {
+			newCar.Gallery[i].OwnerType, newCar.Gallery[i].OwnerID = entity.GalleryOwnerCar, newCar.ID
+		}
+		if err := tx.Create(&newCar.Gallery).Error; err != nil {
+			return err
+		}
+
+		if err := recordAudit(c, tx, &entity.AuditEvent{Action: AuditCarCreate, TargetType: AuditTargetCar, TargetID: newCar.ID}, nil, service.toCarResponse(newCar)); err != nil {
+			return err
+		}
+
+		return service.events.Publish(tx, &CarChangedEvent{Name: EventCarCreated, Car: service.toCarResponse(newCar)})
+	})
+
+	if err != nil {
 		return nil, err
 	}
 
@@ -58,31 +113,75 @@ func (service *carServiceImpl) Update(c *gin.Context, carUpdateReq *request.CarU
 	var car entity.Car
 
 	err := db.Transaction(func(tx *gorm.DB) error {
-		result := db.Model(&entity.Car{}).Where("id = ?", carID).Updates(updateCar)
+		var before entity.Car
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Brand").Preload("CarSpecification").Preload("Gallery", orderedGallery).Take(&before, "id = ?", carID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return exceptions.NewCustomError(http.StatusNotFound, "Car not found")
+			}
+			return err
+		}
 
-		if result.RowsAffected == 0 {
-			return exceptions.NewCustomError(http.StatusNotFound, "Car not found")
+		if updateCar.MediaID != nil {
+			imageURL, err := mediaImageURL(tx, *updateCar.MediaID, nil)
+			if err != nil {
+				return err
+			}
+			updateCar.ImageUrl = imageURL
 		}
 
+		result := tx.Model(&entity.Car{}).Where("id = ?", carID).Updates(updateCar)
+
 		if result.Error != nil {
 			if pgErr, ok := result.Error.(*pgconn.PgError); ok {
 				// violation foreign key brand_id
 				if pgErr.Code == "23503" {
 					return exceptions.NewCustomError(http.StatusNotFound, "Brand not found")
 				}
+				// violation unique index idx_car_identity
+				if pgErr.Code == "23505" {
+					return errCarExists
+				}
 			}
 			return result.Error
 		}
 
-		if err := db.Model(&entity.CarSpecification{CarID: carID}).Where("car_id = ?", carID).Updates(updateCar.CarSpecification).Error; err != nil {
+		if result.RowsAffected == 0 {
+			return exceptions.NewCustomError(http.StatusNotFound, "Car not found")
+		}
+
+		if err := tx.Model(&entity.CarSpecification{CarID: carID}).Where("car_id = ?", carID).Updates(updateCar.CarSpecification).Error; err != nil {
 			return err
 		}
 
-		if err := tx.Preload("Brand").Preload("CarSpecification").Take(&car, "id = ?", carID).Error; err != nil {
+		if updateCar.ImageUrl != "" {
+			if err := setGalleryCover(tx, entity.GalleryOwnerCar, carID, updateCar.ImageUrl, updateCar.MediaID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Preload("Brand").Preload("CarSpecification").Preload("Gallery", orderedGallery).Take(&car, "id = ?", carID).Error; err != nil {
 			return err
 		}
 
-		return nil
+		var editorID *uint
+		if userID, _, err := utils.ExtractTokenClaims(c); err == nil {
+			editorID = &userID
+		}
+
+		if _, err := recordRevision(tx, entity.RevisionOwnerCar, carID, editorID, service.toCarSnapshot(&before), service.toCarSnapshot(&car)); err != nil {
+			return err
+		}
+
+		if err := recordAudit(c, tx, &entity.AuditEvent{Action: AuditCarUpdate, TargetType: AuditTargetCar, TargetID: carID}, service.toCarResponse(&before), service.toCarResponse(&car)); err != nil {
+			return err
+		}
+
+		events := []DomainEvent{&CarChangedEvent{Name: EventCarUpdated, Car: service.toCarResponse(&car)}}
+		if specs := changedCarSpecs(&before.CarSpecification, &car.CarSpecification); len(specs) > 0 {
+			events = append(events, &CarSpecsChangedEvent{CarID: carID, BrandID: car.BrandID, EditorID: editorID, Specs: specs})
+		}
+
+		return service.events.Publish(tx, events...)
 	})
 
 	if err != nil {
@@ -98,23 +197,38 @@ func (service *carServiceImpl) Delete(c *gin.Context, carID uint) error {
 	db, logger := helper.GetDBAndLogger(c)
 
 	err := db.Transaction(func(tx *gorm.DB) error {
-		result := db.Model(&entity.CarSpecification{}).Where("car_id = ?", carID).Delete(&entity.CarSpecification{})
+		var before entity.Car
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Brand").Preload("CarSpecification").Preload("Gallery", orderedGallery).Take(&before, "id = ?", carID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return exceptions.NewCustomError(http.StatusNotFound, "Car not found")
+			}
+			return err
+		}
 
-		if result.Error != nil {
-			return result.Error
+		// the specification and the gallery stay with the deleted car so it
+		// can be restored, the purge removes them. Its reviews and their
+		// comments share its deletion time, restoring the car restores
+		// exactly them
+		now := time.Now()
+
+		reviews := tx.Model(&entity.Review{}).Select("id").Where("car_id = ?", carID)
+		if err := tx.Model(&entity.Comment{}).Where("review_id IN (?)", reviews).UpdateColumn("deleted_at", now).Error; err != nil {
+			return err
 		}
 
-		if result.RowsAffected == 0 {
-			return exceptions.NewCustomError(http.StatusNotFound, "Car not found")
+		if err := tx.Model(&entity.Review{}).Where("car_id = ?", carID).UpdateColumn("deleted_at", now).Error; err != nil {
+			return err
 		}
 
-		err := db.Where("id = ?", carID).Delete(&entity.Car{}).Error
+		if err := tx.Model(&entity.Car{}).Where("id = ?", carID).UpdateColumn("deleted_at", now).Error; err != nil {
+			return err
+		}
 
-		if err != nil {
+		if err := recordAudit(c, tx, &entity.AuditEvent{Action: AuditCarDelete, TargetType: AuditTargetCar, TargetID: carID}, service.toCarResponse(&before), nil); err != nil {
 			return err
 		}
 
-		return nil
+		return service.events.Publish(tx, &CarChangedEvent{Name: EventCarDeleted, Car: service.toCarResponse(&before)})
 	})
 
 	if err != nil {
@@ -131,39 +245,12 @@ func (service *carServiceImpl) FindAll(c *gin.Context, carQueryReq *request.CarQ
 
 	var cars []entity.Car
 
-	query := db.Model(&entity.Car{})
+	query := filterCars(db.Model(&entity.Car{}), carQueryReq)
 
 	query.Count(&pagination.TotalData)
 
 	offset := (pagination.Page - 1) * pagination.Limit
-	query = query.Preload("Brand").Preload("CarSpecification").Limit(pagination.Limit).Offset(offset)
-
-	subquery := db.Model(&entity.CarSpecification{}).Select("car_id")
-
-	// Car Filtering
-	{
-		if carQueryReq.Name != nil {
-			subquery = subquery.Where("name ILIKE ?", "%"+*carQueryReq.Name+"%")
-		}
-
-		if carQueryReq.BrandID != nil {
-			subquery = subquery.Where("brand_id = ?", *carQueryReq.BrandID)
-		}
-
-		if carQueryReq.Model != nil {
-			subquery = subquery.Where("model ILIKE ?", "%"+*carQueryReq.Model+"%")
-		}
-
-		if carQueryReq.MinYear != nil {
-			subquery = subquery.Where("year >= ?", *carQueryReq.MinYear)
-		}
-
-		if carQueryReq.MaxYear != nil {
-			subquery = subquery.Where("year <= ?", *carQueryReq.MaxYear)
-		}
-	}
-
-	query = query.Order("id").Where("id IN (?)", subquery)
+	query = query.Preload("Brand").Preload("CarSpecification").Preload("Gallery", orderedGallery).Limit(pagination.Limit).Offset(offset).Order("id")
 
 	if err := query.Find(&cars).Error; err != nil {
 		return nil, nil, err
@@ -186,12 +273,38 @@ func (service *carServiceImpl) FindAll(c *gin.Context, carQueryReq *request.CarQ
 	return &responseCars, &metadata, nil
 }
 
+// filterCars applies the car list filters, the columns are qualified so the
+// query can be joined with brands and specifications.
+func filterCars(query *gorm.DB, carQueryReq *request.CarQueryRequest) *gorm.DB {
+	if carQueryReq.Name != nil {
+		query = query.Where("cars.name ILIKE ?", "%"+*carQueryReq.Name+"%")
+	}
+
+	if carQueryReq.BrandID != nil {
+		query = query.Where("cars.brand_id = ?", *carQueryReq.BrandID)
+	}
+
+	if carQueryReq.Model != nil {
+		query = query.Where("cars.model ILIKE ?", "%"+*carQueryReq.Model+"%")
+	}
+
+	if carQueryReq.MinYear != nil {
+		query = query.Where("cars.year >= ?", *carQueryReq.MinYear)
+	}
+
+	if carQueryReq.MaxYear != nil {
+		query = query.Where("cars.year <= ?", *carQueryReq.MaxYear)
+	}
+
+	return query
+}
+
 func (service *carServiceImpl) FindByID(c *gin.Context, carId uint) (*response.CarResponse, error) {
 	db, _ := helper.GetDBAndLogger(c)
 
 	var car entity.Car
 
-	if err := db.Preload("Brand").Preload("CarSpecification").Take(&car, "id = ?", carId).Error; err != nil {
+	if err := db.Preload("Brand").Preload("CarSpecification").Preload("Gallery", orderedGallery).Take(&car, "id = ?", carId).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, exceptions.NewCustomError(http.StatusNotFound, "Car not found")
 		}
@@ -210,6 +323,7 @@ func (service *carServiceImpl) toCarResponse(car *entity.Car) *response.CarRespo
 		Model:               car.Model,
 		Year:                car.Year,
 		ImageUrl:            car.ImageUrl,
+		MediaID:             car.MediaID,
 		Width:               car.CarSpecification.Dimension.Width,
 		Height:              car.CarSpecification.Dimension.Height,
 		Length:              car.CarSpecification.Dimension.Length,
@@ -221,6 +335,35 @@ func (service *carServiceImpl) toCarResponse(car *entity.Car) *response.CarRespo
 		BreakingSystemFront: car.CarSpecification.BreakingSystem.Front,
 		BreakingSystemBack:  car.CarSpecification.BreakingSystem.Back,
 		Fuel:                car.CarSpecification.Fuel,
+		Gallery:             *toGalleryImageResponses(car.Gallery),
+	}
+}
+
+// toCarSnapshot is the car as recorded in its revisions, without the gallery
+// which has a history of its own in the audit log.
+func (service *carServiceImpl) toCarSnapshot(car *entity.Car) *response.CarExportResponse {
+	carRes := service.toCarResponse(car)
+
+	return &response.CarExportResponse{
+		ID:                  carRes.ID,
+		BrandID:             carRes.BrandID,
+		BrandName:           carRes.BrandName,
+		Name:                carRes.Name,
+		Model:               carRes.Model,
+		Year:                carRes.Year,
+		ImageUrl:            carRes.ImageUrl,
+		MediaID:             carRes.MediaID,
+		Width:               carRes.Width,
+		Height:              carRes.Height,
+		Length:              carRes.Length,
+		Engine:              carRes.Engine,
+		Torque:              carRes.Torque,
+		Transmission:        carRes.Transmission,
+		Acceleration:        carRes.Acceleration,
+		HorsePower:          carRes.HorsePower,
+		BreakingSystemFront: carRes.BreakingSystemFront,
+		BreakingSystemBack:  carRes.BreakingSystemBack,
+		Fuel:                carRes.Fuel,
 	}
 }
 
@@ -233,6 +376,7 @@ func (service *carServiceImpl) toCarEntity(req any) *entity.Car {
 			Model:    strings.ToUpper(r.Model),
 			Year:     r.Year,
 			ImageUrl: r.ImageUrl,
+			MediaID:  r.MediaID,
 			CarSpecification: entity.CarSpecification{
 				Dimension: entity.CarDimension{
 					Length: r.Length,
@@ -258,6 +402,7 @@ func (service *carServiceImpl) toCarEntity(req any) *entity.Car {
 			Model:    strings.ToUpper(r.Model),
 			Year:     r.Year,
 			ImageUrl: r.ImageUrl,
+			MediaID:  r.MediaID,
 			CarSpecification: entity.CarSpecification{
 				Dimension: entity.CarDimension{
 					Length: r.Length,
@@ -280,3 +425,33 @@ func (service *carServiceImpl) toCarEntity(req any) *entity.Car {
 		return nil
 	}
 }
+
+// changedCarSpecs names the specifications that differ, in the order of the
+// car response.
+func changedCarSpecs(before, after *entity.CarSpecification) []string {
+	specs := []struct {
+		name    string
+		changed bool
+	}{
+		{"width", before.Dimension.Width != after.Dimension.Width},
+		{"height", before.Dimension.Height != after.Dimension.Height},
+		{"length", before.Dimension.Length != after.Dimension.Length},
+		{"engine", before.Engine != after.Engine},
+		{"torque", before.Torque != after.Torque},
+		{"transmission", before.Transmission != after.Transmission},
+		{"acceleration", before.Acceleration != after.Acceleration},
+		{"horse power", before.HorsePower != after.HorsePower},
+		{"front brakes", before.BreakingSystem.Front != after.BreakingSystem.Front},
+		{"rear brakes", before.BreakingSystem.Back != after.BreakingSystem.Back},
+		{"fuel", before.Fuel != after.Fuel},
+	}
+
+	var changed []string
+	for _, spec := range specs {
+		if spec.changed {
+			changed = append(changed, spec.name)
+		}
+	}
+
+	return changed
+}
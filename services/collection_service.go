@@ -0,0 +1,540 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultCollectionName = "Favourites"
+	collectionSlugSuffix  = 10
+	collectionSlugLetters = "abcdefghijkmnpqrstuvwxyz23456789"
+)
+
+type CollectionService interface {
+	Create(*gin.Context, uint, *request.CollectionCreateRequest) (*response.CollectionResponse, error)
+	Update(*gin.Context, string, uint, *request.CollectionUpdateRequest) (*response.CollectionResponse, error)
+	Delete(*gin.Context, string, uint) error
+	FindBySlug(*gin.Context, string) (*response.CollectionResponse, error)
+	FindByUser(*gin.Context, uint, *web.PaginationRequest) (*[]response.CollectionResponse, *web.Metadata, error)
+	FindItems(*gin.Context, string, *web.PaginationRequest) (*[]response.CollectionItemResponse, *web.Metadata, error)
+	AddItem(*gin.Context, string, uint, *request.CollectionItemCreateRequest) (*response.CollectionItemResponse, error)
+	UpdateItem(*gin.Context, string, uint, uint, *request.CollectionItemUpdateRequest) (*response.CollectionItemResponse, error)
+	RemoveItem(*gin.Context, string, uint, uint) error
+	Reorder(*gin.Context, string, uint, *request.CollectionReorderRequest) (*[]response.CollectionItemResponse, error)
+}
+
+type collectionServiceImpl struct{}
+
+func NewCollectionService() CollectionService {
+	return &collectionServiceImpl{}
+}
+
+type collectionRow struct {
+	entity.FavouriteCollection
+	Username  string
+	ItemCount int64
+}
+
+func (service *collectionServiceImpl) Create(c *gin.Context, userID uint, collectionCreateReq *request.CollectionCreateRequest) (*response.CollectionResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	slug, err := newCollectionSlug(collectionCreateReq.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := entity.FavouriteCollection{
+		UserID:     userID,
+		Name:       collectionCreateReq.Name,
+		Slug:       slug,
+		Visibility: collectionCreateReq.Visibility,
+	}
+	if collection.Visibility == "" {
+		collection.Visibility = entity.CollectionPrivate
+	}
+
+	if err := db.Create(&collection).Error; err != nil {
+		return nil, err
+	}
+
+	logger.Info("collection created successfully", zap.Uint("collectionID", collection.ID), zap.Uint("userID", userID))
+
+	return findCollectionResponse(db, collection.ID)
+}
+
+// Update renames a collection or changes its visibility, the slug stays so
+// that shared links keep working.
+func (service *collectionServiceImpl) Update(c *gin.Context, slug string, userID uint, collectionUpdateReq *request.CollectionUpdateRequest) (*response.CollectionResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	collection, err := findOwnCollection(db, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Model(collection).Updates(&entity.FavouriteCollection{Name: collectionUpdateReq.Name, Visibility: collectionUpdateReq.Visibility}).Error; err != nil {
+		return nil, err
+	}
+
+	logger.Info("collection updated successfully", zap.Uint("collectionID", collection.ID), zap.Uint("userID", userID))
+
+	return findCollectionResponse(db, collection.ID)
+}
+
+// Delete removes a collection with its items, the default collection stays.
+func (service *collectionServiceImpl) Delete(c *gin.Context, slug string, userID uint) error {
+	db, logger := helper.GetDBAndLogger(c)
+
+	collection, err := findOwnCollection(db, slug, userID)
+	if err != nil {
+		return err
+	}
+
+	if collection.IsDefault {
+		return exceptions.NewCustomError(http.StatusBadRequest, "The default collection cannot be deleted")
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", collection.ID).Delete(&entity.Favourite{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(collection).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("collection deleted successfully", zap.Uint("collectionID", collection.ID), zap.Uint("userID", userID))
+
+	return nil
+}
+
+func (service *collectionServiceImpl) FindBySlug(c *gin.Context, slug string) (*response.CollectionResponse, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	collection, err := findVisibleCollection(c, db, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return findCollectionResponse(db, collection.ID)
+}
+
+// FindByUser lists the public collections of a user, or all of them for the
+// user themselves, default first then latest first.
+func (service *collectionServiceImpl) FindByUser(c *gin.Context, ownerID uint, paging *web.PaginationRequest) (*[]response.CollectionResponse, *web.Metadata, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	if err := findFollowTarget(db, entity.FollowTargetUser, ownerID); err != nil {
+		return nil, nil, err
+	}
+
+	query := db.Model(&entity.FavouriteCollection{}).Where("favourite_collections.user_id = ?", ownerID)
+
+	if viewerID, _, err := utils.ExtractTokenClaims(c); err != nil || viewerID != ownerID {
+		query = query.Where("favourite_collections.visibility = ?", entity.CollectionPublic)
+	}
+
+	query.Count(&paging.TotalData)
+
+	offset := (paging.Page - 1) * paging.Limit
+
+	var rows []collectionRow
+	if err := selectCollections(query).
+		Order("favourite_collections.is_default desc, favourite_collections.created_at desc, favourite_collections.id desc").
+		Limit(paging.Limit).Offset(offset).
+		Scan(&rows).Error; err != nil {
+		return nil, nil, err
+	}
+
+	paging.TotalPages = int((paging.TotalData + int64(paging.Limit) - 1) / int64(paging.Limit))
+
+	collections := []response.CollectionResponse{}
+	for i := range rows {
+		collections = append(collections, *toCollectionResponse(&rows[i]))
+	}
+
+	metadata := web.Metadata{
+		Page:       &paging.Page,
+		Limit:      &paging.Limit,
+		TotalPages: &paging.TotalPages,
+		TotalData:  &paging.TotalData,
+	}
+
+	return &collections, &metadata, nil
+}
+
+// FindItems lists the cars of a collection in their manual order. Deleted
+// cars are left out until they are restored.
+func (service *collectionServiceImpl) FindItems(c *gin.Context, slug string, paging *web.PaginationRequest) (*[]response.CollectionItemResponse, *web.Metadata, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	collection, err := findVisibleCollection(c, db, slug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := collectionItems(db, collection.ID)
+
+	query.Count(&paging.TotalData)
+
+	offset := (paging.Page - 1) * paging.Limit
+
+	items := []response.CollectionItemResponse{}
+	if err := selectCollectionItems(query).
+		Limit(paging.Limit).Offset(offset).
+		Scan(&items).Error; err != nil {
+		return nil, nil, err
+	}
+
+	paging.TotalPages = int((paging.TotalData + int64(paging.Limit) - 1) / int64(paging.Limit))
+
+	metadata := web.Metadata{
+		Page:       &paging.Page,
+		Limit:      &paging.Limit,
+		TotalPages: &paging.TotalPages,
+		TotalData:  &paging.TotalData,
+	}
+
+	return &items, &metadata, nil
+}
+
+// AddItem puts a car at the end of a collection.
+func (service *collectionServiceImpl) AddItem(c *gin.Context, slug string, userID uint, collectionItemReq *request.CollectionItemCreateRequest) (*response.CollectionItemResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	var item response.CollectionItemResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		collection, err := findOwnCollection(tx.Clauses(clause.Locking{Strength: "UPDATE"}), slug, userID)
+		if err != nil {
+			return err
+		}
+
+		added, err := addCollectionItem(tx, collection, collectionItemReq.CarID, collectionItemReq.Note)
+		if err != nil {
+			return err
+		}
+
+		if !added {
+			return exceptions.NewCustomError(http.StatusConflict, "The car is already in this collection")
+		}
+
+		return selectCollectionItems(collectionItems(tx, collection.ID)).Where("favourites.car_id = ?", collectionItemReq.CarID).Take(&item).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("car added to collection successfully", zap.String("slug", slug), zap.Uint("carID", collectionItemReq.CarID), zap.Uint("userID", userID))
+
+	return &item, nil
+}
+
+func (service *collectionServiceImpl) UpdateItem(c *gin.Context, slug string, carID, userID uint, collectionItemReq *request.CollectionItemUpdateRequest) (*response.CollectionItemResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	collection, err := findOwnCollection(db, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := db.Model(&entity.Favourite{}).
+		Where("collection_id = ? AND car_id = ?", collection.ID, carID).
+		UpdateColumn("note", collectionItemReq.Note)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, exceptions.NewCustomError(http.StatusNotFound, "Car not in this collection")
+	}
+
+	var item response.CollectionItemResponse
+	if err := selectCollectionItems(collectionItems(db, collection.ID)).Where("favourites.car_id = ?", carID).Take(&item).Error; err != nil {
+		return nil, err
+	}
+
+	logger.Info("collection item updated successfully", zap.String("slug", slug), zap.Uint("carID", carID), zap.Uint("userID", userID))
+
+	return &item, nil
+}
+
+func (service *collectionServiceImpl) RemoveItem(c *gin.Context, slug string, carID, userID uint) error {
+	db, logger := helper.GetDBAndLogger(c)
+
+	collection, err := findOwnCollection(db, slug, userID)
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("collection_id = ? AND car_id = ?", collection.ID, carID).Delete(&entity.Favourite{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return exceptions.NewCustomError(http.StatusNotFound, "Car not in this collection")
+	}
+
+	logger.Info("car removed from collection successfully", zap.String("slug", slug), zap.Uint("carID", carID), zap.Uint("userID", userID))
+
+	return nil
+}
+
+// Reorder sets the manual order of a collection, car_ids lists every car of
+// the collection once, deleted ones included.
+func (service *collectionServiceImpl) Reorder(c *gin.Context, slug string, userID uint, collectionReorderReq *request.CollectionReorderRequest) (*[]response.CollectionItemResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	items := []response.CollectionItemResponse{}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		collection, err := findOwnCollection(tx.Clauses(clause.Locking{Strength: "UPDATE"}), slug, userID)
+		if err != nil {
+			return err
+		}
+
+		var carIDs []uint
+		if err := tx.Model(&entity.Favourite{}).Where("collection_id = ?", collection.ID).Pluck("car_id", &carIDs).Error; err != nil {
+			return err
+		}
+
+		inCollection := map[uint]bool{}
+		for _, carID := range carIDs {
+			inCollection[carID] = true
+		}
+
+		seen := map[uint]bool{}
+		for _, carID := range collectionReorderReq.CarIDs {
+			if seen[carID] || !inCollection[carID] {
+				return exceptions.NewCustomError(http.StatusBadRequest, "car_ids must list every car of the collection once")
+			}
+			seen[carID] = true
+		}
+
+		if len(seen) != len(carIDs) {
+			return exceptions.NewCustomError(http.StatusBadRequest, "car_ids must list every car of the collection once")
+		}
+
+		for position, carID := range collectionReorderReq.CarIDs {
+			if err := tx.Model(&entity.Favourite{}).Where("collection_id = ? AND car_id = ?", collection.ID, carID).UpdateColumn("position", position).Error; err != nil {
+				return err
+			}
+		}
+
+		return selectCollectionItems(collectionItems(tx, collection.ID)).Scan(&items).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("collection reordered successfully", zap.String("slug", slug), zap.Uint("userID", userID))
+
+	return &items, nil
+}
+
+// findVisibleCollection finds a collection the current user may see: a
+// public or unlisted one, or one of their own.
+func findVisibleCollection(c *gin.Context, db *gorm.DB, slug string) (*entity.FavouriteCollection, error) {
+	var collection entity.FavouriteCollection
+	if err := db.Take(&collection, "slug = ?", slug).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exceptions.NewCustomError(http.StatusNotFound, "Collection not found")
+		}
+		return nil, err
+	}
+
+	if collection.Visibility == entity.CollectionPrivate {
+		if viewerID, _, err := utils.ExtractTokenClaims(c); err != nil || viewerID != collection.UserID {
+			return nil, exceptions.NewCustomError(http.StatusNotFound, "Collection not found")
+		}
+	}
+
+	return &collection, nil
+}
+
+// findOwnCollection finds a collection of the user, the private collections
+// of others are not found.
+func findOwnCollection(db *gorm.DB, slug string, userID uint) (*entity.FavouriteCollection, error) {
+	var collection entity.FavouriteCollection
+	if err := db.Take(&collection, "slug = ?", slug).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exceptions.NewCustomError(http.StatusNotFound, "Collection not found")
+		}
+		return nil, err
+	}
+
+	if collection.UserID != userID {
+		if collection.Visibility == entity.CollectionPrivate {
+			return nil, exceptions.NewCustomError(http.StatusNotFound, "Collection not found")
+		}
+		return nil, exceptions.NewCustomError(http.StatusForbidden, "You can only edit your own collections")
+	}
+
+	return &collection, nil
+}
+
+// defaultCollection returns the default collection of the user, created on
+// first use.
+func defaultCollection(tx *gorm.DB, userID uint) (*entity.FavouriteCollection, error) {
+	slug, err := newCollectionSlug(defaultCollectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "user_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "is_default"}}},
+		DoNothing:   true,
+	}).Create(&entity.FavouriteCollection{
+		UserID:     userID,
+		Name:       defaultCollectionName,
+		Slug:       slug,
+		Visibility: entity.CollectionPrivate,
+		IsDefault:  true,
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	var collection entity.FavouriteCollection
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&collection, "user_id = ? AND is_default", userID).Error; err != nil {
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+// addCollectionItem puts a car that is not deleted at the end of a locked
+// collection, it is not added when it is already in the collection.
+func addCollectionItem(tx *gorm.DB, collection *entity.FavouriteCollection, carID uint, note string) (bool, error) {
+	if err := tx.Select("id").Take(&entity.Car{}, carID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, exceptions.NewCustomError(http.StatusNotFound, "Car not found")
+		}
+		return false, err
+	}
+
+	var position int
+	if err := tx.Model(&entity.Favourite{}).Select("COALESCE(MAX(position) + 1, 0)").Where("collection_id = ?", collection.ID).Scan(&position).Error; err != nil {
+		return false, err
+	}
+
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.Favourite{
+		UserID:       collection.UserID,
+		CollectionID: collection.ID,
+		CarID:        carID,
+		Note:         note,
+		Position:     position,
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func collectionItems(db *gorm.DB, collectionID uint) *gorm.DB {
+	return db.Model(&entity.Favourite{}).
+		Joins("JOIN cars ON cars.id = favourites.car_id AND cars.deleted_at IS NULL").
+		Joins("JOIN brands ON brands.id = cars.brand_id").
+		Where("favourites.collection_id = ?", collectionID)
+}
+
+func selectCollectionItems(query *gorm.DB) *gorm.DB {
+	return query.Select("cars.id AS car_id, brands.name AS brand, cars.name, cars.model, cars.image_url, favourites.note, favourites.position, favourites.created_at AS added_at").
+		Order("favourites.position, favourites.created_at")
+}
+
+func selectCollections(query *gorm.DB) *gorm.DB {
+	return query.Select(`favourite_collections.*, users.username,
+		(SELECT COUNT(*) FROM favourites JOIN cars ON cars.id = favourites.car_id AND cars.deleted_at IS NULL WHERE favourites.collection_id = favourite_collections.id) AS item_count`).
+		Joins("JOIN users ON users.id = favourite_collections.user_id")
+}
+
+func findCollectionResponse(db *gorm.DB, collectionID uint) (*response.CollectionResponse, error) {
+	var row collectionRow
+	if err := selectCollections(db.Model(&entity.FavouriteCollection{})).Take(&row, "favourite_collections.id = ?", collectionID).Error; err != nil {
+		return nil, err
+	}
+
+	return toCollectionResponse(&row), nil
+}
+
+func toCollectionResponse(row *collectionRow) *response.CollectionResponse {
+	return &response.CollectionResponse{
+		ID:         row.ID,
+		UserID:     row.UserID,
+		Username:   row.Username,
+		Name:       row.Name,
+		Slug:       row.Slug,
+		Visibility: row.Visibility,
+		IsDefault:  row.IsDefault,
+		ItemCount:  row.ItemCount,
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+	}
+}
+
+// newCollectionSlug is the name in lowercase ASCII words with a random
+// suffix, so that slugs are unique and unlisted collections cannot be found
+// from their name.
+func newCollectionSlug(name string) (string, error) {
+	var words strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			words.WriteRune(r)
+		case words.Len() > 0 && !strings.HasSuffix(words.String(), "-"):
+			words.WriteByte('-')
+		}
+	}
+
+	random := make([]byte, collectionSlugSuffix)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	suffix := make([]byte, collectionSlugSuffix)
+	for i, b := range random {
+		suffix[i] = collectionSlugLetters[int(b)%len(collectionSlugLetters)]
+	}
+
+	base := words.String()
+	if len(base) > 100 {
+		base = base[:100]
+	}
+	base = strings.Trim(base, "-")
+
+	if base == "" {
+		return string(suffix), nil
+	}
+
+	return base + "-" + string(suffix), nil
+}
+
+// deleteUserCollections removes the collections of a deleted user with their
+// items.
+func deleteUserCollections(tx *gorm.DB, userID uint) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&entity.Favourite{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Where("user_id = ?", userID).Delete(&entity.FavouriteCollection{}).Error
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CommentReactionService interface {
+	React(*gin.Context, uint, uint, string) (*[]response.CommentReactionResponse, error)
+	Unreact(*gin.Context, uint, uint, string) (*[]response.CommentReactionResponse, error)
+}
+
+type commentReactionServiceImpl struct {
+	emojis []string
+}
+
+// NewCommentReactionService allows the comma separated emojis of
+// COMMENT_REACTIONS.
+func NewCommentReactionService() CommentReactionService {
+	var emojis []string
+	for _, emoji := range strings.Split(helper.GetEnv("COMMENT_REACTIONS", "👍,❤️,😂,😮,😢"), ",") {
+		if emoji = strings.TrimSpace(emoji); emoji != "" {
+			emojis = append(emojis, emoji)
+		}
+	}
+
+	return &commentReactionServiceImpl{emojis}
+}
+
+func (service *commentReactionServiceImpl) React(c *gin.Context, commentID, userID uint, emoji string) (*[]response.CommentReactionResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	allowed, ok := service.allowed(emoji)
+	if !ok {
+		return nil, exceptions.NewCustomError(http.StatusBadRequest, "Reaction must be one of "+strings.Join(service.emojis, " "))
+	}
+
+	reaction := entity.CommentReaction{
+		CommentID: commentID,
+		UserID:    userID,
+		Emoji:     allowed,
+	}
+
+	var reactions []response.CommentReactionResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		comment, err := findModerationTarget(tx, entity.ReportTargetComment, commentID)
+		if err != nil {
+			return err
+		}
+
+		if comment.Status != entity.StatusPublished {
+			return exceptions.NewCustomError(http.StatusNotFound, "comment not found")
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&reaction)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected > 0 {
+			if err := refreshCommentReactions(tx, commentID); err != nil {
+				return err
+			}
+		}
+
+		reactions, err = findCommentReactions(tx, commentID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("comment reacted successfully", zap.Uint("commentID", commentID), zap.Uint("userID", userID), zap.String("emoji", allowed))
+
+	return &reactions, nil
+}
+
+// Unreact removes a reaction, also one that is no longer in COMMENT_REACTIONS.
+func (service *commentReactionServiceImpl) Unreact(c *gin.Context, commentID, userID uint, emoji string) (*[]response.CommentReactionResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	if allowed, ok := service.allowed(emoji); ok {
+		emoji = allowed
+	}
+
+	var reactions []response.CommentReactionResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if _, err := findModerationTarget(tx, entity.ReportTargetComment, commentID); err != nil {
+			return err
+		}
+
+		result := tx.Where("comment_id = ? AND user_id = ? AND emoji = ?", commentID, userID, emoji).Delete(&entity.CommentReaction{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return exceptions.NewCustomError(http.StatusNotFound, "Reaction not found")
+		}
+
+		if err := refreshCommentReactions(tx, commentID); err != nil {
+			return err
+		}
+
+		var err error
+		reactions, err = findCommentReactions(tx, commentID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("comment reaction removed successfully", zap.Uint("commentID", commentID), zap.Uint("userID", userID), zap.String("emoji", emoji))
+
+	return &reactions, nil
+}
+
+// allowed finds the emoji in COMMENT_REACTIONS, with or without the variation
+// selector that turns ❤ into ❤️.
+func (service *commentReactionServiceImpl) allowed(emoji string) (string, bool) {
+	for _, allowed := range service.emojis {
+		if stripVariationSelector(allowed) == stripVariationSelector(emoji) {
+			return allowed, true
+		}
+	}
+	return "", false
+}
+
+func stripVariationSelector(emoji string) string {
+	return strings.ReplaceAll(emoji, "\uFE0F", "")
+}
+
+// refreshCommentReactions recounts the reactions of a comment into its
+// reactions column. The caller must hold a lock on the comment row.
+func refreshCommentReactions(tx *gorm.DB, commentID uint) error {
+	var rows []struct {
+		Emoji string
+		Count int
+	}
+
+	if err := tx.Model(&entity.CommentReaction{}).
+		Select("emoji, COUNT(*) AS count").
+		Where("comment_id = ?", commentID).
+		Group("emoji").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	counts := map[string]int{}
+	for _, row := range rows {
+		counts[row.Emoji] = row.Count
+	}
+
+	raw, err := json.Marshal(counts)
+	if err != nil {
+		return err
+	}
+
+	return tx.Unscoped().Model(&entity.Comment{}).Where("id = ?", commentID).UpdateColumn("reactions", string(raw)).Error
+}
+
+func findCommentReactions(tx *gorm.DB, commentID, userID uint) ([]response.CommentReactionResponse, error) {
+	var comment entity.Comment
+	if err := tx.Select("id", "reactions").Take(&comment, commentID).Error; err != nil {
+		return nil, err
+	}
+
+	reacted, err := userCommentReactions(tx, userID, []uint{commentID})
+	if err != nil {
+		return nil, err
+	}
+
+	return toCommentReactionResponses(comment.Reactions, reacted[commentID]), nil
+}
+
+// userCommentReactions is the emojis the user reacted with on each of the
+// comments, fetched in one query for a whole list.
+func userCommentReactions(db *gorm.DB, userID uint, commentIDs []uint) (map[uint]map[string]bool, error) {
+	reacted := map[uint]map[string]bool{}
+	if userID == 0 || len(commentIDs) == 0 {
+		return reacted, nil
+	}
+
+	var reactions []entity.CommentReaction
+	if err := db.Select("comment_id", "emoji").Where("user_id = ? AND comment_id IN ?", userID, commentIDs).Find(&reactions).Error; err != nil {
+		return nil, err
+	}
+
+	for _, reaction := range reactions {
+		if reacted[reaction.CommentID] == nil {
+			reacted[reaction.CommentID] = map[string]bool{}
+		}
+		reacted[reaction.CommentID][reaction.Emoji] = true
+	}
+
+	return reacted, nil
+}
+
+// toCommentReactionResponses turns the stored counts into a list, the most
+// used emoji first.
+func toCommentReactionResponses(counts string, reacted map[string]bool) []response.CommentReactionResponse {
+	reactions := []response.CommentReactionResponse{}
+
+	var parsed map[string]int
+	if err := json.Unmarshal([]byte(counts), &parsed); err != nil {
+		return reactions
+	}
+
+	for emoji, count := range parsed {
+		reactions = append(reactions, response.CommentReactionResponse{Emoji: emoji, Count: count, Reacted: reacted[emoji]})
+	}
+
+	sort.Slice(reactions, func(i, j int) bool {
+		if reactions[i].Count != reactions[j].Count {
+			return reactions[i].Count > reactions[j].Count
+		}
+		return reactions[i].Emoji < reactions[j].Emoji
+	})
+
+	return reactions
+}
+
+// deleteUserCommentReactions removes the reactions of a deleted user and
+// recounts the comments they reacted on.
+func deleteUserCommentReactions(tx *gorm.DB, userID uint) error {
+	var commentIDs []uint
+	if err := tx.Model(&entity.CommentReaction{}).Distinct("comment_id").Where("user_id = ?", userID).Order("comment_id").Pluck("comment_id", &commentIDs).Error; err != nil {
+		return err
+	}
+
+	if len(commentIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Unscoped().Model(&entity.Comment{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", commentIDs).Order("id").Pluck("id", &[]uint{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("user_id = ?", userID).Delete(&entity.CommentReaction{}).Error; err != nil {
+		return err
+	}
+
+	for _, commentID := range commentIDs {
+		if err := refreshCommentReactions(tx, commentID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// viewerID is the signed in user of a public request, 0 for guests.
+func viewerID(c *gin.Context) uint {
+	userID, _, err := utils.ExtractTokenClaims(c)
+	if err != nil {
+		return 0
+	}
+	return userID
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -21,10 +22,21 @@ type CommentService interface {
 	FindByReviewId(*gin.Context, uint) (*[]response.CommentResponse, error)
 }
 
-type commentServiceImpl struct{}
+type commentServiceImpl struct {
+	contentFilter ContentFilter
+	events        EventBus
+	newAccountAge time.Duration
+}
 
-func NewCommentService() CommentService {
-	return &commentServiceImpl{}
+// NewCommentService screens comments with the content filter and holds the
+// comments of accounts younger than MODERATION_NEW_ACCOUNT_DAYS for
+// moderation. Published comments and new mentions are emitted as events.
+func NewCommentService(contentFilter ContentFilter, events EventBus) CommentService {
+	return &commentServiceImpl{
+		contentFilter: contentFilter,
+		events:        events,
+		newAccountAge: moderationNewAccountAge(),
+	}
 }
 
 func (service *commentServiceImpl) Create(c *gin.Context, commentCreateReq *request.CommentCreateRequest, userID uint) (*response.CommentResponse, error) {
@@ -35,6 +47,40 @@ func (service *commentServiceImpl) Create(c *gin.Context, commentCreateReq *requ
 	newComment.UserID = userID
 
 	err := db.Transaction(func(tx *gorm.DB) error {
+		// deleted reviews still satisfy the foreign key
+		if err := tx.Select("id").Where("status = ?", entity.StatusPublished).Take(&entity.Review{}, newComment.ReviewID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return exceptions.NewCustomError(http.StatusNotFound, "Review not found")
+			}
+			return err
+		}
+
+		// a reply stays on the review of its parent
+		if newComment.ParentID != nil {
+			if err := tx.Select("id").Where("review_id = ? AND status = ?", newComment.ReviewID, entity.StatusPublished).Take(&entity.Comment{}, *newComment.ParentID).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return exceptions.NewCustomError(http.StatusNotFound, "Parent comment not found")
+				}
+				return err
+			}
+		}
+
+		status, err := initialStatus(tx, userID, service.newAccountAge)
+		if err != nil {
+			return err
+		}
+		newComment.Status = status
+
+		screened, err := screenContent(tx, service.contentFilter, &ContentItem{Type: entity.ReportTargetComment, UserID: userID, Text: newComment.Content})
+		if err != nil {
+			return err
+		}
+		newComment.ContentHash = screened.Hash
+		newComment.Screening = screened.Screening
+		if screened.Held {
+			newComment.Status = entity.StatusPending
+		}
+
 		if err := tx.Create(newComment).Error; err != nil {
 			if pgErr, ok := err.(*pgconn.PgError); ok {
 				// violation foreign key review_id
@@ -45,6 +91,19 @@ func (service *commentServiceImpl) Create(c *gin.Context, commentCreateReq *requ
 			return err
 		}
 
+		mentioned, err := saveMentions(tx, entity.MentionOwnerComment, newComment.ID, userID, newComment.Content)
+		if err != nil {
+			return err
+		}
+
+		if newComment.Status == entity.StatusPublished {
+			if err := service.events.Publish(tx,
+				&CommentPublishedEvent{CommentID: newComment.ID, ReviewID: newComment.ReviewID, ParentID: newComment.ParentID, AuthorID: userID},
+				&UserMentionedEvent{OwnerType: entity.MentionOwnerComment, OwnerID: newComment.ID, AuthorID: userID, UserIDs: mentioned}); err != nil {
+				return err
+			}
+		}
+
 		if err := tx.Model(&entity.Comment{}).
 			Preload("User", func(tx *gorm.DB) *gorm.DB {
 				return tx.Select("id, username")
@@ -62,7 +121,12 @@ func (service *commentServiceImpl) Create(c *gin.Context, commentCreateReq *requ
 
 	logger.Info("Comment created successfully", zap.Any("comment", newComment))
 
-	return service.toCommentResponse(newComment), nil
+	mentions, err := loadMentions(db, entity.MentionOwnerComment, []uint{newComment.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return toCommentResponse(newComment, mentions[newComment.ID], nil), nil
 }
 
 func (service *commentServiceImpl) Update(c *gin.Context, commentUpdateReq *request.CommentUpdateRequest, userID, commentID uint) (*response.CommentResponse, error) {
@@ -81,12 +145,40 @@ func (service *commentServiceImpl) Update(c *gin.Context, commentUpdateReq *requ
 			return exceptions.NewCustomError(http.StatusNotFound, "Comment not found")
 		}
 
+		wasPublished := comment.Status == entity.StatusPublished
 		comment.Content = commentUpdateReq.Content
 
+		screened, err := screenContent(tx, service.contentFilter, &ContentItem{Type: entity.ReportTargetComment, ID: commentID, UserID: userID, Text: comment.Content})
+		if err != nil {
+			return err
+		}
+		comment.ContentHash = screened.Hash
+		comment.Screening = screened.Screening
+		if screened.Held && comment.Status == entity.StatusPublished {
+			comment.Status = entity.StatusPending
+		}
+
 		if err := tx.Save(&comment).Error; err != nil {
 			return err
 		}
 
+		mentioned, err := saveMentions(tx, entity.MentionOwnerComment, commentID, userID, comment.Content)
+		if err != nil {
+			return err
+		}
+
+		if comment.Status == entity.StatusPublished {
+			if err := service.events.Publish(tx,
+				&CommentUpdatedEvent{CommentID: commentID, ReviewID: comment.ReviewID},
+				&UserMentionedEvent{OwnerType: entity.MentionOwnerComment, OwnerID: commentID, AuthorID: userID, UserIDs: mentioned}); err != nil {
+				return err
+			}
+		} else if wasPublished {
+			if err := service.events.Publish(tx, &CommentDeletedEvent{CommentID: commentID, ReviewID: comment.ReviewID}); err != nil {
+				return err
+			}
+		}
+
 		if err := tx.Model(&entity.Comment{}).Preload("User", func(tx *gorm.DB) *gorm.DB {
 			return tx.Select("id, username")
 		}).Take(&comment).Error; err != nil {
@@ -102,20 +194,43 @@ func (service *commentServiceImpl) Update(c *gin.Context, commentUpdateReq *requ
 
 	logger.Info("comment updated successfully", zap.Uint("commentID", commentID))
 
-	return service.toCommentResponse(&comment), nil
+	mentions, err := loadMentions(db, entity.MentionOwnerComment, []uint{commentID})
+	if err != nil {
+		return nil, err
+	}
+
+	reacted, err := userCommentReactions(db, userID, []uint{commentID})
+	if err != nil {
+		return nil, err
+	}
+
+	return toCommentResponse(&comment, mentions[commentID], reacted[commentID]), nil
 }
 
 func (service *commentServiceImpl) Delete(c *gin.Context, userID, commentID uint) error {
 	db, logger := helper.GetDBAndLogger(c)
 
-	result := db.Where("id = ?", commentID).Where("user_id = ?", userID).Delete(&entity.Comment{})
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var comment entity.Comment
+		if err := tx.Select("id", "review_id", "status").Where("user_id = ?", userID).Take(&comment, commentID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return exceptions.NewCustomError(http.StatusNotFound, "Comment not found")
+			}
+			return err
+		}
 
-	if result.Error != nil {
-		return result.Error
-	}
+		if err := tx.Delete(&comment).Error; err != nil {
+			return err
+		}
 
-	if result.RowsAffected == 0 {
-		return exceptions.NewCustomError(http.StatusNotFound, "Comment not found")
+		if comment.Status != entity.StatusPublished {
+			return nil
+		}
+
+		return service.events.Publish(tx, &CommentDeletedEvent{CommentID: commentID, ReviewID: comment.ReviewID})
+	})
+	if err != nil {
+		return err
 	}
 
 	logger.Info("Comment deleted successfully", zap.Uint("commentID", commentID))
@@ -126,8 +241,16 @@ func (service *commentServiceImpl) Delete(c *gin.Context, userID, commentID uint
 func (service *commentServiceImpl) FindByReviewId(c *gin.Context, reviewID uint) (*[]response.CommentResponse, error) {
 	db, logger := helper.GetDBAndLogger(c)
 
+	var review entity.Review
+	if err := visibleContent(c, db.Select("id"), "reviews").Take(&review, reviewID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, exceptions.NewCustomError(http.StatusNotFound, "Review not found")
+		}
+		return nil, err
+	}
+
 	var comments []entity.Comment
-	if err := db.Model(&entity.Comment{}).
+	if err := visibleContent(c, db.Model(&entity.Comment{}), "comments").
 		Preload("User", func(tx *gorm.DB) *gorm.DB {
 			return tx.Select("id, username")
 		}).
@@ -142,9 +265,24 @@ func (service *commentServiceImpl) FindByReviewId(c *gin.Context, reviewID uint)
 
 	logger.Info("Comments fetched successfully", zap.Uint("reviewID", reviewID), zap.Int("count", len(comments)))
 
+	commentIDs := make([]uint, len(comments))
+	for i, comment := range comments {
+		commentIDs[i] = comment.ID
+	}
+
+	mentions, err := loadMentions(db, entity.MentionOwnerComment, commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	reacted, err := userCommentReactions(db, viewerID(c), commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	var commentResponses []response.CommentResponse
 	for _, comment := range comments {
-		commentResponses = append(commentResponses, *service.toCommentResponse(&comment))
+		commentResponses = append(commentResponses, *toCommentResponse(&comment, mentions[comment.ID], reacted[comment.ID]))
 	}
 
 	return &commentResponses, nil
@@ -155,6 +293,7 @@ func (service *commentServiceImpl) toCommentEntity(req any) *entity.Comment {
 	case *request.CommentCreateRequest:
 		return &entity.Comment{
 			ReviewID: v.ReviewID,
+			ParentID: v.ParentID,
 			Content:  v.Content,
 		}
 	case *request.CommentUpdateRequest:
@@ -166,15 +305,39 @@ func (service *commentServiceImpl) toCommentEntity(req any) *entity.Comment {
 	}
 }
 
-func (service *commentServiceImpl) toCommentResponse(comment *entity.Comment) *response.CommentResponse {
+// loadCommentResponse fetches a comment as anyone sees it, without the
+// reactions of a current user.
+func loadCommentResponse(db *gorm.DB, commentID uint) (*response.CommentResponse, error) {
+	var comment entity.Comment
+	if err := db.Preload("User", func(tx *gorm.DB) *gorm.DB {
+		return tx.Select("id, username")
+	}).Take(&comment, commentID).Error; err != nil {
+		return nil, err
+	}
+
+	mentions, err := loadMentions(db, entity.MentionOwnerComment, []uint{commentID})
+	if err != nil {
+		return nil, err
+	}
+
+	return toCommentResponse(&comment, mentions[commentID], nil), nil
+}
+
+// toCommentResponse includes the mentions and the reaction counts, reacted
+// holds the emojis of the current user.
+func toCommentResponse(comment *entity.Comment, mentions []response.MentionResponse, reacted map[string]bool) *response.CommentResponse {
 	return &response.CommentResponse{
 		ID:       comment.ID,
 		ReviewID: comment.ReviewID,
+		ParentID: comment.ParentID,
 		User: response.CommentUserResponse{
 			ID:       comment.User.ID,
 			Username: comment.User.Username,
 		},
 		Content:   comment.Content,
+		Status:    comment.Status,
+		Mentions:  append([]response.MentionResponse{}, mentions...),
+		Reactions: toCommentReactionResponses(comment.Reactions, reacted),
 		CreatedAt: comment.CreatedAt,
 		UpdatedAt: comment.UpdatedAt,
 	}
@@ -0,0 +1,369 @@
+package services
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"gorm.io/gorm"
+)
+
+// What a content rule does with an item, ordered from the mildest.
+const (
+	FilterAllow  = "allow"
+	FilterHold   = "hold"
+	FilterReject = "reject"
+)
+
+var filterSeverity = map[string]int{FilterAllow: 0, FilterHold: 1, FilterReject: 2}
+
+// ContentItem is a review or a comment about to be saved. ID is 0 for new
+// items and Text holds every user written field.
+type ContentItem struct {
+	Type   string
+	ID     uint
+	UserID uint
+	Text   string
+}
+
+// ContentRuleResult is the verdict of one rule that did not allow an item.
+type ContentRuleResult struct {
+	Rule   string `json:"rule"`
+	Action string `json:"action"`
+	Reason string `json:"reason"`
+}
+
+// ContentDecision is the strictest action of the rules and the rules that
+// asked for it, it is stored with the item.
+type ContentDecision struct {
+	Action string              `json:"action"`
+	Rules  []ContentRuleResult `json:"rules"`
+}
+
+// ContentRule screens an item and returns nil when it allows it.
+type ContentRule interface {
+	Check(*gorm.DB, *ContentItem) (*ContentRuleResult, error)
+}
+
+type ContentFilter interface {
+	Screen(*gorm.DB, *ContentItem) (*ContentDecision, error)
+}
+
+type contentFilterImpl struct {
+	rules []ContentRule
+}
+
+func NewContentFilter(rules ...ContentRule) ContentFilter {
+	return &contentFilterImpl{rules}
+}
+
+// Screen runs every rule. A rejected item is returned as a 422 error, the
+// decision of a held or allowed item is for the caller to store.
+func (filter *contentFilterImpl) Screen(tx *gorm.DB, item *ContentItem) (*ContentDecision, error) {
+	decision := ContentDecision{Action: FilterAllow, Rules: []ContentRuleResult{}}
+
+	for _, rule := range filter.rules {
+		result, err := rule.Check(tx, item)
+		if err != nil {
+			return nil, err
+		}
+
+		if result == nil || result.Action == FilterAllow {
+			continue
+		}
+
+		decision.Rules = append(decision.Rules, *result)
+
+		if filterSeverity[result.Action] > filterSeverity[decision.Action] {
+			decision.Action = result.Action
+		}
+	}
+
+	if decision.Action == FilterReject {
+		for _, result := range decision.Rules {
+			if result.Action == FilterReject {
+				return nil, exceptions.NewCustomError(http.StatusUnprocessableEntity, "Rejected by the content filter: "+result.Reason)
+			}
+		}
+	}
+
+	return &decision, nil
+}
+
+// screenedContent is what the content filter stores with an item.
+type screenedContent struct {
+	Hash      string
+	Screening *string
+	Held      bool
+}
+
+func screenContent(tx *gorm.DB, filter ContentFilter, item *ContentItem) (*screenedContent, error) {
+	decision, err := filter.Screen(tx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(decision)
+	if err != nil {
+		return nil, err
+	}
+	screening := string(raw)
+
+	return &screenedContent{
+		Hash:      contentHash(item.Text),
+		Screening: &screening,
+		Held:      decision.Action == FilterHold,
+	}, nil
+}
+
+// DefaultContentRules builds the rules from the CONTENT_* environment
+// variables, see .env.example.
+func DefaultContentRules() []ContentRule {
+	bannedWords := strings.Split(helper.GetEnv("CONTENT_BANNED_WORDS", ""), ",")
+
+	if path := helper.GetEnv("CONTENT_BANNED_WORDS_FILE", ""); path != "" {
+		words, err := readWordList(path)
+		helper.PanicIfError(err)
+		bannedWords = append(bannedWords, words...)
+	}
+
+	return []ContentRule{
+		NewBannedWordsRule(bannedWords, filterAction("CONTENT_BANNED_WORDS_ACTION", FilterReject)),
+		NewLinkLimitRule(helper.GetEnvInt("CONTENT_MAX_LINKS", 2), filterAction("CONTENT_LINKS_ACTION", FilterHold)),
+		NewDuplicateContentRule(
+			time.Duration(helper.GetEnvInt("CONTENT_DUPLICATE_WINDOW_HOURS", 24))*time.Hour,
+			helper.GetEnvInt("CONTENT_DUPLICATE_MIN_LENGTH", 20),
+			helper.GetEnvInt("CONTENT_DUPLICATE_LIMIT", 2),
+			filterAction("CONTENT_DUPLICATE_ACTION", FilterHold),
+		),
+		NewVelocityRule(
+			time.Duration(helper.GetEnvInt("CONTENT_VELOCITY_ACCOUNT_DAYS", 7))*24*time.Hour,
+			time.Hour,
+			helper.GetEnvInt("CONTENT_VELOCITY_LIMIT", 5),
+			filterAction("CONTENT_VELOCITY_ACTION", FilterHold),
+		),
+	}
+}
+
+func filterAction(key, defaultValue string) string {
+	action := helper.GetEnv(key, defaultValue)
+	if _, ok := filterSeverity[action]; !ok {
+		panic(fmt.Sprintf("%s must be allow, hold or reject", key))
+	}
+	return action
+}
+
+func readWordList(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var words []string
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if word := strings.TrimSpace(scanner.Text()); word != "" && !strings.HasPrefix(word, "#") {
+			words = append(words, word)
+		}
+	}
+
+	return words, scanner.Err()
+}
+
+var leetspeak = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "8", "b", "9", "g", "@", "a", "$", "s", "!", "i", "|", "i", "+", "t")
+
+// normalizeContent lowercases the text, reads leetspeak digits and symbols as
+// letters, drops the punctuation used to split a word ("s.p.a.m") and
+// separates the words with single spaces.
+func normalizeContent(text string) string {
+	runes := []rune(leetspeak.Replace(strings.ToLower(text)))
+
+	var normalized strings.Builder
+	for i, r := range runes {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			normalized.WriteRune(r)
+		case strings.ContainsRune(".-_*'", r) && i > 0 && i < len(runes)-1 && unicode.IsLetter(runes[i-1]) && unicode.IsLetter(runes[i+1]):
+			// inside a word, dropped
+		default:
+			normalized.WriteRune(' ')
+		}
+	}
+
+	return strings.Join(strings.Fields(normalized.String()), " ")
+}
+
+// contentHash identifies the text regardless of case, spacing and
+// punctuation.
+func contentHash(text string) string {
+	sum := sha256.Sum256([]byte(normalizeContent(text)))
+	return hex.EncodeToString(sum[:])
+}
+
+type bannedWordsRule struct {
+	patterns map[string]*regexp.Regexp
+	action   string
+}
+
+// NewBannedWordsRule matches whole words after normalizeContent, a letter
+// may be repeated ("spaaam").
+func NewBannedWordsRule(words []string, action string) ContentRule {
+	patterns := map[string]*regexp.Regexp{}
+
+	for _, word := range words {
+		normalized := normalizeContent(word)
+		if normalized == "" {
+			continue
+		}
+
+		var pattern strings.Builder
+		pattern.WriteString(`(?:^| )`)
+		for _, r := range normalized {
+			if r == ' ' {
+				pattern.WriteString(` +`)
+				continue
+			}
+			pattern.WriteString(regexp.QuoteMeta(string(r)) + "+")
+		}
+		pattern.WriteString(`(?: |$)`)
+
+		patterns[normalized] = regexp.MustCompile(pattern.String())
+	}
+
+	return &bannedWordsRule{patterns, action}
+}
+
+func (rule *bannedWordsRule) Check(tx *gorm.DB, item *ContentItem) (*ContentRuleResult, error) {
+	text := normalizeContent(item.Text)
+
+	for word, pattern := range rule.patterns {
+		if pattern.MatchString(text) {
+			return &ContentRuleResult{Rule: "banned_words", Action: rule.action, Reason: fmt.Sprintf("contains the banned word %q", word)}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+type linkLimitRule struct {
+	max    int
+	action string
+}
+
+func NewLinkLimitRule(max int, action string) ContentRule {
+	return &linkLimitRule{max, action}
+}
+
+func (rule *linkLimitRule) Check(tx *gorm.DB, item *ContentItem) (*ContentRuleResult, error) {
+	if links := len(linkPattern.FindAllString(item.Text, -1)); links > rule.max {
+		return &ContentRuleResult{Rule: "links", Action: rule.action, Reason: fmt.Sprintf("contains %d links, at most %d are allowed", links, rule.max)}, nil
+	}
+
+	return nil, nil
+}
+
+type duplicateContentRule struct {
+	window    time.Duration
+	minLength int
+	limit     int
+	action    string
+}
+
+// NewDuplicateContentRule flags a text once it was already posted limit
+// times within the window, by anyone. Texts shorter than minLength, like
+// "Nice car!", are not compared.
+func NewDuplicateContentRule(window time.Duration, minLength, limit int, action string) ContentRule {
+	return &duplicateContentRule{window, minLength, limit, action}
+}
+
+func (rule *duplicateContentRule) Check(tx *gorm.DB, item *ContentItem) (*ContentRuleResult, error) {
+	if len(normalizeContent(item.Text)) < rule.minLength {
+		return nil, nil
+	}
+
+	hash := contentHash(item.Text)
+	since := time.Now().Add(-rule.window)
+
+	var copies int64
+	for _, table := range []string{entity.ReportTargetReview, entity.ReportTargetComment} {
+		var count int64
+
+		query := tx.Table(table).Where("content_hash = ? AND created_at > ? AND deleted_at IS NULL", hash, since)
+		if table == item.Type {
+			query = query.Where("id <> ?", item.ID)
+		}
+
+		if err := query.Count(&count).Error; err != nil {
+			return nil, err
+		}
+		copies += count
+	}
+
+	if copies >= int64(rule.limit) {
+		return &ContentRuleResult{Rule: "duplicate", Action: rule.action, Reason: fmt.Sprintf("was posted %d times in the last %.0f hours", copies, rule.window.Hours())}, nil
+	}
+
+	return nil, nil
+}
+
+type velocityRule struct {
+	accountAge time.Duration
+	window     time.Duration
+	limit      int
+	action     string
+}
+
+// NewVelocityRule flags new items of accounts younger than accountAge that
+// already posted limit reviews and comments within the window. Edits and
+// moderators are not checked.
+func NewVelocityRule(accountAge, window time.Duration, limit int, action string) ContentRule {
+	return &velocityRule{accountAge, window, limit, action}
+}
+
+func (rule *velocityRule) Check(tx *gorm.DB, item *ContentItem) (*ContentRuleResult, error) {
+	if item.ID != 0 {
+		return nil, nil
+	}
+
+	var user entity.User
+	if err := tx.Select("id", "role", "created_at").Take(&user, item.UserID).Error; err != nil {
+		return nil, err
+	}
+
+	if utils.IsModerator(user.Role) || user.CreatedAt.Before(time.Now().Add(-rule.accountAge)) {
+		return nil, nil
+	}
+
+	since := time.Now().Add(-rule.window)
+
+	var posts int64
+	for _, table := range []string{entity.ReportTargetReview, entity.ReportTargetComment} {
+		var count int64
+		if err := tx.Table(table).Where("user_id = ? AND created_at > ?", item.UserID, since).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		posts += count
+	}
+
+	if posts >= int64(rule.limit) {
+		return &ContentRuleResult{Rule: "velocity", Action: rule.action, Reason: fmt.Sprintf("a new account posted %d times in the last %.0f minutes", posts, rule.window.Minutes())}, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"sync"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"gorm.io/gorm"
+)
+
+// Domain event names.
+const (
+	EventCommentPublished    = "comment.published"
+	EventReviewPublished     = "review.published"
+	EventUserMentioned       = "user.mentioned"
+	EventContentModerated    = "content.moderated"
+	EventCommentUpdated      = "comment.updated"
+	EventCommentDeleted      = "comment.deleted"
+	EventNotificationCreated = "notification.created"
+	EventCarCreated          = "car.created"
+	EventCarUpdated          = "car.updated"
+	EventCarDeleted          = "car.deleted"
+	EventCarSpecsChanged     = "car.specs_changed"
+	EventBrandCreated        = "brand.created"
+	EventBrandUpdated        = "brand.updated"
+	EventBrandDeleted        = "brand.deleted"
+)
+
+// DomainEvent is something that happened in a service that other parts of
+// the application react to, like notifications.
+type DomainEvent interface {
+	EventName() string
+}
+
+// CommentPublishedEvent is emitted when a comment becomes visible, on
+// creation or when a moderator approves it.
+type CommentPublishedEvent struct {
+	CommentID uint
+	ReviewID  uint
+	ParentID  *uint
+	AuthorID  uint
+}
+
+func (event *CommentPublishedEvent) EventName() string { return EventCommentPublished }
+
+// CommentUpdatedEvent is emitted when the author edits a published comment
+// that stays published.
+type CommentUpdatedEvent struct {
+	CommentID uint
+	ReviewID  uint
+}
+
+func (event *CommentUpdatedEvent) EventName() string { return EventCommentUpdated }
+
+// CommentDeletedEvent is emitted when a published comment stops being
+// visible: deleted by its author, held for moderation after an edit or
+// hidden after too many reports.
+type CommentDeletedEvent struct {
+	CommentID uint
+	ReviewID  uint
+}
+
+func (event *CommentDeletedEvent) EventName() string { return EventCommentDeleted }
+
+// ReviewPublishedEvent is emitted when a review becomes visible, on creation
+// or when a moderator approves it.
+type ReviewPublishedEvent struct {
+	ReviewID uint
+	CarID    uint
+	AuthorID uint
+}
+
+func (event *ReviewPublishedEvent) EventName() string { return EventReviewPublished }
+
+// UserMentionedEvent is emitted for the users mentioned for the first time in
+// a published review or comment.
+type UserMentionedEvent struct {
+	OwnerType string
+	OwnerID   uint
+	AuthorID  uint
+	UserIDs   []uint
+}
+
+func (event *UserMentionedEvent) EventName() string { return EventUserMentioned }
+
+// ContentModeratedEvent is emitted when a moderator decides on a review or a
+// comment.
+type ContentModeratedEvent struct {
+	TargetType     string
+	TargetID       uint
+	AuthorID       uint
+	PreviousStatus string
+	Status         string
+	Note           string
+}
+
+func (event *ContentModeratedEvent) EventName() string { return EventContentModerated }
+
+// NotificationCreatedEvent is emitted with the notifications stored for one
+// domain event.
+type NotificationCreatedEvent struct {
+	NotificationIDs []uint
+}
+
+func (event *NotificationCreatedEvent) EventName() string { return EventNotificationCreated }
+
+// CarChangedEvent is emitted when a car is created, updated or deleted, Name
+// is EventCarCreated, EventCarUpdated or EventCarDeleted. Car is the car
+// after the change, or before it for a deletion.
+type CarChangedEvent struct {
+	Name string
+	Car  *response.CarResponse
+}
+
+func (event *CarChangedEvent) EventName() string { return event.Name }
+
+// CarSpecsChangedEvent is emitted when an update changes the specifications
+// of a car, Specs names the changed ones.
+type CarSpecsChangedEvent struct {
+	CarID    uint
+	BrandID  uint
+	EditorID *uint
+	Specs    []string
+}
+
+func (event *CarSpecsChangedEvent) EventName() string { return EventCarSpecsChanged }
+
+// BrandChangedEvent is emitted when a brand is created, updated or deleted,
+// Name is EventBrandCreated, EventBrandUpdated or EventBrandDeleted. Brand is
+// the brand after the change, or before it for a deletion.
+type BrandChangedEvent struct {
+	Name  string
+	Brand *response.BrandResponse
+}
+
+func (event *BrandChangedEvent) EventName() string { return event.Name }
+
+// EventHandler reacts to an event inside the transaction that emitted it, an
+// error rolls the transaction back.
+type EventHandler func(tx *gorm.DB, event DomainEvent) error
+
+type EventBus interface {
+	Subscribe(string, EventHandler)
+	Publish(*gorm.DB, ...DomainEvent) error
+}
+
+type eventBusImpl struct {
+	mutex    sync.RWMutex
+	handlers map[string][]EventHandler
+}
+
+// NewEventBus dispatches events synchronously to the handlers subscribed to
+// their name, in subscription order.
+func NewEventBus() EventBus {
+	return &eventBusImpl{handlers: map[string][]EventHandler{}}
+}
+
+func (bus *eventBusImpl) Subscribe(name string, handler EventHandler) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	bus.handlers[name] = append(bus.handlers[name], handler)
+}
+
+func (bus *eventBusImpl) Publish(tx *gorm.DB, events ...DomainEvent) error {
+	bus.mutex.RLock()
+	defer bus.mutex.RUnlock()
+
+	for _, event := range events {
+		for _, handler := range bus.handlers[event.EventName()] {
+			if err := handler(tx, event); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"gorm.io/gorm"
+)
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+type ExportService interface {
+	ExportCars(*gin.Context, *request.CarQueryRequest, string, io.Writer) error
+	ExportReviews(*gin.Context, *request.ReviewQueryRequest, string, io.Writer) error
+	ExportComments(*gin.Context, *request.CommentQueryRequest, string, io.Writer) error
+}
+
+type exportServiceImpl struct{}
+
+func NewExportService() ExportService {
+	return &exportServiceImpl{}
+}
+
+func (service *exportServiceImpl) ExportCars(c *gin.Context, carQueryReq *request.CarQueryRequest, format string, w io.Writer) error {
+	db, _ := helper.GetDBAndLogger(c)
+
+	query := filterCars(db.Table("cars").Where("cars.deleted_at IS NULL"), carQueryReq).
+		Select(`cars.id, cars.brand_id, brands.name AS brand_name, cars.name, cars.model, cars.year, cars.image_url, cars.media_id,
+			car_specifications.width, car_specifications.height, car_specifications.length, car_specifications.engine,
+			car_specifications.torque, car_specifications.transmission, car_specifications.acceleration,
+			car_specifications.horse_power, car_specifications.breaking_system_front, car_specifications.breaking_system_back,
+			car_specifications.fuel`).
+		Joins("LEFT JOIN brands ON brands.id = cars.brand_id").
+		Joins("LEFT JOIN car_specifications ON car_specifications.car_id = cars.id").
+		Order("cars.id")
+
+	return streamExport[response.CarExportResponse](db, query, format, w)
+}
+
+func (service *exportServiceImpl) ExportReviews(c *gin.Context, reviewQueryReq *request.ReviewQueryRequest, format string, w io.Writer) error {
+	db, _ := helper.GetDBAndLogger(c)
+
+	query := filterReviews(db.Table("reviews").Where("reviews.deleted_at IS NULL AND reviews.status = ?", entity.StatusPublished), reviewQueryReq).
+		Select("reviews.id, reviews.car_id, reviews.user_id, users.username, reviews.title, reviews.content, reviews.image_url, reviews.media_id, reviews.created_at, reviews.updated_at").
+		Joins("LEFT JOIN users ON users.id = reviews.user_id").
+		Order("reviews.id")
+
+	return streamExport[response.ReviewExportResponse](db, query, format, w)
+}
+
+func (service *exportServiceImpl) ExportComments(c *gin.Context, commentQueryReq *request.CommentQueryRequest, format string, w io.Writer) error {
+	db, _ := helper.GetDBAndLogger(c)
+
+	query := db.Table("comments").
+		Where("comments.deleted_at IS NULL AND comments.status = ?", entity.StatusPublished).
+		Where("reviews.deleted_at IS NULL AND reviews.status = ?", entity.StatusPublished).
+		Select("comments.id, comments.review_id, comments.user_id, users.username, comments.content, comments.created_at, comments.updated_at").
+		Joins("JOIN reviews ON reviews.id = comments.review_id").
+		Joins("LEFT JOIN users ON users.id = comments.user_id").
+		Order("comments.id")
+
+	if commentQueryReq.ReviewID != nil {
+		query = query.Where("comments.review_id = ?", *commentQueryReq.ReviewID)
+	}
+
+	if commentQueryReq.UserID != nil {
+		query = query.Where("comments.user_id = ?", *commentQueryReq.UserID)
+	}
+
+	return streamExport[response.CommentExportResponse](db, query, format, w)
+}
+
+// streamExport reads the rows one at a time with a database cursor and
+// writes each one as soon as it is scanned, so memory use does not depend on
+// the number of rows. T is a flat response struct whose json tags name the
+// CSV columns.
+func streamExport[T any](db *gorm.DB, query *gorm.DB, format string, w io.Writer) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var encoder exportEncoder
+	switch format {
+	case ExportFormatNDJSON:
+		encoder = &ndjsonExportEncoder{encoder: json.NewEncoder(w)}
+	default:
+		encoder = &csvExportEncoder{writer: csv.NewWriter(w)}
+	}
+
+	flusher, _ := w.(http.Flusher)
+
+	for count := 1; rows.Next(); count++ {
+		var record T
+		if err := db.ScanRows(rows, &record); err != nil {
+			return err
+		}
+
+		if err := encoder.Encode(&record); err != nil {
+			return err
+		}
+
+		if count%500 == 0 {
+			if err := encoder.Flush(); err != nil {
+				return err
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return encoder.Flush()
+}
+
+type exportEncoder interface {
+	Encode(record any) error
+	Flush() error
+}
+
+type ndjsonExportEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonExportEncoder) Encode(record any) error {
+	return e.encoder.Encode(record)
+}
+
+func (e *ndjsonExportEncoder) Flush() error {
+	return nil
+}
+
+type csvExportEncoder struct {
+	writer      *csv.Writer
+	wroteHeader bool
+}
+
+func (e *csvExportEncoder) Encode(record any) error {
+	value := reflect.Indirect(reflect.ValueOf(record))
+
+	if !e.wroteHeader {
+		header := make([]string, value.NumField())
+		for i := range header {
+			header[i], _, _ = strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+		}
+
+		if err := e.writer.Write(header); err != nil {
+			return err
+		}
+		e.wroteHeader = true
+	}
+
+	line := make([]string, value.NumField())
+	for i := range line {
+		field := reflect.Indirect(value.Field(i))
+		if !field.IsValid() {
+			// nil pointers are empty cells
+			continue
+		}
+
+		switch field := field.Interface().(type) {
+		case time.Time:
+			line[i] = field.Format(time.RFC3339)
+		case string:
+			line[i] = csvCell(field)
+		default:
+			line[i] = fmt.Sprint(field)
+		}
+	}
+
+	return e.writer.Write(line)
+}
+
+func (e *csvExportEncoder) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// csvCell quotes user text that a spreadsheet would otherwise run as a
+// formula.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
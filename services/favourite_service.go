@@ -1,22 +1,24 @@
 package services
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
 	"github.com/raihanmd/fp-superbootcamp-go/helper"
 	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
 	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
+// FavouriteService works on the default collection of a user, see
+// CollectionService for the named ones.
 type FavouriteService interface {
 	FavouriteCar(c *gin.Context, carID uint, userID uint) error
 	UnfavouriteCar(c *gin.Context, carID uint, userID uint) error
-	GetUserFavourites(c *gin.Context, userID uint) (*[]response.FavouriteResponse, error)
+	GetUserFavourites(c *gin.Context, userID uint, paging *web.PaginationRequest) (*[]response.FavouriteResponse, *web.Metadata, error)
 }
 
 type favouriteServiceImpl struct{}
@@ -28,22 +30,24 @@ func NewFavouriteService() FavouriteService {
 func (service *favouriteServiceImpl) FavouriteCar(c *gin.Context, carID uint, userID uint) error {
 	db, logger := helper.GetDBAndLogger(c)
 
-	favourite := entity.Favourite{
-		UserID: userID,
-		CarID:  carID,
-	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		collection, err := defaultCollection(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		added, err := addCollectionItem(tx, collection, carID, "")
+		if err != nil {
+			return err
+		}
 
-	if err := db.Create(&favourite).Error; err != nil {
-		if pgErr, ok := err.(*pgconn.PgError); ok {
-			// unique violation
-			if pgErr.Code == "23505" {
-				return exceptions.NewCustomError(http.StatusBadRequest, "You have favourited this car")
-			}
-			// violation foreign key car_id
-			if pgErr.Code == "23503" {
-				return exceptions.NewCustomError(http.StatusNotFound, "Car not found")
-			}
+		if !added {
+			return exceptions.NewCustomError(http.StatusBadRequest, "You have favourited this car")
 		}
+
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
@@ -55,32 +59,54 @@ func (service *favouriteServiceImpl) FavouriteCar(c *gin.Context, carID uint, us
 func (service *favouriteServiceImpl) UnfavouriteCar(c *gin.Context, carID uint, userID uint) error {
 	db, _ := helper.GetDBAndLogger(c)
 
-	result := db.Where("user_id = ? AND car_id = ?", userID, carID).Delete(&entity.Favourite{})
+	result := db.Where("car_id = ? AND collection_id IN (?)", carID,
+		db.Model(&entity.FavouriteCollection{}).Select("id").Where("user_id = ? AND is_default", userID)).
+		Delete(&entity.Favourite{})
 
 	if result.Error != nil {
 		return result.Error
 	}
 
 	if result.RowsAffected == 0 {
-		return errors.New("favourite not found")
+		return exceptions.NewCustomError(http.StatusNotFound, "Favourite not found")
 	}
 
 	return nil
 }
 
-func (service *favouriteServiceImpl) GetUserFavourites(c *gin.Context, userID uint) (*[]response.FavouriteResponse, error) {
+// GetUserFavourites lists the default collection of a user in its manual
+// order.
+func (service *favouriteServiceImpl) GetUserFavourites(c *gin.Context, userID uint, paging *web.PaginationRequest) (*[]response.FavouriteResponse, *web.Metadata, error) {
 	db, _ := helper.GetDBAndLogger(c)
 
-	var favouriteResponses []response.FavouriteResponse
+	query := db.Model(&entity.Favourite{}).
+		Joins("JOIN favourite_collections ON favourite_collections.id = favourites.collection_id AND favourite_collections.is_default").
+		Joins("JOIN cars ON cars.id = favourites.car_id AND cars.deleted_at IS NULL").
+		Joins("JOIN brands ON brands.id = cars.brand_id").
+		Where("favourites.user_id = ?", userID)
+
+	query.Count(&paging.TotalData)
 
-	if err := db.Model(&entity.Favourite{}).
+	offset := (paging.Page - 1) * paging.Limit
+
+	favouriteResponses := []response.FavouriteResponse{}
+
+	if err := query.
 		Select("cars.id as car_id, brands.name as brand, cars.model, cars.image_url").
-		Joins("left join cars on favourites.car_id = cars.id").
-		Joins("left join brands on cars.brand_id = brands.id").
-		Where("user_id = ?", userID).
-		Find(&favouriteResponses).Error; err != nil {
-		return nil, err
+		Order("favourites.position, favourites.created_at").
+		Limit(paging.Limit).Offset(offset).
+		Scan(&favouriteResponses).Error; err != nil {
+		return nil, nil, err
+	}
+
+	paging.TotalPages = int((paging.TotalData + int64(paging.Limit) - 1) / int64(paging.Limit))
+
+	metadata := web.Metadata{
+		Page:       &paging.Page,
+		Limit:      &paging.Limit,
+		TotalPages: &paging.TotalPages,
+		TotalData:  &paging.TotalData,
 	}
 
-	return &favouriteResponses, nil
+	return &favouriteResponses, &metadata, nil
 }
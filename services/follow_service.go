@@ -0,0 +1,253 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FollowService interface {
+	Follow(*gin.Context, string, uint, uint) error
+	Unfollow(*gin.Context, string, uint, uint) error
+	FindFollowers(*gin.Context, string, uint, *web.PaginationRequest) (*[]response.FollowResponse, *web.Metadata, error)
+	FindFollowing(*gin.Context, uint, string, *web.PaginationRequest) (*[]response.FollowResponse, *web.Metadata, error)
+}
+
+type followServiceImpl struct{}
+
+func NewFollowService() FollowService {
+	return &followServiceImpl{}
+}
+
+// followTargetNames is how the target types are named in messages.
+var followTargetNames = map[string]string{
+	entity.FollowTargetUser:  "user",
+	entity.FollowTargetBrand: "brand",
+	entity.FollowTargetCar:   "car",
+}
+
+// Follow makes the user follow a user, a brand or a car. Following a user
+// counts on both profiles.
+func (service *followServiceImpl) Follow(c *gin.Context, targetType string, targetID, userID uint) error {
+	db, logger := helper.GetDBAndLogger(c)
+
+	if targetType == entity.FollowTargetUser && targetID == userID {
+		return exceptions.NewCustomError(http.StatusBadRequest, "You cannot follow yourself")
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := findFollowTarget(tx, targetType, targetID); err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.Follow{FollowerID: userID, TargetType: targetType, TargetID: targetID})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return exceptions.NewCustomError(http.StatusConflict, "You already follow this "+followTargetNames[targetType])
+		}
+
+		if targetType == entity.FollowTargetUser {
+			return updateFollowCounts(tx, userID, targetID, 1)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("followed successfully", zap.String("targetType", targetType), zap.Uint("targetID", targetID), zap.Uint("userID", userID))
+
+	return nil
+}
+
+func (service *followServiceImpl) Unfollow(c *gin.Context, targetType string, targetID, userID uint) error {
+	db, logger := helper.GetDBAndLogger(c)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("follower_id = ? AND target_type = ? AND target_id = ?", userID, targetType, targetID).Delete(&entity.Follow{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return exceptions.NewCustomError(http.StatusNotFound, "You do not follow this "+followTargetNames[targetType])
+		}
+
+		if targetType == entity.FollowTargetUser {
+			return updateFollowCounts(tx, userID, targetID, -1)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("unfollowed successfully", zap.String("targetType", targetType), zap.Uint("targetID", targetID), zap.Uint("userID", userID))
+
+	return nil
+}
+
+// FindFollowers lists the users following a user, a brand or a car, latest
+// first.
+func (service *followServiceImpl) FindFollowers(c *gin.Context, targetType string, targetID uint, paging *web.PaginationRequest) (*[]response.FollowResponse, *web.Metadata, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	if err := findFollowTarget(db, targetType, targetID); err != nil {
+		return nil, nil, err
+	}
+
+	query := db.Model(&entity.Follow{}).
+		Joins("JOIN users ON users.id = follows.follower_id").
+		Where("follows.target_type = ? AND follows.target_id = ?", targetType, targetID)
+
+	followers := []response.FollowResponse{}
+	metadata, err := paginateFollows(query, "users.id, users.username AS name", entity.FollowTargetUser, paging, &followers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &followers, metadata, nil
+}
+
+// FindFollowing lists the users, brands or cars a user follows, latest
+// first. Deleted brands and cars are left out until they are restored.
+func (service *followServiceImpl) FindFollowing(c *gin.Context, userID uint, targetType string, paging *web.PaginationRequest) (*[]response.FollowResponse, *web.Metadata, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	if err := findFollowTarget(db, entity.FollowTargetUser, userID); err != nil {
+		return nil, nil, err
+	}
+
+	query := db.Model(&entity.Follow{}).Where("follows.follower_id = ? AND follows.target_type = ?", userID, targetType)
+
+	var columns string
+	switch targetType {
+	case entity.FollowTargetBrand:
+		query = query.Joins("JOIN brands ON brands.id = follows.target_id AND brands.deleted_at IS NULL")
+		columns = "brands.id, brands.name AS name"
+	case entity.FollowTargetCar:
+		query = query.Joins("JOIN cars ON cars.id = follows.target_id AND cars.deleted_at IS NULL")
+		columns = "cars.id, cars.name || ' ' || cars.model AS name"
+	default:
+		query = query.Joins("JOIN users ON users.id = follows.target_id")
+		columns = "users.id, users.username AS name"
+	}
+
+	following := []response.FollowResponse{}
+	metadata, err := paginateFollows(query, columns, targetType, paging, &following)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &following, metadata, nil
+}
+
+func paginateFollows(query *gorm.DB, columns, targetType string, paging *web.PaginationRequest, follows *[]response.FollowResponse) (*web.Metadata, error) {
+	query.Count(&paging.TotalData)
+
+	offset := (paging.Page - 1) * paging.Limit
+
+	if err := query.Select(columns + ", follows.created_at AS followed_at").
+		Order("follows.created_at desc").
+		Limit(paging.Limit).Offset(offset).
+		Scan(follows).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range *follows {
+		(*follows)[i].Type = targetType
+	}
+
+	paging.TotalPages = int((paging.TotalData + int64(paging.Limit) - 1) / int64(paging.Limit))
+
+	return &web.Metadata{
+		Page:       &paging.Page,
+		Limit:      &paging.Limit,
+		TotalPages: &paging.TotalPages,
+		TotalData:  &paging.TotalData,
+	}, nil
+}
+
+// findFollowTarget checks that the user, or the brand or car that is not
+// deleted, exists.
+func findFollowTarget(db *gorm.DB, targetType string, targetID uint) error {
+	var target any
+	var notFound string
+
+	switch targetType {
+	case entity.FollowTargetUser:
+		target, notFound = &entity.User{}, "User not found"
+	case entity.FollowTargetBrand:
+		target, notFound = &entity.Brand{}, "Brand not found"
+	case entity.FollowTargetCar:
+		target, notFound = &entity.Car{}, "Car not found"
+	default:
+		return exceptions.NewCustomError(http.StatusBadRequest, "Unknown follow target")
+	}
+
+	if err := db.Select("id").Take(target, targetID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exceptions.NewCustomError(http.StatusNotFound, notFound)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// updateFollowCounts moves the following count of the follower and the
+// follower count of the followed user by delta, in ID order so that two
+// users following each other at once do not deadlock.
+func updateFollowCounts(tx *gorm.DB, followerID, followedID uint, delta int) error {
+	counts := []struct {
+		userID uint
+		column string
+	}{
+		{followerID, "following_count"},
+		{followedID, "follower_count"},
+	}
+
+	sort.Slice(counts, func(i, j int) bool { return counts[i].userID < counts[j].userID })
+
+	for _, count := range counts {
+		if err := tx.Model(&entity.User{}).Where("id = ?", count.userID).
+			UpdateColumn(count.column, gorm.Expr(count.column+" + ?", delta)).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteUserFollows removes what a deleted user follows and their followers,
+// and updates the counts of the users on the other side.
+func deleteUserFollows(tx *gorm.DB, userID uint) error {
+	if err := tx.Model(&entity.User{}).
+		Where("id IN (?)", tx.Model(&entity.Follow{}).Select("target_id").Where("follower_id = ? AND target_type = ?", userID, entity.FollowTargetUser)).
+		UpdateColumn("follower_count", gorm.Expr("follower_count - 1")).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&entity.User{}).
+		Where("id IN (?)", tx.Model(&entity.Follow{}).Select("follower_id").Where("target_type = ? AND target_id = ?", entity.FollowTargetUser, userID)).
+		UpdateColumn("following_count", gorm.Expr("following_count - 1")).Error; err != nil {
+		return err
+	}
+
+	return tx.Where("follower_id = ? OR (target_type = ? AND target_id = ?)", userID, entity.FollowTargetUser, userID).Delete(&entity.Follow{}).Error
+}
@@ -0,0 +1,381 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GalleryService manages the galleries of cars and reviews, the owner type
+// is entity.GalleryOwnerCar or entity.GalleryOwnerReview. Car galleries are
+// edited by admins, review galleries by the author of the review.
+type GalleryService interface {
+	FindAll(*gin.Context, string, uint) (*[]response.GalleryImageResponse, error)
+	Add(*gin.Context, string, uint, uint, *request.GalleryImageCreateRequest) (*[]response.GalleryImageResponse, error)
+	Update(*gin.Context, string, uint, uint, uint, *request.GalleryImageUpdateRequest) (*[]response.GalleryImageResponse, error)
+	Reorder(*gin.Context, string, uint, uint, *request.GalleryReorderRequest) (*[]response.GalleryImageResponse, error)
+	Remove(*gin.Context, string, uint, uint, uint) (*[]response.GalleryImageResponse, error)
+}
+
+type galleryServiceImpl struct {
+	maxImages int
+}
+
+// NewGalleryService reads the gallery size limit from GALLERY_MAX_IMAGES.
+func NewGalleryService() GalleryService {
+	return &galleryServiceImpl{
+		maxImages: helper.GetEnvInt("GALLERY_MAX_IMAGES", 20),
+	}
+}
+
+func (service *galleryServiceImpl) FindAll(c *gin.Context, ownerType string, ownerID uint) (*[]response.GalleryImageResponse, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	if err := findGalleryOwner(c, db, ownerType, ownerID, nil); err != nil {
+		return nil, err
+	}
+
+	images, err := findGallery(db, ownerType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGalleryImageResponses(images), nil
+}
+
+func (service *galleryServiceImpl) Add(c *gin.Context, ownerType string, ownerID, userID uint, galleryImageReq *request.GalleryImageCreateRequest) (*[]response.GalleryImageResponse, error) {
+	return service.edit(c, ownerType, ownerID, userID, func(tx *gorm.DB, images []entity.GalleryImage) error {
+		if len(images) >= service.maxImages {
+			return exceptions.NewCustomError(http.StatusConflict, fmt.Sprintf("A gallery holds at most %d images", service.maxImages))
+		}
+
+		image := entity.GalleryImage{
+			OwnerType: ownerType,
+			OwnerID:   ownerID,
+			ImageUrl:  galleryImageReq.ImageUrl,
+			MediaID:   galleryImageReq.MediaID,
+			Caption:   galleryImageReq.Caption,
+			Position:  len(images),
+			IsCover:   galleryImageReq.IsCover,
+		}
+
+		if image.MediaID != nil {
+			// admins may use any media for cars, authors only their own
+			var mediaOwnerID *uint
+			if ownerType == entity.GalleryOwnerReview {
+				mediaOwnerID = &userID
+			}
+
+			imageURL, err := mediaImageURL(tx, *image.MediaID, mediaOwnerID)
+			if err != nil {
+				return err
+			}
+			image.ImageUrl = imageURL
+		}
+
+		if image.IsCover {
+			if err := clearGalleryCover(tx, ownerType, ownerID); err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(&image).Error
+	})
+}
+
+func (service *galleryServiceImpl) Update(c *gin.Context, ownerType string, ownerID, imageID, userID uint, galleryImageReq *request.GalleryImageUpdateRequest) (*[]response.GalleryImageResponse, error) {
+	return service.edit(c, ownerType, ownerID, userID, func(tx *gorm.DB, images []entity.GalleryImage) error {
+		if !galleryContains(images, imageID) {
+			return exceptions.NewCustomError(http.StatusNotFound, "Image not found")
+		}
+
+		if galleryImageReq.Caption != nil {
+			if err := tx.Model(&entity.GalleryImage{}).Where("id = ?", imageID).Update("caption", *galleryImageReq.Caption).Error; err != nil {
+				return err
+			}
+		}
+
+		// a gallery always keeps a cover, it is moved by choosing another one
+		if galleryImageReq.IsCover != nil && *galleryImageReq.IsCover {
+			if err := clearGalleryCover(tx, ownerType, ownerID); err != nil {
+				return err
+			}
+
+			return tx.Model(&entity.GalleryImage{}).Where("id = ?", imageID).Update("is_cover", true).Error
+		}
+
+		return nil
+	})
+}
+
+func (service *galleryServiceImpl) Reorder(c *gin.Context, ownerType string, ownerID, userID uint, galleryReorderReq *request.GalleryReorderRequest) (*[]response.GalleryImageResponse, error) {
+	return service.edit(c, ownerType, ownerID, userID, func(tx *gorm.DB, images []entity.GalleryImage) error {
+		seen := map[uint]bool{}
+		for _, imageID := range galleryReorderReq.ImageIDs {
+			if seen[imageID] || !galleryContains(images, imageID) {
+				return exceptions.NewCustomError(http.StatusBadRequest, "image_ids must list every image of the gallery once")
+			}
+			seen[imageID] = true
+		}
+
+		if len(seen) != len(images) {
+			return exceptions.NewCustomError(http.StatusBadRequest, "image_ids must list every image of the gallery once")
+		}
+
+		for position, imageID := range galleryReorderReq.ImageIDs {
+			if err := tx.Model(&entity.GalleryImage{}).Where("id = ?", imageID).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Remove deletes an image, when it was the cover the first remaining image
+// becomes the cover. The owner keeps its image_url when the gallery empties.
+func (service *galleryServiceImpl) Remove(c *gin.Context, ownerType string, ownerID, imageID, userID uint) (*[]response.GalleryImageResponse, error) {
+	return service.edit(c, ownerType, ownerID, userID, func(tx *gorm.DB, images []entity.GalleryImage) error {
+		if !galleryContains(images, imageID) {
+			return exceptions.NewCustomError(http.StatusNotFound, "Image not found")
+		}
+
+		return tx.Delete(&entity.GalleryImage{}, imageID).Error
+	})
+}
+
+// edit runs fn on the locked gallery, then fixes up positions and the cover
+// and returns the new gallery. Car gallery changes are audited.
+func (service *galleryServiceImpl) edit(c *gin.Context, ownerType string, ownerID, userID uint, fn func(*gorm.DB, []entity.GalleryImage) error) (*[]response.GalleryImageResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	_, role, err := utils.ExtractTokenClaims(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var after []entity.GalleryImage
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// the owner row lock serializes concurrent edits of the gallery
+		if err := findGalleryOwner(c, tx.Clauses(clause.Locking{Strength: "UPDATE"}), ownerType, ownerID, &galleryEditor{userID, role}); err != nil {
+			return err
+		}
+
+		before, err := findGallery(tx, ownerType, ownerID)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(tx, before); err != nil {
+			return err
+		}
+
+		if err := syncGallery(tx, ownerType, ownerID); err != nil {
+			return err
+		}
+
+		if after, err = findGallery(tx, ownerType, ownerID); err != nil {
+			return err
+		}
+
+		if ownerType != entity.GalleryOwnerCar {
+			return nil
+		}
+
+		return recordAudit(c, tx, &entity.AuditEvent{Action: AuditCarGalleryUpdate, TargetType: AuditTargetCar, TargetID: ownerID},
+			gin.H{"gallery": toGalleryImageResponses(before)}, gin.H{"gallery": toGalleryImageResponses(after)})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("gallery updated successfully", zap.String("ownerType", ownerType), zap.Uint("ownerID", ownerID), zap.Int("images", len(after)))
+
+	return toGalleryImageResponses(after), nil
+}
+
+type galleryEditor struct {
+	userID uint
+	role   string
+}
+
+// findGalleryOwner checks that the owner exists and can be seen by the current
+// user and, when editor is not nil, that the editor may change its gallery.
+func findGalleryOwner(c *gin.Context, tx *gorm.DB, ownerType string, ownerID uint, editor *galleryEditor) error {
+	switch ownerType {
+	case entity.GalleryOwnerCar:
+		if editor != nil && editor.role != entity.RoleAdmin {
+			return exceptions.NewCustomError(http.StatusForbidden, "Only admin can edit car galleries")
+		}
+
+		if err := tx.Select("id").Take(&entity.Car{}, ownerID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return exceptions.NewCustomError(http.StatusNotFound, "Car not found")
+			}
+			return err
+		}
+	case entity.GalleryOwnerReview:
+		var review entity.Review
+		if err := visibleContent(c, tx.Select("id", "user_id"), "reviews").Take(&review, ownerID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return exceptions.NewCustomError(http.StatusNotFound, "review not found")
+			}
+			return err
+		}
+
+		if editor != nil && review.UserID != editor.userID && editor.role != entity.RoleAdmin {
+			return exceptions.NewCustomError(http.StatusForbidden, "You can only edit the gallery of your own review")
+		}
+	default:
+		return exceptions.NewCustomError(http.StatusNotFound, "Gallery not found")
+	}
+
+	return nil
+}
+
+func findGallery(tx *gorm.DB, ownerType string, ownerID uint) ([]entity.GalleryImage, error) {
+	var images []entity.GalleryImage
+
+	err := tx.Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).Order("position, id").Find(&images).Error
+
+	return images, err
+}
+
+func orderedGallery(db *gorm.DB) *gorm.DB {
+	return db.Order("position, id")
+}
+
+// loadGalleries returns the galleries of several owners keyed by owner ID.
+func loadGalleries(db *gorm.DB, ownerType string, ownerIDs []uint) (map[uint][]response.GalleryImageResponse, error) {
+	galleries := map[uint][]response.GalleryImageResponse{}
+
+	if len(ownerIDs) == 0 {
+		return galleries, nil
+	}
+
+	var images []entity.GalleryImage
+	if err := orderedGallery(db).Where("owner_type = ? AND owner_id IN ?", ownerType, ownerIDs).Find(&images).Error; err != nil {
+		return nil, err
+	}
+
+	for _, image := range images {
+		galleries[image.OwnerID] = append(galleries[image.OwnerID], toGalleryImageResponse(&image))
+	}
+
+	return galleries, nil
+}
+
+func galleryContains(images []entity.GalleryImage, imageID uint) bool {
+	for _, image := range images {
+		if image.ID == imageID {
+			return true
+		}
+	}
+	return false
+}
+
+func clearGalleryCover(tx *gorm.DB, ownerType string, ownerID uint) error {
+	return tx.Model(&entity.GalleryImage{}).
+		Where("owner_type = ? AND owner_id = ? AND is_cover", ownerType, ownerID).
+		Update("is_cover", false).Error
+}
+
+// syncGallery numbers the images from 0, makes the first image the cover
+// when there is none and copies the cover to the owner's image_url and
+// media_id. The owner table is named like the owner type.
+func syncGallery(tx *gorm.DB, ownerType string, ownerID uint) error {
+	images, err := findGallery(tx, ownerType, ownerID)
+	if err != nil || len(images) == 0 {
+		return err
+	}
+
+	cover := -1
+	for i, image := range images {
+		if image.Position != i {
+			if err := tx.Model(&entity.GalleryImage{}).Where("id = ?", image.ID).Update("position", i).Error; err != nil {
+				return err
+			}
+		}
+
+		if image.IsCover {
+			cover = i
+		}
+	}
+
+	if cover == -1 {
+		cover = 0
+		if err := tx.Model(&entity.GalleryImage{}).Where("id = ?", images[cover].ID).Update("is_cover", true).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Table(ownerType).Where("id = ?", ownerID).Updates(map[string]any{
+		"image_url": images[cover].ImageUrl,
+		"media_id":  images[cover].MediaID,
+	}).Error
+}
+
+// newGallery is the gallery of a new car or review, its image as the cover.
+func newGallery(imageURL string, mediaID *uint) []entity.GalleryImage {
+	return []entity.GalleryImage{{ImageUrl: imageURL, MediaID: mediaID, IsCover: true}}
+}
+
+// setGalleryCover replaces the cover image when the image_url of the owner
+// is updated directly.
+func setGalleryCover(tx *gorm.DB, ownerType string, ownerID uint, imageURL string, mediaID *uint) error {
+	result := tx.Model(&entity.GalleryImage{}).
+		Where("owner_type = ? AND owner_id = ? AND is_cover", ownerType, ownerID).
+		Updates(map[string]any{"image_url": imageURL, "media_id": mediaID})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		var count int64
+		if err := tx.Model(&entity.GalleryImage{}).Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).Count(&count).Error; err != nil {
+			return err
+		}
+
+		image := entity.GalleryImage{OwnerType: ownerType, OwnerID: ownerID, ImageUrl: imageURL, MediaID: mediaID, Position: int(count), IsCover: true}
+		if err := tx.Create(&image).Error; err != nil {
+			return err
+		}
+	}
+
+	return syncGallery(tx, ownerType, ownerID)
+}
+
+func deleteGallery(tx *gorm.DB, ownerType string, ownerID uint) error {
+	return tx.Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).Delete(&entity.GalleryImage{}).Error
+}
+
+func toGalleryImageResponse(image *entity.GalleryImage) response.GalleryImageResponse {
+	return response.GalleryImageResponse{
+		ID:       image.ID,
+		ImageUrl: image.ImageUrl,
+		MediaID:  image.MediaID,
+		Caption:  image.Caption,
+		Position: image.Position,
+		IsCover:  image.IsCover,
+	}
+}
+
+func toGalleryImageResponses(images []entity.GalleryImage) *[]response.GalleryImageResponse {
+	gallery := []response.GalleryImageResponse{}
+	for _, image := range images {
+		gallery = append(gallery, toGalleryImageResponse(&image))
+	}
+	return &gallery
+}
@@ -0,0 +1,329 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type MediaService interface {
+	Upload(*gin.Context, uint, io.Reader) (*response.MediaResponse, error)
+	FindByID(*gin.Context, uint) (*response.MediaResponse, error)
+	Open(*gin.Context, uint, string) (io.ReadCloser, *entity.MediaVariant, error)
+	Delete(*gin.Context, uint, uint) error
+}
+
+type mediaServiceImpl struct {
+	store     utils.BlobStore
+	outbox    Outbox
+	maxBytes  int64
+	maxPixels int
+	sizes     []int
+}
+
+var mediaContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// NewMediaService reads its limits from MEDIA_MAX_BYTES, MEDIA_MAX_PIXELS and
+// MEDIA_THUMBNAIL_SIZES (comma separated longest edges). The blobs of deleted
+// media are removed by an outbox job.
+func NewMediaService(store utils.BlobStore, outbox Outbox) MediaService {
+	var sizes []int
+	for _, size := range strings.Split(helper.GetEnv("MEDIA_THUMBNAIL_SIZES", "160,480,1024"), ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(size)); err == nil && n > 0 {
+			sizes = append(sizes, n)
+		}
+	}
+
+	service := &mediaServiceImpl{
+		store:     store,
+		outbox:    outbox,
+		maxBytes:  int64(helper.GetEnvInt("MEDIA_MAX_BYTES", 10<<20)),
+		maxPixels: helper.GetEnvInt("MEDIA_MAX_PIXELS", 40_000_000),
+		sizes:     sizes,
+	}
+
+	outbox.Register(OutboxMediaDeleteBlobs, service.deleteBlobs)
+
+	return service
+}
+
+// Upload trusts neither the file name nor the declared content type, the
+// type is sniffed from the content. The image is re-encoded, which strips
+// its metadata, and stored with its thumbnails.
+func (service *mediaServiceImpl) Upload(c *gin.Context, ownerID uint, file io.Reader) (*response.MediaResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	data, err := io.ReadAll(io.LimitReader(file, service.maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > service.maxBytes {
+		return nil, exceptions.NewCustomError(http.StatusRequestEntityTooLarge, fmt.Sprintf("File must be at most %d bytes", service.maxBytes))
+	}
+
+	contentType := http.DetectContentType(data)
+	if !mediaContentTypes[contentType] {
+		return nil, exceptions.NewCustomError(http.StatusUnsupportedMediaType, "Only JPEG, PNG and GIF images are supported")
+	}
+
+	variants, err := utils.ProcessImage(data, contentType, service.sizes, service.maxPixels)
+	if err != nil {
+		return nil, exceptions.NewCustomError(http.StatusUnprocessableEntity, "Invalid image: "+err.Error())
+	}
+
+	prefix, err := utils.RandomURLSafeString(16)
+	if err != nil {
+		return nil, err
+	}
+	prefix = time.Now().UTC().Format("media/2006/01/") + prefix
+
+	sum := sha256.Sum256(variants[0].Data)
+
+	media := entity.Media{
+		OwnerID:     ownerID,
+		ContentType: variants[0].ContentType,
+		Size:        int64(len(variants[0].Data)),
+		Width:       variants[0].Width,
+		Height:      variants[0].Height,
+		SHA256:      hex.EncodeToString(sum[:]),
+	}
+
+	for _, variant := range variants {
+		key := prefix + "/" + variant.Name + mediaExtension(variant.ContentType)
+
+		if err := service.store.Put(c.Request.Context(), key, variant.Data, variant.ContentType); err != nil {
+			service.removeBlobs(c, media.Variants)
+			return nil, err
+		}
+
+		media.Variants = append(media.Variants, entity.MediaVariant{
+			Name:        variant.Name,
+			ContentType: variant.ContentType,
+			Width:       variant.Width,
+			Height:      variant.Height,
+			Size:        int64(len(variant.Data)),
+			StorageKey:  key,
+		})
+	}
+
+	if err := db.Create(&media).Error; err != nil {
+		service.removeBlobs(c, media.Variants)
+		return nil, err
+	}
+
+	logger.Info("media uploaded successfully", zap.Uint("mediaID", media.ID), zap.Uint("ownerID", ownerID), zap.Int("variants", len(media.Variants)))
+
+	return toMediaResponse(&media), nil
+}
+
+func (service *mediaServiceImpl) FindByID(c *gin.Context, mediaID uint) (*response.MediaResponse, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	var media entity.Media
+
+	if err := db.Preload("Variants").Take(&media, mediaID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exceptions.NewCustomError(http.StatusNotFound, "Media not found")
+		}
+		return nil, err
+	}
+
+	return toMediaResponse(&media), nil
+}
+
+func (service *mediaServiceImpl) Open(c *gin.Context, mediaID uint, variantName string) (io.ReadCloser, *entity.MediaVariant, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	var variant entity.MediaVariant
+
+	if err := db.Take(&variant, "media_id = ? AND name = ?", mediaID, variantName).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, exceptions.NewCustomError(http.StatusNotFound, "Media not found")
+		}
+		return nil, nil, err
+	}
+
+	blob, err := service.store.Get(c.Request.Context(), variant.StorageKey)
+	if errors.Is(err, utils.ErrBlobNotFound) {
+		return nil, nil, exceptions.NewCustomError(http.StatusNotFound, "Media not found")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return blob, &variant, nil
+}
+
+// Delete removes media of the user, admins may remove any. Media still used
+// by a car, a review or a gallery is kept.
+func (service *mediaServiceImpl) Delete(c *gin.Context, userID, mediaID uint) error {
+	db, logger := helper.GetDBAndLogger(c)
+
+	_, role, err := utils.ExtractTokenClaims(c)
+	if err != nil {
+		return err
+	}
+
+	var media entity.Media
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Preload("Variants").Take(&media, mediaID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return exceptions.NewCustomError(http.StatusNotFound, "Media not found")
+			}
+			return err
+		}
+
+		if media.OwnerID != userID && role != entity.RoleAdmin {
+			return exceptions.NewCustomError(http.StatusForbidden, "You can only delete your own media")
+		}
+
+		var cars, reviews, images int64
+		// deleted cars and reviews count, they may be restored
+		if err := tx.Unscoped().Model(&entity.Car{}).Where("media_id = ?", mediaID).Count(&cars).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&entity.Review{}).Where("media_id = ?", mediaID).Count(&reviews).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.GalleryImage{}).Where("media_id = ?", mediaID).Count(&images).Error; err != nil {
+			return err
+		}
+		if cars+reviews+images > 0 {
+			return exceptions.NewCustomError(http.StatusConflict, "Media is used by a car, a review or a gallery")
+		}
+
+		if err := tx.Select("Variants").Delete(&media).Error; err != nil {
+			return err
+		}
+
+		keys := make([]string, len(media.Variants))
+		for i, variant := range media.Variants {
+			keys[i] = variant.StorageKey
+		}
+
+		return service.outbox.Enqueue(tx, OutboxMediaDeleteBlobs, &mediaBlobsPayload{Keys: keys})
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("media deleted successfully", zap.Uint("mediaID", mediaID))
+
+	return nil
+}
+
+func (service *mediaServiceImpl) removeBlobs(c *gin.Context, variants []entity.MediaVariant) {
+	_, logger := helper.GetDBAndLogger(c)
+
+	for _, variant := range variants {
+		if err := service.store.Delete(c.Request.Context(), variant.StorageKey); err != nil {
+			logger.Warn("failed to delete media blob", zap.String("key", variant.StorageKey), zap.Error(err))
+		}
+	}
+}
+
+type mediaBlobsPayload struct {
+	Keys []string `json:"keys"`
+}
+
+// deleteBlobs removes the blobs of deleted media, deleting a missing blob
+// succeeds so a retried job only removes what is left.
+func (service *mediaServiceImpl) deleteBlobs(tx *gorm.DB, payload json.RawMessage) error {
+	var blobs mediaBlobsPayload
+	if err := json.Unmarshal(payload, &blobs); err != nil {
+		return err
+	}
+
+	for _, key := range blobs.Keys {
+		if err := service.store.Delete(context.Background(), key); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// mediaImageURL checks that the media exists, and belongs to ownerID unless
+// it is nil, and returns the URL of its original to store as image_url.
+func mediaImageURL(tx *gorm.DB, mediaID uint, ownerID *uint) (string, error) {
+	var media entity.Media
+
+	if err := tx.Take(&media, mediaID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", exceptions.NewCustomError(http.StatusNotFound, "Media not found")
+		}
+		return "", err
+	}
+
+	if ownerID != nil && media.OwnerID != *ownerID {
+		return "", exceptions.NewCustomError(http.StatusForbidden, "You can only use your own media")
+	}
+
+	return mediaURL(media.ID, "original"), nil
+}
+
+func mediaURL(mediaID uint, variant string) string {
+	return fmt.Sprintf("%s/api/media/%d/%s", strings.TrimRight(helper.GetEnv("MEDIA_PUBLIC_URL", ""), "/"), mediaID, variant)
+}
+
+func mediaExtension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	default:
+		return ""
+	}
+}
+
+func toMediaResponse(media *entity.Media) *response.MediaResponse {
+	variants := []response.MediaVariantResponse{}
+	for _, variant := range media.Variants {
+		variants = append(variants, response.MediaVariantResponse{
+			Name:        variant.Name,
+			ContentType: variant.ContentType,
+			Width:       variant.Width,
+			Height:      variant.Height,
+			Size:        variant.Size,
+			URL:         mediaURL(media.ID, variant.Name),
+		})
+	}
+
+	return &response.MediaResponse{
+		ID:          media.ID,
+		OwnerID:     media.OwnerID,
+		ContentType: media.ContentType,
+		Size:        media.Size,
+		Width:       media.Width,
+		Height:      media.Height,
+		URL:         mediaURL(media.ID, "original"),
+		Variants:    variants,
+		CreatedAt:   media.CreatedAt,
+	}
+}
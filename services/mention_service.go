@@ -0,0 +1,160 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"gorm.io/gorm"
+)
+
+// mentionPattern matches an @ that does not follow a letter, a digit or
+// another @, so e-mail addresses are not mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@])@([\p{L}\p{N}_.\-]+)`)
+
+type parsedMention struct {
+	Username   string
+	Start, End int
+}
+
+// parseMentions finds the @usernames of a text with their positions in
+// characters. Dots and dashes ending a sentence are not part of the username.
+func parseMentions(text string) []parsedMention {
+	var mentions []parsedMention
+
+	for _, match := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		username := strings.TrimRight(text[match[2]:match[3]], ".-")
+		if username == "" {
+			continue
+		}
+
+		// the @ is the byte before the username
+		start := utf8.RuneCountInString(text[:match[2]-1])
+
+		mentions = append(mentions, parsedMention{
+			Username: strings.ToLower(username),
+			Start:    start,
+			End:      start + 1 + utf8.RuneCountInString(username),
+		})
+	}
+
+	return mentions
+}
+
+// saveMentions replaces the mentions of a review or a comment with the
+// @usernames of its text that belong to a user, the others stay plain text.
+// It returns the users mentioned for the first time, never the author.
+func saveMentions(tx *gorm.DB, ownerType string, ownerID, authorID uint, text string) ([]uint, error) {
+	parsed := parseMentions(text)
+
+	userIDs := map[string]uint{}
+	if len(parsed) > 0 {
+		usernames := make([]string, len(parsed))
+		for i, mention := range parsed {
+			usernames[i] = mention.Username
+		}
+
+		var users []entity.User
+		if err := tx.Select("id", "username").Where("username IN ?", usernames).Find(&users).Error; err != nil {
+			return nil, err
+		}
+
+		for _, user := range users {
+			userIDs[user.Username] = user.ID
+		}
+	}
+
+	var previous []uint
+	if err := tx.Model(&entity.Mention{}).Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).Distinct("user_id").Pluck("user_id", &previous).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).Delete(&entity.Mention{}).Error; err != nil {
+		return nil, err
+	}
+
+	var mentions []entity.Mention
+	for _, mention := range parsed {
+		if userID, ok := userIDs[mention.Username]; ok {
+			mentions = append(mentions, entity.Mention{OwnerType: ownerType, OwnerID: ownerID, UserID: userID, Start: mention.Start, End: mention.End})
+		}
+	}
+
+	if len(mentions) == 0 {
+		return nil, nil
+	}
+
+	if err := tx.Create(&mentions).Error; err != nil {
+		return nil, err
+	}
+
+	known := map[uint]bool{authorID: true}
+	for _, userID := range previous {
+		known[userID] = true
+	}
+
+	var mentioned []uint
+	for _, mention := range mentions {
+		if !known[mention.UserID] {
+			known[mention.UserID] = true
+			mentioned = append(mentioned, mention.UserID)
+		}
+	}
+
+	return mentioned, nil
+}
+
+// mentionedUsers is every user mentioned in a review or a comment except its
+// author, for content published by a moderator.
+func mentionedUsers(tx *gorm.DB, ownerType string, ownerID, authorID uint) ([]uint, error) {
+	var userIDs []uint
+	err := tx.Model(&entity.Mention{}).
+		Where("owner_type = ? AND owner_id = ? AND user_id <> ?", ownerType, ownerID, authorID).
+		Distinct("user_id").
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// loadMentions fetches the mentions of several reviews or comments at once,
+// keyed by owner ID and in text order.
+func loadMentions(db *gorm.DB, ownerType string, ownerIDs []uint) (map[uint][]response.MentionResponse, error) {
+	mentions := map[uint][]response.MentionResponse{}
+	if len(ownerIDs) == 0 {
+		return mentions, nil
+	}
+
+	var rows []struct {
+		OwnerID  uint
+		UserID   uint
+		Username string
+		Start    int
+		End      int
+	}
+
+	if err := db.Model(&entity.Mention{}).
+		Select("mentions.owner_id, mentions.user_id, users.username, mentions.start, mentions.end").
+		Joins("JOIN users ON users.id = mentions.user_id").
+		Where("mentions.owner_type = ? AND mentions.owner_id IN ?", ownerType, ownerIDs).
+		Order("mentions.owner_id, mentions.start").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		mentions[row.OwnerID] = append(mentions[row.OwnerID], response.MentionResponse{
+			UserID:   row.UserID,
+			Username: row.Username,
+			Start:    row.Start,
+			End:      row.End,
+		})
+	}
+
+	return mentions, nil
+}
+
+// deleteMentions removes the mentions of purged owners.
+func deleteMentions(tx *gorm.DB, ownerType string, ownerIDs []uint) error {
+	return tx.Where("owner_type = ? AND owner_id IN ?", ownerType, ownerIDs).Delete(&entity.Mention{}).Error
+}
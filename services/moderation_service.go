@@ -0,0 +1,398 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// moderationTargets names the reviews and comments that can be reported,
+// with the SQL expression shown in the queue and the audit target type.
+var moderationTargets = map[string]struct {
+	name, excerpt, auditTarget, auditAction string
+}{
+	entity.ReportTargetReview:  {"review", "t.title", AuditTargetReview, AuditReviewModerate},
+	entity.ReportTargetComment: {"comment", "LEFT(t.content, 100)", AuditTargetComment, AuditCommentModerate},
+}
+
+var moderationActions = map[string]string{
+	"approve": entity.StatusPublished,
+	"hide":    entity.StatusHidden,
+	"reject":  entity.StatusRejected,
+}
+
+type ModerationService interface {
+	Report(*gin.Context, string, uint, uint, *request.ReportCreateRequest) (*response.ReportResponse, error)
+	Queue(*gin.Context, *request.ModerationQueueRequest, *web.PaginationRequest) (*[]response.ModerationQueueItemResponse, *web.Metadata, error)
+	Decide(*gin.Context, string, uint, *request.ModerationDecisionRequest) (*response.ModerationDecisionResponse, error)
+}
+
+type moderationServiceImpl struct {
+	events          EventBus
+	reportThreshold int64
+}
+
+// NewModerationService hides an item once it has MODERATION_REPORT_THRESHOLD
+// open reports. Decisions are emitted as events.
+func NewModerationService(events EventBus) ModerationService {
+	return &moderationServiceImpl{
+		events:          events,
+		reportThreshold: int64(helper.GetEnvInt("MODERATION_REPORT_THRESHOLD", 3)),
+	}
+}
+
+type moderationTarget struct {
+	ID     uint
+	UserID uint
+	Status string
+}
+
+func (service *moderationServiceImpl) Report(c *gin.Context, targetType string, targetID, userID uint, reportReq *request.ReportCreateRequest) (*response.ReportResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	report := entity.Report{
+		TargetType: targetType,
+		TargetID:   targetID,
+		ReporterID: userID,
+		Reason:     reportReq.Reason,
+		Details:    reportReq.Details,
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		target, err := findModerationTarget(tx, targetType, targetID)
+		if err != nil {
+			return err
+		}
+
+		name := moderationTargets[targetType].name
+
+		if target.Status != entity.StatusPublished {
+			return exceptions.NewCustomError(http.StatusNotFound, name+" not found")
+		}
+
+		if target.UserID == userID {
+			return exceptions.NewCustomError(http.StatusBadRequest, "You cannot report your own "+name)
+		}
+
+		if err := tx.Create(&report).Error; err != nil {
+			if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
+				return exceptions.NewCustomError(http.StatusConflict, "You have reported this "+name)
+			}
+			return err
+		}
+
+		var openReports int64
+		if err := tx.Model(&entity.Report{}).
+			Where("target_type = ? AND target_id = ? AND resolved_at IS NULL", targetType, targetID).
+			Count(&openReports).Error; err != nil {
+			return err
+		}
+
+		if openReports < service.reportThreshold {
+			return nil
+		}
+
+		logger.Info("reported content hidden", zap.String("targetType", targetType), zap.Uint("targetID", targetID), zap.Int64("reports", openReports))
+
+		if err := tx.Table(targetType).Where("id = ?", targetID).UpdateColumn("status", entity.StatusHidden).Error; err != nil {
+			return err
+		}
+
+		if targetType != entity.ReportTargetComment {
+			return nil
+		}
+
+		var comment entity.Comment
+		if err := tx.Select("id", "review_id").Take(&comment, targetID).Error; err != nil {
+			return err
+		}
+
+		return service.events.Publish(tx, &CommentDeletedEvent{CommentID: targetID, ReviewID: comment.ReviewID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("content reported successfully", zap.String("targetType", targetType), zap.Uint("targetID", targetID), zap.Uint("userID", userID))
+
+	return &response.ReportResponse{
+		ID:         report.ID,
+		TargetType: report.TargetType,
+		TargetID:   report.TargetID,
+		Reason:     report.Reason,
+		Details:    report.Details,
+		CreatedAt:  report.CreatedAt,
+	}, nil
+}
+
+type moderationQueueRow struct {
+	TargetType     string
+	TargetID       uint
+	AuthorID       uint
+	AuthorUsername string
+	Status         string
+	Excerpt        string
+	Reports        int64
+	Reasons        string
+	LastReportedAt *time.Time
+	Screening      *string
+	CreatedAt      time.Time
+}
+
+// Queue lists the pending items and the items with open reports, the most
+// reported first.
+func (service *moderationServiceImpl) Queue(c *gin.Context, queueReq *request.ModerationQueueRequest, paging *web.PaginationRequest) (*[]response.ModerationQueueItemResponse, *web.Metadata, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	var subqueries []any
+	for _, targetType := range []string{entity.ReportTargetReview, entity.ReportTargetComment} {
+		if queueReq.Type != nil && *queueReq.Type != targetType {
+			continue
+		}
+
+		subquery := db.Table(targetType+" AS t").
+			Select("? AS target_type, t.id AS target_id, t.user_id AS author_id, users.username AS author_username, t.status, "+moderationTargets[targetType].excerpt+" AS excerpt, "+
+				"COUNT(reports.id) AS reports, COALESCE(STRING_AGG(DISTINCT reports.reason, ','), '') AS reasons, MAX(reports.created_at) AS last_reported_at, t.screening, t.created_at", targetType).
+			Joins("JOIN users ON users.id = t.user_id").
+			Joins("LEFT JOIN reports ON reports.target_type = ? AND reports.target_id = t.id AND reports.resolved_at IS NULL", targetType).
+			Where("t.deleted_at IS NULL").
+			Group("t.id, users.username").
+			Having("t.status = ? OR COUNT(reports.id) > 0", entity.StatusPending)
+
+		if queueReq.Status != nil {
+			subquery = subquery.Where("t.status = ?", *queueReq.Status)
+		}
+
+		subqueries = append(subqueries, subquery)
+	}
+
+	union := "?"
+	if len(subqueries) == 2 {
+		union = "? UNION ALL ?"
+	}
+
+	query := db.Table("("+union+") AS queue", subqueries...)
+
+	query.Count(&paging.TotalData)
+
+	offset := (paging.Page - 1) * paging.Limit
+
+	var rows []moderationQueueRow
+	if err := query.Order("reports desc, created_at").Limit(paging.Limit).Offset(offset).Scan(&rows).Error; err != nil {
+		return nil, nil, err
+	}
+
+	paging.TotalPages = int((paging.TotalData + int64(paging.Limit) - 1) / int64(paging.Limit))
+
+	items := []response.ModerationQueueItemResponse{}
+	for _, row := range rows {
+		reasons := []string{}
+		if row.Reasons != "" {
+			reasons = strings.Split(row.Reasons, ",")
+		}
+
+		var screening json.RawMessage
+		if row.Screening != nil {
+			screening = json.RawMessage(*row.Screening)
+		}
+
+		items = append(items, response.ModerationQueueItemResponse{
+			TargetType:     row.TargetType,
+			TargetID:       row.TargetID,
+			AuthorID:       row.AuthorID,
+			AuthorUsername: row.AuthorUsername,
+			Status:         row.Status,
+			Excerpt:        row.Excerpt,
+			Reports:        row.Reports,
+			Reasons:        reasons,
+			LastReportedAt: row.LastReportedAt,
+			Screening:      screening,
+			CreatedAt:      row.CreatedAt,
+		})
+	}
+
+	metadata := web.Metadata{
+		Page:       &paging.Page,
+		Limit:      &paging.Limit,
+		TotalPages: &paging.TotalPages,
+		TotalData:  &paging.TotalData,
+	}
+
+	return &items, &metadata, nil
+}
+
+// Decide publishes, hides or rejects an item and closes its open reports. The
+// decision and its note are kept in the audit log.
+func (service *moderationServiceImpl) Decide(c *gin.Context, targetType string, targetID uint, decisionReq *request.ModerationDecisionRequest) (*response.ModerationDecisionResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	decision := response.ModerationDecisionResponse{
+		TargetType: targetType,
+		TargetID:   targetID,
+		Status:     moderationActions[decisionReq.Action],
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		target, err := findModerationTarget(tx, targetType, targetID)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Table(targetType).Where("id = ?", targetID).UpdateColumn("status", decision.Status).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&entity.Report{}).
+			Where("target_type = ? AND target_id = ? AND resolved_at IS NULL", targetType, targetID).
+			UpdateColumn("resolved_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		decision.ResolvedReports = result.RowsAffected
+
+		auditTarget := moderationTargets[targetType]
+
+		if err := recordAudit(c, tx, &entity.AuditEvent{Action: auditTarget.auditAction, TargetType: auditTarget.auditTarget, TargetID: targetID},
+			gin.H{"status": target.Status},
+			gin.H{"status": decision.Status, "note": decisionReq.Note, "resolved_reports": decision.ResolvedReports}); err != nil {
+			return err
+		}
+
+		events := []DomainEvent{&ContentModeratedEvent{TargetType: targetType, TargetID: targetID, AuthorID: target.UserID, PreviousStatus: target.Status, Status: decision.Status, Note: decisionReq.Note}}
+
+		// content approved out of the queue is published for the first time
+		if target.Status == entity.StatusPending && decision.Status == entity.StatusPublished {
+			published, err := publishedEvents(tx, targetType, target)
+			if err != nil {
+				return err
+			}
+			events = append(events, published...)
+		}
+
+		return service.events.Publish(tx, events...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("content moderated successfully", zap.String("targetType", targetType), zap.Uint("targetID", targetID), zap.String("status", decision.Status))
+
+	return &decision, nil
+}
+
+// findModerationTarget locks the review or the comment for the rest of the
+// transaction.
+func findModerationTarget(tx *gorm.DB, targetType string, targetID uint) (*moderationTarget, error) {
+	target, ok := moderationTargets[targetType]
+	if !ok {
+		return nil, exceptions.NewCustomError(http.StatusNotFound, "Unknown content type")
+	}
+
+	var item moderationTarget
+	if err := tx.Table(targetType).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id, user_id, status").
+		Where("deleted_at IS NULL").
+		Take(&item, "id = ?", targetID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exceptions.NewCustomError(http.StatusNotFound, target.name+" not found")
+		}
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// publishedEvents are the events of a review or a comment published by a
+// moderator, the same its author would have emitted.
+func publishedEvents(tx *gorm.DB, targetType string, target *moderationTarget) ([]DomainEvent, error) {
+	var published DomainEvent
+
+	switch targetType {
+	case entity.ReportTargetReview:
+		var review entity.Review
+		if err := tx.Select("id", "car_id").Take(&review, target.ID).Error; err != nil {
+			return nil, err
+		}
+		published = &ReviewPublishedEvent{ReviewID: target.ID, CarID: review.CarID, AuthorID: target.UserID}
+	default:
+		var comment entity.Comment
+		if err := tx.Select("id", "review_id", "parent_id").Take(&comment, target.ID).Error; err != nil {
+			return nil, err
+		}
+		published = &CommentPublishedEvent{CommentID: target.ID, ReviewID: comment.ReviewID, ParentID: comment.ParentID, AuthorID: target.UserID}
+	}
+
+	mentioned, err := mentionedUsers(tx, targetType, target.ID, target.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return []DomainEvent{published, &UserMentionedEvent{OwnerType: targetType, OwnerID: target.ID, AuthorID: target.UserID, UserIDs: mentioned}}, nil
+}
+
+// moderationNewAccountAge is how long the reviews and comments of a new
+// account wait for a moderator, MODERATION_NEW_ACCOUNT_DAYS, 0 publishes them
+// immediately.
+func moderationNewAccountAge() time.Duration {
+	return time.Duration(helper.GetEnvInt("MODERATION_NEW_ACCOUNT_DAYS", 0)) * 24 * time.Hour
+}
+
+// initialStatus is the status of new content by the given user, pending while
+// the account is younger than newAccountAge unless the user is a moderator.
+func initialStatus(tx *gorm.DB, userID uint, newAccountAge time.Duration) (string, error) {
+	if newAccountAge <= 0 {
+		return entity.StatusPublished, nil
+	}
+
+	var user entity.User
+	if err := tx.Select("id", "role", "created_at").Take(&user, userID).Error; err != nil {
+		return "", err
+	}
+
+	if utils.IsModerator(user.Role) || user.CreatedAt.Before(time.Now().Add(-newAccountAge)) {
+		return entity.StatusPublished, nil
+	}
+
+	return entity.StatusPending, nil
+}
+
+// visibleContent limits a query on reviews or comments to the published
+// rows, the author also sees their own and moderators see everything.
+func visibleContent(c *gin.Context, query *gorm.DB, table string) *gorm.DB {
+	userID, role, err := utils.ExtractTokenClaims(c)
+	if err != nil {
+		return query.Where(table+".status = ?", entity.StatusPublished)
+	}
+
+	if utils.IsModerator(role) {
+		return query
+	}
+
+	return query.Where("("+table+".status = ? OR "+table+".user_id = ?)", entity.StatusPublished, userID)
+}
+
+// deleteOrphanReports removes the reports of purged reviews and comments.
+func deleteOrphanReports(tx *gorm.DB) error {
+	for targetType := range moderationTargets {
+		if err := tx.Where("target_type = ? AND NOT EXISTS (SELECT 1 FROM "+targetType+" t WHERE t.id = reports.target_id)", targetType).
+			Delete(&entity.Report{}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
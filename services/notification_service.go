@@ -0,0 +1,473 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationService interface {
+	FindAll(*gin.Context, uint, *request.NotificationQueryRequest, *web.PaginationRequest) (*[]response.NotificationResponse, *web.Metadata, error)
+	MarkRead(*gin.Context, uint, uint) (*response.NotificationResponse, error)
+	MarkAllRead(*gin.Context, uint) (*response.NotificationReadAllResponse, error)
+	FindPreferences(*gin.Context, uint) (*[]response.NotificationPreferenceResponse, error)
+	UpdatePreferences(*gin.Context, uint, *request.NotificationPreferenceRequest) (*[]response.NotificationPreferenceResponse, error)
+}
+
+type notificationServiceImpl struct {
+	events EventBus
+}
+
+// NewNotificationService subscribes to the domain events that notify users,
+// the services that emit them never write notifications themselves. The
+// notifications it stores are emitted in turn.
+func NewNotificationService(events EventBus) NotificationService {
+	service := &notificationServiceImpl{events: events}
+
+	events.Subscribe(EventCommentPublished, service.onCommentPublished)
+	events.Subscribe(EventReviewPublished, service.onReviewPublished)
+	events.Subscribe(EventUserMentioned, service.onUserMentioned)
+	events.Subscribe(EventContentModerated, service.onContentModerated)
+	events.Subscribe(EventCarSpecsChanged, service.onCarSpecsChanged)
+
+	return service
+}
+
+type notificationRow struct {
+	entity.Notification
+	ActorUsername *string
+}
+
+func (service *notificationServiceImpl) FindAll(c *gin.Context, userID uint, notificationQueryReq *request.NotificationQueryRequest, paging *web.PaginationRequest) (*[]response.NotificationResponse, *web.Metadata, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	query := db.Model(&entity.Notification{}).Where("notifications.user_id = ?", userID)
+
+	if notificationQueryReq.Unread != nil {
+		if *notificationQueryReq.Unread {
+			query = query.Where("notifications.read_at IS NULL")
+		} else {
+			query = query.Where("notifications.read_at IS NOT NULL")
+		}
+	}
+
+	query.Count(&paging.TotalData)
+
+	offset := (paging.Page - 1) * paging.Limit
+
+	var rows []notificationRow
+	if err := selectNotificationActor(query).
+		Order("notifications.created_at desc, notifications.id desc").
+		Limit(paging.Limit).Offset(offset).
+		Scan(&rows).Error; err != nil {
+		return nil, nil, err
+	}
+
+	paging.TotalPages = int((paging.TotalData + int64(paging.Limit) - 1) / int64(paging.Limit))
+
+	notifications := []response.NotificationResponse{}
+	for _, row := range rows {
+		notifications = append(notifications, *toNotificationResponse(&row))
+	}
+
+	metadata := web.Metadata{
+		Page:       &paging.Page,
+		Limit:      &paging.Limit,
+		TotalPages: &paging.TotalPages,
+		TotalData:  &paging.TotalData,
+	}
+
+	return &notifications, &metadata, nil
+}
+
+func (service *notificationServiceImpl) MarkRead(c *gin.Context, userID, notificationID uint) (*response.NotificationResponse, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	// reading twice keeps the first read time
+	result := db.Model(&entity.Notification{}).
+		Where("id = ? AND user_id = ?", notificationID, userID).
+		UpdateColumn("read_at", gorm.Expr("COALESCE(read_at, ?)", time.Now()))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, exceptions.NewCustomError(http.StatusNotFound, "Notification not found")
+	}
+
+	var row notificationRow
+	if err := selectNotificationActor(db.Model(&entity.Notification{})).Take(&row, "notifications.id = ?", notificationID).Error; err != nil {
+		return nil, err
+	}
+
+	return toNotificationResponse(&row), nil
+}
+
+func (service *notificationServiceImpl) MarkAllRead(c *gin.Context, userID uint) (*response.NotificationReadAllResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	result := db.Model(&entity.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		UpdateColumn("read_at", time.Now())
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	logger.Info("notifications read successfully", zap.Uint("userID", userID), zap.Int64("count", result.RowsAffected))
+
+	return &response.NotificationReadAllResponse{Read: result.RowsAffected}, nil
+}
+
+func (service *notificationServiceImpl) FindPreferences(c *gin.Context, userID uint) (*[]response.NotificationPreferenceResponse, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	var preferences []entity.NotificationPreference
+	if err := db.Where("user_id = ?", userID).Find(&preferences).Error; err != nil {
+		return nil, err
+	}
+
+	enabled := map[string]bool{}
+	for _, notificationType := range entity.NotificationTypes {
+		enabled[notificationType] = true
+	}
+	for _, preference := range preferences {
+		enabled[preference.Type] = preference.Enabled
+	}
+
+	responsePreferences := []response.NotificationPreferenceResponse{}
+	for _, notificationType := range entity.NotificationTypes {
+		responsePreferences = append(responsePreferences, response.NotificationPreferenceResponse{
+			Type:    notificationType,
+			Enabled: enabled[notificationType],
+		})
+	}
+
+	return &responsePreferences, nil
+}
+
+// UpdatePreferences changes the given types only, the others keep their
+// setting.
+func (service *notificationServiceImpl) UpdatePreferences(c *gin.Context, userID uint, preferenceReq *request.NotificationPreferenceRequest) (*[]response.NotificationPreferenceResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	known := map[string]bool{}
+	for _, notificationType := range entity.NotificationTypes {
+		known[notificationType] = true
+	}
+
+	var preferences []entity.NotificationPreference
+	for notificationType, enabled := range preferenceReq.Preferences {
+		if !known[notificationType] {
+			return nil, exceptions.NewCustomError(http.StatusBadRequest, fmt.Sprintf("Unknown notification type %q", notificationType))
+		}
+		preferences = append(preferences, entity.NotificationPreference{UserID: userID, Type: notificationType, Enabled: enabled})
+	}
+
+	if len(preferences) > 0 {
+		if err := db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+			DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+		}).Create(&preferences).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Info("notification preferences updated successfully", zap.Uint("userID", userID))
+
+	return service.FindPreferences(c, userID)
+}
+
+func selectNotificationActor(query *gorm.DB) *gorm.DB {
+	return query.Select("notifications.*, users.username AS actor_username").
+		Joins("LEFT JOIN users ON users.id = notifications.actor_id")
+}
+
+// onCommentPublished notifies the author of the review and, for a reply, the
+// author of the parent comment. An author who is both only gets the reply.
+func (service *notificationServiceImpl) onCommentPublished(tx *gorm.DB, event DomainEvent) error {
+	published := event.(*CommentPublishedEvent)
+
+	actor, err := notificationActor(tx, published.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	var notifications []entity.Notification
+	notified := map[uint]bool{published.AuthorID: true}
+
+	if published.ParentID != nil {
+		var parent entity.Comment
+		if err := tx.Unscoped().Select("id", "user_id").Take(&parent, *published.ParentID).Error; err != nil {
+			return err
+		}
+
+		if !notified[parent.UserID] {
+			notified[parent.UserID] = true
+			notifications = append(notifications, entity.Notification{
+				UserID:     parent.UserID,
+				Type:       entity.NotificationCommentReply,
+				ActorID:    &published.AuthorID,
+				TargetType: entity.MentionOwnerComment,
+				TargetID:   published.CommentID,
+				Message:    actor + " replied to your comment",
+			})
+		}
+	}
+
+	var review entity.Review
+	if err := tx.Unscoped().Select("id", "user_id", "title").Take(&review, published.ReviewID).Error; err != nil {
+		return err
+	}
+
+	if !notified[review.UserID] {
+		notifications = append(notifications, entity.Notification{
+			UserID:     review.UserID,
+			Type:       entity.NotificationReviewComment,
+			ActorID:    &published.AuthorID,
+			TargetType: entity.MentionOwnerComment,
+			TargetID:   published.CommentID,
+			Message:    fmt.Sprintf("%s commented on your review %q", actor, review.Title),
+		})
+	}
+
+	return service.createNotifications(tx, notifications)
+}
+
+// onReviewPublished notifies the users who favourited the car and those who
+// watch the car or its brand, in one statement however many they are. A user
+// who does both is notified once, as a favourite unless they turned that off.
+func (service *notificationServiceImpl) onReviewPublished(tx *gorm.DB, event DomainEvent) error {
+	published := event.(*ReviewPublishedEvent)
+
+	actor, err := notificationActor(tx, published.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	var car entity.Car
+	if err := tx.Unscoped().Select("id", "brand_id", "name", "model").Take(&car, published.CarID).Error; err != nil {
+		return err
+	}
+
+	var notificationIDs []uint
+	if err := tx.Raw(`INSERT INTO notifications (user_id, type, actor_id, target_type, target_id, message, created_at)
+		SELECT DISTINCT ON (recipients.user_id) recipients.user_id, recipients.type, ?, ?, ?, recipients.message, ? FROM (
+			SELECT user_id, ?::text AS type, ?::text AS message, 0 AS rank FROM favourites WHERE car_id = ?
+			UNION ALL
+			SELECT follower_id, ?::text, ?::text, 1 FROM follows WHERE alerts AND ((target_type = ? AND target_id = ?) OR (target_type = ? AND target_id = ?))
+		) AS recipients
+		WHERE recipients.user_id <> ?
+		AND NOT EXISTS (SELECT 1 FROM notification_preferences p WHERE p.user_id = recipients.user_id AND p.type = recipients.type AND NOT p.enabled)
+		ORDER BY recipients.user_id, recipients.rank
+		RETURNING id`,
+		published.AuthorID, entity.MentionOwnerReview, published.ReviewID, time.Now(),
+		entity.NotificationFavouriteReview, fmt.Sprintf("%s reviewed the %s %s from your favourites", actor, car.Name, car.Model), published.CarID,
+		entity.NotificationWatchReview, fmt.Sprintf("%s reviewed the %s %s you watch", actor, car.Name, car.Model),
+		entity.FollowTargetCar, car.ID, entity.FollowTargetBrand, car.BrandID,
+		published.AuthorID).Scan(&notificationIDs).Error; err != nil {
+		return err
+	}
+
+	if len(notificationIDs) == 0 {
+		return nil
+	}
+
+	return service.events.Publish(tx, &NotificationCreatedEvent{NotificationIDs: notificationIDs})
+}
+
+// onCarSpecsChanged notifies the users who watch the car or its brand, except
+// the editor.
+func (service *notificationServiceImpl) onCarSpecsChanged(tx *gorm.DB, event DomainEvent) error {
+	changed := event.(*CarSpecsChangedEvent)
+
+	var car entity.Car
+	if err := tx.Select("id", "name", "model").Take(&car, changed.CarID).Error; err != nil {
+		return err
+	}
+
+	var notificationIDs []uint
+	if err := tx.Raw(`INSERT INTO notifications (user_id, type, actor_id, target_type, target_id, message, created_at)
+		SELECT DISTINCT follows.follower_id, ?, ?, ?, ?, ?, ? FROM follows
+		WHERE follows.alerts AND ((follows.target_type = ? AND follows.target_id = ?) OR (follows.target_type = ? AND follows.target_id = ?))
+		AND follows.follower_id IS DISTINCT FROM ?
+		AND NOT EXISTS (SELECT 1 FROM notification_preferences p WHERE p.user_id = follows.follower_id AND p.type = ? AND NOT p.enabled)
+		RETURNING id`,
+		entity.NotificationWatchSpecs, changed.EditorID, entity.FollowTargetCar, car.ID,
+		fmt.Sprintf("The specs of the %s %s you watch changed: %s", car.Name, car.Model, strings.Join(changed.Specs, ", ")), time.Now(),
+		entity.FollowTargetCar, car.ID, entity.FollowTargetBrand, changed.BrandID,
+		changed.EditorID, entity.NotificationWatchSpecs).Scan(&notificationIDs).Error; err != nil {
+		return err
+	}
+
+	if len(notificationIDs) == 0 {
+		return nil
+	}
+
+	return service.events.Publish(tx, &NotificationCreatedEvent{NotificationIDs: notificationIDs})
+}
+
+func (service *notificationServiceImpl) onUserMentioned(tx *gorm.DB, event DomainEvent) error {
+	mentioned := event.(*UserMentionedEvent)
+
+	if len(mentioned.UserIDs) == 0 {
+		return nil
+	}
+
+	actor, err := notificationActor(tx, mentioned.AuthorID)
+	if err != nil {
+		return err
+	}
+
+	owner := "comment"
+	if mentioned.OwnerType == entity.MentionOwnerReview {
+		owner = "review"
+	}
+
+	var notifications []entity.Notification
+	for _, userID := range mentioned.UserIDs {
+		notifications = append(notifications, entity.Notification{
+			UserID:     userID,
+			Type:       entity.NotificationMention,
+			ActorID:    &mentioned.AuthorID,
+			TargetType: mentioned.OwnerType,
+			TargetID:   mentioned.OwnerID,
+			Message:    fmt.Sprintf("%s mentioned you in a %s", actor, owner),
+		})
+	}
+
+	return service.createNotifications(tx, notifications)
+}
+
+// onContentModerated tells the author what a moderator decided, without
+// naming the moderator.
+func (service *notificationServiceImpl) onContentModerated(tx *gorm.DB, event DomainEvent) error {
+	moderated := event.(*ContentModeratedEvent)
+
+	message := fmt.Sprintf("Your %s is now %s", moderationTargets[moderated.TargetType].name, moderated.Status)
+	if moderated.Note != "" {
+		message += ": " + moderated.Note
+	}
+
+	return service.createNotifications(tx, []entity.Notification{{
+		UserID:     moderated.AuthorID,
+		Type:       entity.NotificationModeration,
+		TargetType: moderated.TargetType,
+		TargetID:   moderated.TargetID,
+		Message:    message,
+	}})
+}
+
+func notificationActor(tx *gorm.DB, userID uint) (string, error) {
+	var user entity.User
+	if err := tx.Select("id", "username").Take(&user, userID).Error; err != nil {
+		return "", err
+	}
+	return user.Username, nil
+}
+
+// createNotifications stores the notifications whose type the recipient has
+// not turned off, inside the caller's transaction.
+func (service *notificationServiceImpl) createNotifications(tx *gorm.DB, notifications []entity.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	userIDs := make([]uint, len(notifications))
+	for i, notification := range notifications {
+		userIDs[i] = notification.UserID
+	}
+
+	var disabled []entity.NotificationPreference
+	if err := tx.Where("user_id IN ? AND NOT enabled", userIDs).Find(&disabled).Error; err != nil {
+		return err
+	}
+
+	off := map[uint]map[string]bool{}
+	for _, preference := range disabled {
+		if off[preference.UserID] == nil {
+			off[preference.UserID] = map[string]bool{}
+		}
+		off[preference.UserID][preference.Type] = true
+	}
+
+	var enabled []entity.Notification
+	for _, notification := range notifications {
+		if !off[notification.UserID][notification.Type] {
+			enabled = append(enabled, notification)
+		}
+	}
+
+	if len(enabled) == 0 {
+		return nil
+	}
+
+	if err := tx.Create(&enabled).Error; err != nil {
+		return err
+	}
+
+	notificationIDs := make([]uint, len(enabled))
+	for i, notification := range enabled {
+		notificationIDs[i] = notification.ID
+	}
+
+	return service.events.Publish(tx, &NotificationCreatedEvent{NotificationIDs: notificationIDs})
+}
+
+// deleteUserNotifications removes the notifications and preferences of a
+// deleted user and keeps the notifications they caused without an actor.
+func deleteUserNotifications(tx *gorm.DB, userID uint) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&entity.Notification{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("user_id = ?", userID).Delete(&entity.NotificationPreference{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&entity.Notification{}).Where("actor_id = ?", userID).UpdateColumn("actor_id", nil).Error
+}
+
+// loadNotificationResponses fetches notifications with their actor, keyed by
+// recipient.
+func loadNotificationResponses(db *gorm.DB, notificationIDs []uint) (map[uint][]response.NotificationResponse, error) {
+	var rows []notificationRow
+	if err := selectNotificationActor(db.Model(&entity.Notification{})).
+		Where("notifications.id IN ?", notificationIDs).
+		Order("notifications.id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	notifications := map[uint][]response.NotificationResponse{}
+	for i := range rows {
+		notifications[rows[i].UserID] = append(notifications[rows[i].UserID], *toNotificationResponse(&rows[i]))
+	}
+
+	return notifications, nil
+}
+
+func toNotificationResponse(row *notificationRow) *response.NotificationResponse {
+	return &response.NotificationResponse{
+		ID:            row.ID,
+		Type:          row.Type,
+		ActorID:       row.ActorID,
+		ActorUsername: row.ActorUsername,
+		TargetType:    row.TargetType,
+		TargetID:      row.TargetID,
+		Message:       row.Message,
+		ReadAt:        row.ReadAt,
+		CreatedAt:     row.CreatedAt,
+	}
+}
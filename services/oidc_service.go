@@ -0,0 +1,256 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type OIDCService interface {
+	Start(*gin.Context, string) (string, string, error)
+	Callback(*gin.Context, string, *request.OIDCCallbackRequest, string) (*response.LoginResponse, error)
+}
+
+type oidcServiceImpl struct{}
+
+func NewOIDCService() OIDCService {
+	return &oidcServiceImpl{}
+}
+
+// Start returns the provider authorization URL and the signed flow token
+// holding the state, nonce and PKCE verifier for the callback.
+func (service *oidcServiceImpl) Start(c *gin.Context, providerName string) (string, string, error) {
+	provider, err := utils.GetOIDCProvider(providerName)
+	if err != nil {
+		return "", "", err
+	}
+
+	discovery, err := provider.Discover()
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err := utils.RandomURLSafeString(24)
+	if err != nil {
+		return "", "", err
+	}
+
+	nonce, err := utils.RandomURLSafeString(24)
+	if err != nil {
+		return "", "", err
+	}
+
+	codeVerifier, err := utils.RandomURLSafeString(48)
+	if err != nil {
+		return "", "", err
+	}
+
+	authURL, err := provider.AuthCodeURL(discovery, state, nonce, utils.PKCEChallenge(codeVerifier))
+	if err != nil {
+		return "", "", err
+	}
+
+	flowToken, err := utils.GenerateOIDCFlowToken(provider.Name, state, nonce, codeVerifier)
+	if err != nil {
+		return "", "", err
+	}
+
+	return authURL, flowToken, nil
+}
+
+func (service *oidcServiceImpl) Callback(c *gin.Context, providerName string, callbackReq *request.OIDCCallbackRequest, flowToken string) (*response.LoginResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	provider, err := utils.GetOIDCProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	if callbackReq.Error != "" {
+		return nil, exceptions.NewCustomError(http.StatusUnauthorized, fmt.Sprintf("OIDC login failed: %s %s", callbackReq.Error, callbackReq.ErrorDescription))
+	}
+
+	if callbackReq.Code == "" {
+		return nil, exceptions.NewCustomError(http.StatusBadRequest, "Authorization code is required")
+	}
+
+	flow, err := utils.ParseOIDCFlowToken(flowToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if flow.Provider != provider.Name || flow.State != callbackReq.State {
+		return nil, exceptions.NewCustomError(http.StatusBadRequest, "Invalid or expired login session")
+	}
+
+	discovery, err := provider.Discover()
+	if err != nil {
+		return nil, err
+	}
+
+	rawIDToken, err := provider.ExchangeCode(discovery, callbackReq.Code, flow.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := provider.VerifyIDToken(discovery, rawIDToken, flow.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Email == "" || !claims.IsEmailVerified() {
+		return nil, exceptions.NewCustomError(http.StatusForbidden, "OIDC account email is not verified")
+	}
+
+	var user entity.User
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var identity entity.UserIdentity
+
+		err := tx.Preload("User").Take(&identity, "provider = ? AND subject = ?", provider.Name, claims.Subject).Error
+		if err == nil {
+			user = identity.User
+			return nil
+		}
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		email := strings.ToLower(claims.Email)
+
+		err = tx.Take(&user, "LOWER(email) = ?", email).Error
+		if err == gorm.ErrRecordNotFound {
+			if err := service.createUser(tx, &user, email); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		} else if user.EmailVerifiedAt == nil {
+			// anyone could have registered the email before its owner signed
+			// in with the provider, so whoever set the password loses the
+			// account: its sessions end and the password must be reset
+			if err := revokeUserSessions(tx, user.ID, ""); err != nil {
+				return err
+			}
+
+			hashedPassword, err := randomPasswordHash()
+			if err != nil {
+				return err
+			}
+
+			user.Password = hashedPassword
+			if err := tx.Model(&user).Update("password", hashedPassword).Error; err != nil {
+				return err
+			}
+		}
+
+		if user.EmailVerifiedAt == nil {
+			now := time.Now()
+			user.EmailVerifiedAt = &now
+			if err := tx.Model(&user).Update("email_verified_at", now).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Create(&entity.UserIdentity{
+			UserID:   user.ID,
+			Provider: provider.Name,
+			Subject:  claims.Subject,
+			Email:    email,
+		}).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := issueSessionToken(c, db, &user, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("user logged in with OIDC", zap.Uint("userID", user.ID), zap.String("provider", provider.Name))
+
+	return &response.LoginResponse{
+		Username: user.Username,
+		Email:    user.Email,
+		Role:     user.Role,
+		Token:    token,
+	}, nil
+}
+
+var usernameInvalidChars = regexp.MustCompile(`[^a-z0-9._-]`)
+
+func (service *oidcServiceImpl) createUser(tx *gorm.DB, user *entity.User, email string) error {
+	base := usernameInvalidChars.ReplaceAllString(strings.ToLower(strings.SplitN(email, "@", 2)[0]), "")
+	if len(base) > 13 {
+		base = base[:13]
+	}
+	for len(base) < 3 {
+		base += "0"
+	}
+
+	username := base
+	for i := 0; ; i++ {
+		var count int64
+		if err := tx.Model(&entity.User{}).Where("username = ?", username).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			break
+		}
+		if i >= 10 {
+			return exceptions.NewCustomError(http.StatusConflict, "Could not allocate a username")
+		}
+		suffix, err := utils.RandomURLSafeString(4)
+		if err != nil {
+			return err
+		}
+		username = base + "-" + usernameInvalidChars.ReplaceAllString(strings.ToLower(suffix), "")
+	}
+
+	hashedPassword, err := randomPasswordHash()
+	if err != nil {
+		return err
+	}
+
+	*user = entity.User{
+		Username: username,
+		Email:    email,
+		Password: hashedPassword,
+	}
+
+	if err := tx.Create(user).Error; err != nil {
+		return exceptions.NewCustomError(http.StatusConflict, "Username or email already exists")
+	}
+
+	if err := tx.Create(&entity.Profile{UserID: user.ID}).Error; err != nil {
+		return err
+	}
+
+	// reload to pick up the database default role
+	return tx.Take(user, "id = ?", user.ID).Error
+}
+
+// randomPasswordHash hashes an unguessable password, the account is then only
+// reachable through the provider until the user resets their password.
+func randomPasswordHash() (string, error) {
+	randomPassword, err := utils.RandomURLSafeString(32)
+	if err != nil {
+		return "", err
+	}
+
+	return helper.HashPassword(randomPassword)
+}
@@ -0,0 +1,494 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Outbox job kinds.
+const (
+	OutboxMediaDeleteBlobs = "media.delete_blobs"
+	OutboxWatchDigest      = "watch.digest"
+	OutboxWebhookDeliver   = "webhook.deliver"
+)
+
+const (
+	outboxBatchSize   = 50
+	outboxMaxBackoff  = time.Hour
+	outboxCallWorkers = 4
+	outboxCallLease   = 5 * time.Minute
+)
+
+// OutboxHandler runs a job inside the transaction that marks it done, so its
+// database writes commit with the job. Other side effects are at least once:
+// they repeat when the worker dies before the commit, and must be idempotent.
+type OutboxHandler func(tx *gorm.DB, payload json.RawMessage) error
+
+// OutboxCall runs a job outside of any transaction, for side effects too slow
+// to keep the job locked such as HTTP requests. It opens its own short
+// transactions on db, and runs again when it fails or its worker dies.
+type OutboxCall func(db *gorm.DB, payload json.RawMessage) error
+
+type Outbox interface {
+	Register(string, OutboxHandler)
+	RegisterCall(string, OutboxCall)
+	Enqueue(*gorm.DB, string, any) error
+	EnqueueAt(*gorm.DB, string, any, time.Time) error
+	Dispatch(*gorm.DB, *zap.Logger, time.Time) (int, error)
+	DispatchCalls(*gorm.DB, *zap.Logger, time.Time) (int, error)
+	Start(*gorm.DB, *zap.Logger)
+}
+
+type outboxImpl struct {
+	mutex    sync.RWMutex
+	handlers map[string]OutboxHandler
+	calls    map[string]OutboxCall
+}
+
+// NewOutbox stores side effects in outbox_jobs, in the transaction of the
+// change that causes them, and runs them after the commit with the handler
+// or the call registered for their kind.
+func NewOutbox() Outbox {
+	return &outboxImpl{handlers: map[string]OutboxHandler{}, calls: map[string]OutboxCall{}}
+}
+
+func (outbox *outboxImpl) Register(kind string, handler OutboxHandler) {
+	outbox.mutex.Lock()
+	defer outbox.mutex.Unlock()
+
+	outbox.handlers[kind] = handler
+}
+
+func (outbox *outboxImpl) RegisterCall(kind string, call OutboxCall) {
+	outbox.mutex.Lock()
+	defer outbox.mutex.Unlock()
+
+	outbox.calls[kind] = call
+}
+
+// Enqueue stores a job with the payload as JSON, tx should be the transaction
+// of the change so that the job only exists when the change commits.
+func (outbox *outboxImpl) Enqueue(tx *gorm.DB, kind string, payload any) error {
+	return outbox.EnqueueAt(tx, kind, payload, time.Now())
+}
+
+// EnqueueAt is Enqueue for a job that should not run before runAt.
+func (outbox *outboxImpl) EnqueueAt(tx *gorm.DB, kind string, payload any, runAt time.Time) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&entity.OutboxJob{
+		Kind:    kind,
+		Payload: string(encoded),
+		Status:  entity.OutboxJobPending,
+		RunAt:   runAt,
+	}).Error
+}
+
+// Dispatch runs the jobs due at now, at most outboxBatchSize of them, and
+// returns how many it ran. Only the kinds with a handler are claimed. Each job
+// is claimed with SKIP LOCKED and stays locked while it runs, so several
+// workers can dispatch at the same time and a job whose worker dies is picked
+// up again.
+func (outbox *outboxImpl) Dispatch(db *gorm.DB, logger *zap.Logger, now time.Time) (int, error) {
+	outbox.mutex.RLock()
+	kinds := make([]string, 0, len(outbox.handlers))
+	for kind := range outbox.handlers {
+		kinds = append(kinds, kind)
+	}
+	outbox.mutex.RUnlock()
+
+	dispatched := 0
+
+	for len(kinds) > 0 && dispatched < outboxBatchSize {
+		var job entity.OutboxJob
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+				Where("status = ? AND run_at <= ? AND kind IN ?", entity.OutboxJobPending, now, kinds).
+				Order("run_at, id").
+				Limit(1).
+				Find(&job)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+
+			outbox.finish(&job, outbox.handle(tx, &job), now)
+
+			return tx.Model(&job).
+				Select("status", "attempts", "run_at", "last_error", "processed_at").
+				Updates(&job).Error
+		})
+		if err != nil {
+			return dispatched, err
+		}
+
+		if job.ID == 0 {
+			break
+		}
+		dispatched++
+
+		logOutboxJob(logger, &job)
+	}
+
+	return dispatched, nil
+}
+
+// DispatchCalls runs the call jobs due at now, at most outboxBatchSize of
+// them on outboxCallWorkers workers, and returns how many it ran. Each job is
+// leased for outboxCallLease in a transaction of its own before its call,
+// and the outcome is recorded in another one after it.
+func (outbox *outboxImpl) DispatchCalls(db *gorm.DB, logger *zap.Logger, now time.Time) (int, error) {
+	outbox.mutex.RLock()
+	kinds := make([]string, 0, len(outbox.calls))
+	for kind := range outbox.calls {
+		kinds = append(kinds, kind)
+	}
+	outbox.mutex.RUnlock()
+
+	if len(kinds) == 0 {
+		return 0, nil
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	dispatched := 0
+
+	// next reserves a place in the batch, false once it is full or a worker
+	// failed
+	next := func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if dispatched >= outboxBatchSize || firstErr != nil {
+			return false
+		}
+		dispatched++
+		return true
+	}
+
+	done := func(err error, ran bool) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if !ran {
+			dispatched--
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	for i := 0; i < outboxCallWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for next() {
+				ran, err := outbox.runCall(db, logger, kinds, now)
+				done(err, ran)
+				if err != nil || !ran {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return dispatched, firstErr
+}
+
+// runCall leases a due call job, runs its call and records the outcome. It
+// reports whether there was a job to run.
+func (outbox *outboxImpl) runCall(db *gorm.DB, logger *zap.Logger, kinds []string, now time.Time) (bool, error) {
+	var job entity.OutboxJob
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND run_at <= ? AND kind IN ?", entity.OutboxJobPending, now, kinds).
+			Order("run_at, id").
+			Limit(1).
+			Find(&job)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		// the job is due again if its worker dies during the call
+		return tx.Model(&job).Update("run_at", now.Add(outboxCallLease)).Error
+	})
+	if err != nil || job.ID == 0 {
+		return false, err
+	}
+
+	outbox.finish(&job, outbox.call(db, &job), now)
+
+	if err := db.Model(&job).
+		Select("status", "attempts", "run_at", "last_error", "processed_at").
+		Updates(&job).Error; err != nil {
+		return true, err
+	}
+
+	logOutboxJob(logger, &job)
+
+	return true, nil
+}
+
+func logOutboxJob(logger *zap.Logger, job *entity.OutboxJob) {
+	switch job.Status {
+	case entity.OutboxJobDone:
+		logger.Info("outbox job done", zap.Uint("jobID", job.ID), zap.String("kind", job.Kind))
+	case entity.OutboxJobDead:
+		logger.Error("outbox job dead-lettered", zap.Uint("jobID", job.ID), zap.String("kind", job.Kind), zap.Int("attempts", job.Attempts), zap.String("error", job.LastError))
+	default:
+		logger.Warn("outbox job failed", zap.Uint("jobID", job.ID), zap.String("kind", job.Kind), zap.Int("attempts", job.Attempts), zap.String("error", job.LastError))
+	}
+}
+
+// finish records the outcome of an attempt, a failed job is retried after
+// outboxBackoff and dead-lettered after OUTBOX_MAX_ATTEMPTS.
+func (outbox *outboxImpl) finish(job *entity.OutboxJob, err error, now time.Time) {
+	job.Attempts++
+
+	if err != nil {
+		job.LastError = err.Error()
+
+		if job.Attempts >= helper.GetEnvInt("OUTBOX_MAX_ATTEMPTS", 10) {
+			job.Status = entity.OutboxJobDead
+			job.ProcessedAt = &now
+			return
+		}
+
+		job.RunAt = now.Add(outboxBackoff(job.Attempts))
+		return
+	}
+
+	job.Status = entity.OutboxJobDone
+	job.LastError = ""
+	job.ProcessedAt = &now
+}
+
+// handle calls the handler of the job, what a failed handler wrote is rolled
+// back and the attempt is kept.
+func (outbox *outboxImpl) handle(tx *gorm.DB, job *entity.OutboxJob) (err error) {
+	outbox.mutex.RLock()
+	handler, ok := outbox.handlers[job.Kind]
+	outbox.mutex.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("no handler registered for %q", job.Kind)
+	}
+
+	if err := tx.SavePoint("outbox_job").Error; err != nil {
+		return err
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+		if err != nil {
+			tx.RollbackTo("outbox_job")
+		}
+	}()
+
+	return handler(tx, json.RawMessage(job.Payload))
+}
+
+// call calls the call of the job, a panic fails the attempt.
+func (outbox *outboxImpl) call(db *gorm.DB, job *entity.OutboxJob) (err error) {
+	outbox.mutex.RLock()
+	call, ok := outbox.calls[job.Kind]
+	outbox.mutex.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("no call registered for %q", job.Kind)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+
+	return call(db, json.RawMessage(job.Payload))
+}
+
+// outboxBackoff is OUTBOX_BACKOFF_SECONDS doubled after each failed attempt,
+// at most outboxMaxBackoff.
+func outboxBackoff(attempts int) time.Duration {
+	delay := time.Duration(helper.GetEnvInt("OUTBOX_BACKOFF_SECONDS", 10)) * time.Second
+	for i := 1; i < attempts && delay < outboxMaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, outboxMaxBackoff)
+}
+
+// Start dispatches the due jobs every OUTBOX_POLL_SECONDS, 0 disables it and
+// leaves the jobs to "carreview outbox work". Done jobs are kept for
+// OUTBOX_RETENTION_DAYS, dead ones until they are replayed.
+func (outbox *outboxImpl) Start(db *gorm.DB, logger *zap.Logger) {
+	interval := time.Duration(helper.GetEnvInt("OUTBOX_POLL_SECONDS", 2)) * time.Second
+	if interval <= 0 {
+		return
+	}
+
+	retention := time.Duration(helper.GetEnvInt("OUTBOX_RETENTION_DAYS", 7)) * 24 * time.Hour
+
+	go WorkOutbox(context.Background(), outbox, db, logger, interval)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := db.Where("status = ? AND processed_at < ?", entity.OutboxJobDone, time.Now().Add(-retention)).Delete(&entity.OutboxJob{}).Error; err != nil {
+				logger.Error("failed to prune outbox jobs", zap.Error(err))
+			}
+		}
+	}()
+}
+
+// WorkOutbox drains the due jobs every interval until ctx is done. The calls
+// are drained on their own, so slow calls never hold back the other jobs.
+func WorkOutbox(ctx context.Context, outbox Outbox, db *gorm.DB, logger *zap.Logger, interval time.Duration) {
+	var wg sync.WaitGroup
+
+	for _, dispatch := range []outboxDispatch{outbox.Dispatch, outbox.DispatchCalls} {
+		wg.Add(1)
+		go func(dispatch outboxDispatch) {
+			defer wg.Done()
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				if _, err := drainOutbox(dispatch, db, logger); err != nil {
+					logger.Error("failed to dispatch outbox jobs", zap.Error(err))
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}(dispatch)
+	}
+
+	wg.Wait()
+}
+
+// DrainOutbox dispatches the jobs and the calls until none is due and returns
+// how many it ran.
+func DrainOutbox(outbox Outbox, db *gorm.DB, logger *zap.Logger) (int, error) {
+	jobs, err := drainOutbox(outbox.Dispatch, db, logger)
+	if err != nil {
+		return jobs, err
+	}
+
+	calls, err := drainOutbox(outbox.DispatchCalls, db, logger)
+	return jobs + calls, err
+}
+
+type outboxDispatch func(*gorm.DB, *zap.Logger, time.Time) (int, error)
+
+func drainOutbox(dispatch outboxDispatch, db *gorm.DB, logger *zap.Logger) (int, error) {
+	total := 0
+	for {
+		dispatched, err := dispatch(db, logger, time.Now())
+		total += dispatched
+		if err != nil || dispatched < outboxBatchSize {
+			return total, err
+		}
+	}
+}
+
+// OutboxFilter selects outbox jobs by ID, status and kind, the empty fields
+// match everything.
+type OutboxFilter struct {
+	IDs    []uint
+	Status string
+	Kind   string
+}
+
+func (filter *OutboxFilter) apply(query *gorm.DB) *gorm.DB {
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN ?", filter.IDs)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Kind != "" {
+		query = query.Where("kind = ?", filter.Kind)
+	}
+	return query
+}
+
+// FindOutboxJobs returns the jobs matching the filter, newest first.
+func FindOutboxJobs(db *gorm.DB, filter *OutboxFilter, limit int) ([]entity.OutboxJob, error) {
+	var jobs []entity.OutboxJob
+	err := filter.apply(db.Model(&entity.OutboxJob{})).Order("id desc").Limit(limit).Find(&jobs).Error
+	return jobs, err
+}
+
+// CountOutboxJobs counts the jobs of each status.
+func CountOutboxJobs(db *gorm.DB) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+
+	if err := db.Model(&entity.OutboxJob{}).Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int64{entity.OutboxJobPending: 0, entity.OutboxJobDone: 0, entity.OutboxJobDead: 0}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+
+	return counts, nil
+}
+
+// ReplayOutboxJobs queues the jobs matching the filter again with their
+// attempts reset and returns how many. Jobs being dispatched are skipped.
+func ReplayOutboxJobs(db *gorm.DB, filter *OutboxFilter, now time.Time) (int64, error) {
+	var replayed int64
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var jobIDs []uint
+		if err := filter.apply(tx.Model(&entity.OutboxJob{})).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Pluck("id", &jobIDs).Error; err != nil {
+			return err
+		}
+
+		if len(jobIDs) == 0 {
+			return nil
+		}
+
+		result := tx.Model(&entity.OutboxJob{}).Where("id IN ?", jobIDs).Updates(map[string]any{
+			"status":       entity.OutboxJobPending,
+			"attempts":     0,
+			"run_at":       now,
+			"last_error":   "",
+			"processed_at": nil,
+		})
+		replayed = result.RowsAffected
+		return result.Error
+	})
+
+	return replayed, err
+}
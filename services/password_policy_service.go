@@ -0,0 +1,189 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/raihanmd/fp-superbootcamp-go/assets"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+)
+
+type PasswordPolicyService interface {
+	Validate(password, username, email string) error
+}
+
+type passwordPolicyServiceImpl struct {
+	minLength      int
+	maxLength      int
+	requireUpper   bool
+	requireLower   bool
+	requireDigit   bool
+	requireSymbol  bool
+	allowSpaces    bool
+	checkIdentity  bool
+	checkBreached  bool
+	breachedSource string
+}
+
+// NewPasswordPolicyService reads the policy from the PASSWORD_* environment
+// variables, see .env.example for the defaults.
+func NewPasswordPolicyService() PasswordPolicyService {
+	return &passwordPolicyServiceImpl{
+		minLength:      helper.GetEnvInt("PASSWORD_MIN_LENGTH", 8),
+		maxLength:      helper.GetEnvInt("PASSWORD_MAX_LENGTH", 72),
+		requireUpper:   helper.GetEnvBool("PASSWORD_REQUIRE_UPPER", false),
+		requireLower:   helper.GetEnvBool("PASSWORD_REQUIRE_LOWER", false),
+		requireDigit:   helper.GetEnvBool("PASSWORD_REQUIRE_DIGIT", false),
+		requireSymbol:  helper.GetEnvBool("PASSWORD_REQUIRE_SYMBOL", false),
+		allowSpaces:    helper.GetEnvBool("PASSWORD_ALLOW_SPACES", false),
+		checkIdentity:  helper.GetEnvBool("PASSWORD_DISALLOW_IDENTITY", true),
+		checkBreached:  helper.GetEnvBool("PASSWORD_CHECK_BREACHED", true),
+		breachedSource: helper.GetEnv("PASSWORD_BREACHED_LIST_FILE", ""),
+	}
+}
+
+func (service *passwordPolicyServiceImpl) Validate(password, username, email string) error {
+	var violations []response.PasswordPolicyViolation
+
+	violate := func(rule, message string) {
+		violations = append(violations, response.PasswordPolicyViolation{Rule: rule, Message: message})
+	}
+
+	length := len([]rune(password))
+	if length < service.minLength {
+		violate("min_length", fmt.Sprintf("Password must be at least %d characters", service.minLength))
+	}
+	if service.maxLength > 0 && len(password) > service.maxLength {
+		violate("max_length", fmt.Sprintf("Password must be at most %d bytes", service.maxLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol, hasSpace bool
+	for _, r := range password {
+		switch {
+		case unicode.IsSpace(r):
+			hasSpace = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+
+	if hasSpace && !service.allowSpaces {
+		violate("no_space", "Password must not contain spaces")
+	}
+	if service.requireUpper && !hasUpper {
+		violate("uppercase", "Password must contain an uppercase letter")
+	}
+	if service.requireLower && !hasLower {
+		violate("lowercase", "Password must contain a lowercase letter")
+	}
+	if service.requireDigit && !hasDigit {
+		violate("digit", "Password must contain a digit")
+	}
+	if service.requireSymbol && !hasSymbol {
+		violate("symbol", "Password must contain a symbol")
+	}
+
+	if service.checkIdentity {
+		lowerPassword := strings.ToLower(password)
+
+		if username = strings.ToLower(strings.TrimSpace(username)); len(username) >= 3 && strings.Contains(lowerPassword, username) {
+			violate("contains_username", "Password must not contain the username")
+		}
+
+		email = strings.ToLower(strings.TrimSpace(email))
+		if local, _, _ := strings.Cut(email, "@"); len(local) >= 3 && strings.Contains(lowerPassword, local) {
+			violate("contains_email", "Password must not contain the email")
+		}
+	}
+
+	if service.checkBreached {
+		breached, err := service.isBreached(password)
+		if err != nil {
+			return err
+		}
+		if breached {
+			violate("breached", "Password has appeared in a data breach, choose another one")
+		}
+	}
+
+	if len(violations) > 0 {
+		return exceptions.NewFieldError(http.StatusBadRequest, map[string]any{"password": violations})
+	}
+
+	return nil
+}
+
+var (
+	breachedOnce    sync.Once
+	breachedHashes  map[string]map[string]struct{}
+	breachedLoadErr error
+)
+
+// isBreached looks the password up the same way the k-anonymity range API
+// does: hashes are bucketed by their first five hex characters and only the
+// remaining suffix is compared.
+func (service *passwordPolicyServiceImpl) isBreached(password string) (bool, error) {
+	breachedOnce.Do(func() {
+		var source io.Reader = bytes.NewReader(assets.BreachedPasswords)
+
+		if service.breachedSource != "" {
+			file, err := os.Open(service.breachedSource)
+			if err != nil {
+				breachedLoadErr = err
+				return
+			}
+			defer file.Close()
+			source = file
+		}
+
+		breachedHashes, breachedLoadErr = loadBreachedHashes(source)
+	})
+	if breachedLoadErr != nil {
+		return false, breachedLoadErr
+	}
+
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	_, found := breachedHashes[hash[:5]][hash[5:]]
+	return found, nil
+}
+
+func loadBreachedHashes(source io.Reader) (map[string]map[string]struct{}, error) {
+	hashes := map[string]map[string]struct{}{}
+
+	scanner := bufio.NewScanner(source)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if len(line) != 40 {
+			continue
+		}
+		line = strings.ToUpper(line)
+
+		bucket, ok := hashes[line[:5]]
+		if !ok {
+			bucket = map[string]struct{}{}
+			hashes[line[:5]] = bucket
+		}
+		bucket[line[5:]] = struct{}{}
+	}
+
+	return hashes, scanner.Err()
+}
@@ -1,7 +1,10 @@
 package services
 
 import (
+	"encoding/base64"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -14,6 +17,7 @@ import (
 	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ReviewService interface {
@@ -23,82 +27,207 @@ type ReviewService interface {
 	FindAll(*gin.Context, *request.ReviewQueryRequest, *web.PaginationRequest) (*[]response.FindReviewResponse, *web.Metadata, error)
 	FindByID(*gin.Context, uint) (*response.FindReviewResponse, error)
 	FindByUserID(*gin.Context, *web.PaginationRequest, uint) (*[]response.FindReviewResponse, *web.Metadata, error)
+	Feed(*gin.Context, uint, *request.FeedRequest) (*[]response.FindReviewResponse, *web.Metadata, error)
 }
 
-type reviewServiceImpl struct{}
+type reviewServiceImpl struct {
+	contentFilter ContentFilter
+	events        EventBus
+	newAccountAge time.Duration
+}
 
-func NewreviewService() ReviewService {
-	return &reviewServiceImpl{}
+// NewreviewService screens reviews with the content filter and holds the
+// reviews of accounts younger than MODERATION_NEW_ACCOUNT_DAYS for
+// moderation. Published reviews and new mentions are emitted as events.
+func NewreviewService(contentFilter ContentFilter, events EventBus) ReviewService {
+	return &reviewServiceImpl{
+		contentFilter: contentFilter,
+		events:        events,
+		newAccountAge: moderationNewAccountAge(),
+	}
 }
 
 func (service *reviewServiceImpl) Create(c *gin.Context, reviewCreateReq *request.ReviewCreateRequest, userID uint) (*response.ReviewResponse, error) {
 	db, logger := helper.GetDBAndLogger(c)
 
-	var responseReview response.ReviewResponse
-
 	newReview := entity.Review{
 		UserID:   userID,
 		CarID:    reviewCreateReq.CarID,
 		Title:    reviewCreateReq.Title,
 		Content:  reviewCreateReq.Content,
 		ImageUrl: reviewCreateReq.ImageUrl,
+		MediaID:  reviewCreateReq.MediaID,
 	}
 
-	if err := db.Model(&entity.Review{}).Create(&newReview).Take(&responseReview, "car_id = ?", newReview.CarID).Error; err != nil {
-		if pgErr, ok := err.(*pgconn.PgError); ok {
-			// violation foreign key car_id
-			if pgErr.Code == "23503" {
-				return nil, exceptions.NewCustomError(http.StatusNotFound, "Car not found")
-			}
-			// Handle duplicate key error
-			if pgErr.Code == "23505" {
-				return nil, exceptions.NewCustomError(http.StatusConflict, "You have reviewed this car")
-			}
+	if newReview.MediaID != nil {
+		imageURL, err := mediaImageURL(db, *newReview.MediaID, &userID)
+		if err != nil {
+			return nil, err
+		}
+		newReview.ImageUrl = imageURL
+	}
+
+	// deleted cars still satisfy the foreign key
+	if err := db.Select("id").Take(&entity.Car{}, newReview.CarID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, exceptions.NewCustomError(http.StatusNotFound, "Car not found")
 		}
 		return nil, err
 	}
 
-	logger.Info("review created successfully", zap.Uint("reviewID", responseReview.ID), zap.Any("userID", userID))
+	newReview.Gallery = newGallery(newReview.ImageUrl, newReview.MediaID)
 
-	return &responseReview, nil
-}
+	status, err := initialStatus(db, userID, service.newAccountAge)
+	if err != nil {
+		return nil, err
+	}
+	newReview.Status = status
 
-func (service *reviewServiceImpl) Update(c *gin.Context, reviewUpdateReq *request.ReviewUpdateRequest, userID, reviewID uint) (*response.FindReviewResponse, error) {
-	db, logger := helper.GetDBAndLogger(c)
+	screened, err := screenContent(db, service.contentFilter, &ContentItem{Type: entity.ReportTargetReview, UserID: userID, Text: service.screeningText(&newReview)})
+	if err != nil {
+		return nil, err
+	}
+	newReview.ContentHash = screened.Hash
+	newReview.Screening = screened.Screening
+	if screened.Held {
+		newReview.Status = entity.StatusPending
+	}
 
-	responseReview, err := service.FindByID(c, reviewID)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&newReview).Error; err != nil {
+			if pgErr, ok := err.(*pgconn.PgError); ok {
+				// violation foreign key car_id
+				if pgErr.Code == "23503" {
+					return exceptions.NewCustomError(http.StatusNotFound, "Car not found")
+				}
+				// Handle duplicate key error
+				if pgErr.Code == "23505" {
+					return exceptions.NewCustomError(http.StatusConflict, "You have reviewed this car")
+				}
+			}
+			return err
+		}
 
+		mentioned, err := saveMentions(tx, entity.MentionOwnerReview, newReview.ID, userID, newReview.Content)
+		if err != nil || newReview.Status != entity.StatusPublished {
+			return err
+		}
+
+		return service.events.Publish(tx,
+			&ReviewPublishedEvent{ReviewID: newReview.ID, CarID: newReview.CarID, AuthorID: userID},
+			&UserMentionedEvent{OwnerType: entity.MentionOwnerReview, OwnerID: newReview.ID, AuthorID: userID, UserIDs: mentioned})
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	result := db.Model(&entity.Review{}).
-		Where("id = ?", reviewID).
-		Where("user_id = ?", userID).
-		Updates(reviewUpdateReq).
-		Take(&responseReview, "id = ?", reviewID)
-
-	if result.Error != nil {
+	mentions, err := loadMentions(db, entity.MentionOwnerReview, []uint{newReview.ID})
+	if err != nil {
 		return nil, err
 	}
 
-	if result.RowsAffected == 0 {
-		return nil, exceptions.NewCustomError(http.StatusNotFound, "review not found")
+	logger.Info("review created successfully", zap.Uint("reviewID", newReview.ID), zap.Any("userID", userID))
+
+	return &response.ReviewResponse{
+		ID:        newReview.ID,
+		CarID:     newReview.CarID,
+		UserID:    newReview.UserID,
+		Title:     newReview.Title,
+		Content:   newReview.Content,
+		ImageUrl:  newReview.ImageUrl,
+		MediaID:   newReview.MediaID,
+		Status:    newReview.Status,
+		Mentions:  append([]response.MentionResponse{}, mentions[newReview.ID]...),
+		CreatedAt: newReview.CreatedAt,
+		UpdatedAt: newReview.UpdatedAt,
+	}, nil
+}
+
+func (service *reviewServiceImpl) Update(c *gin.Context, reviewUpdateReq *request.ReviewUpdateRequest, userID, reviewID uint) (*response.FindReviewResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var before entity.Review
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userID).Take(&before, reviewID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return exceptions.NewCustomError(http.StatusNotFound, "review not found")
+			}
+			return err
+		}
+
+		if reviewUpdateReq.MediaID != nil {
+			imageURL, err := mediaImageURL(tx, *reviewUpdateReq.MediaID, &userID)
+			if err != nil {
+				return err
+			}
+			reviewUpdateReq.ImageUrl = &imageURL
+		}
+
+		if err := tx.Model(&entity.Review{}).Where("id = ?", reviewID).Updates(reviewUpdateReq).Error; err != nil {
+			return err
+		}
+
+		if reviewUpdateReq.ImageUrl != nil {
+			if err := setGalleryCover(tx, entity.GalleryOwnerReview, reviewID, *reviewUpdateReq.ImageUrl, reviewUpdateReq.MediaID); err != nil {
+				return err
+			}
+		}
+
+		var after entity.Review
+		if err := tx.Take(&after, reviewID).Error; err != nil {
+			return err
+		}
+
+		screened, err := screenContent(tx, service.contentFilter, &ContentItem{Type: entity.ReportTargetReview, ID: reviewID, UserID: userID, Text: service.screeningText(&after)})
+		if err != nil {
+			return err
+		}
+
+		screening := map[string]any{"content_hash": screened.Hash, "screening": screened.Screening}
+		if screened.Held && after.Status == entity.StatusPublished {
+			screening["status"] = entity.StatusPending
+		}
+
+		if err := tx.Model(&after).UpdateColumns(screening).Error; err != nil {
+			return err
+		}
+
+		mentioned, err := saveMentions(tx, entity.MentionOwnerReview, reviewID, userID, after.Content)
+		if err != nil {
+			return err
+		}
+
+		if after.Status == entity.StatusPublished && !screened.Held {
+			if err := service.events.Publish(tx, &UserMentionedEvent{OwnerType: entity.MentionOwnerReview, OwnerID: reviewID, AuthorID: userID, UserIDs: mentioned}); err != nil {
+				return err
+			}
+		}
+
+		edited, err := recordRevision(tx, entity.RevisionOwnerReview, reviewID, &userID, service.toReviewSnapshot(&before), service.toReviewSnapshot(&after))
+		if err != nil || !edited {
+			return err
+		}
+
+		return tx.Model(&after).UpdateColumn("edited_at", time.Now()).Error
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	logger.Info("review created successfully", zap.Uint("reviewID", responseReview.ID), zap.Any("userID", userID))
+	logger.Info("review updated successfully", zap.Uint("reviewID", reviewID), zap.Any("userID", userID))
 
-	return responseReview, nil
+	return service.FindByID(c, reviewID)
 }
 
 func (service *reviewServiceImpl) Delete(c *gin.Context, userID, reviewID uint) error {
 	db, logger := helper.GetDBAndLogger(c)
 
 	err := db.Transaction(func(tx *gorm.DB) error {
-		err := tx.Where("review_id = ?", reviewID).Delete(&entity.Comment{}).Error
-		helper.PanicIfError(err)
+		// the comments share the deletion time of the review, restoring the
+		// review restores exactly them
+		now := time.Now()
 
-		result := tx.Where("id = ?", reviewID).Where("user_id = ?", userID).Delete(&entity.Review{})
+		result := tx.Model(&entity.Review{}).Where("id = ?", reviewID).Where("user_id = ?", userID).UpdateColumn("deleted_at", now)
 
 		if result.Error != nil {
 			return result.Error
@@ -108,7 +237,7 @@ func (service *reviewServiceImpl) Delete(c *gin.Context, userID, reviewID uint)
 			return exceptions.NewCustomError(http.StatusNotFound, "review not found")
 		}
 
-		return nil
+		return tx.Model(&entity.Comment{}).Where("review_id = ?", reviewID).UpdateColumn("deleted_at", now).Error
 	})
 	helper.PanicIfError(err)
 
@@ -123,21 +252,18 @@ func (service *reviewServiceImpl) FindAll(c *gin.Context, reviewQueryReq *reques
 	var reviews []map[string]interface{}
 
 	query := db.Table("reviews").
-		Order("created_at desc").
+		Where("reviews.deleted_at IS NULL").
+		Where("reviews.status = ?", entity.StatusPublished).
 		Select("reviews.*, reviews.id as review_id, cars.id as car_id, users.username, users.id as user_id").
 		Joins("left join cars on reviews.car_id = cars.id").
 		Joins("left join users on reviews.user_id = users.id")
 
-	if reviewQueryReq.Title != nil {
-		query = query.Where("to_tsvector('english', reviews.title) @@ plainto_tsquery('english', ?)", *reviewQueryReq.Title)
-	}
-
-	if reviewQueryReq.CarID != nil {
-		query = query.Where("reviews.car_id = ?", *reviewQueryReq.CarID)
-	}
+	query = filterReviews(query, reviewQueryReq)
 
 	query.Count(&paging.TotalData)
 
+	query = sortReviews(query, reviewQueryReq.Sort)
+
 	offset := (paging.Page - 1) * paging.Limit
 	query = query.Limit(paging.Limit).Offset(offset)
 
@@ -150,25 +276,19 @@ func (service *reviewServiceImpl) FindAll(c *gin.Context, reviewQueryReq *reques
 	var responseReviews []response.FindReviewResponse
 
 	for _, v := range reviews {
-		review := response.FindReviewResponse{
-			ID:        uint(v["review_id"].(int64)),
-			Title:     v["title"].(string),
-			Content:   v["content"].(string),
-			ImageUrl:  v["image_url"].(string),
-			CreatedAt: v["created_at"].(time.Time),
-			UpdatedAt: v["updated_at"].(time.Time),
-			Car: response.ReviewCarResponse{
-				ID: uint(v["car_id"].(int64)),
-			},
-			User: response.ReviewUserResponse{
-				ID:       uint(v["user_id"].(int64)),
-				Username: v["username"].(string),
-			},
-		}
+		review := *service.toFindReviewResponse(v)
 
 		responseReviews = append(responseReviews, review)
 	}
 
+	if err := service.attachMentions(db, responseReviews); err != nil {
+		return nil, nil, err
+	}
+
+	if err := service.attachGalleries(db, responseReviews); err != nil {
+		return nil, nil, err
+	}
+
 	metadata := web.Metadata{
 		Page:       &paging.Page,
 		Limit:      &paging.Limit,
@@ -179,12 +299,43 @@ func (service *reviewServiceImpl) FindAll(c *gin.Context, reviewQueryReq *reques
 	return &responseReviews, &metadata, nil
 }
 
+func filterReviews(query *gorm.DB, reviewQueryReq *request.ReviewQueryRequest) *gorm.DB {
+	if reviewQueryReq.Title != nil {
+		query = query.Where("to_tsvector('english', reviews.title) @@ plainto_tsquery('english', ?)", *reviewQueryReq.Title)
+	}
+
+	if reviewQueryReq.CarID != nil {
+		query = query.Where("reviews.car_id = ?", *reviewQueryReq.CarID)
+	}
+
+	return query
+}
+
+// sortReviews orders reviews, newest first by default. helpful ranks by the
+// Wilson score of the votes and rating by helpful minus unhelpful votes.
+func sortReviews(query *gorm.DB, sort *string) *gorm.DB {
+	if sort == nil {
+		return query.Order("reviews.created_at desc")
+	}
+
+	switch *sort {
+	case "helpful":
+		return query.Order("reviews.helpful_score desc, reviews.helpful_count desc, reviews.created_at desc")
+	case "rating":
+		return query.Order("reviews.helpful_count - reviews.unhelpful_count desc, reviews.created_at desc")
+	case "oldest":
+		return query.Order("reviews.created_at")
+	default:
+		return query.Order("reviews.created_at desc")
+	}
+}
+
 func (service *reviewServiceImpl) FindByID(c *gin.Context, reviewId uint) (*response.FindReviewResponse, error) {
 	db, _ := helper.GetDBAndLogger(c)
 
 	var review map[string]any
 
-	if err := db.Table("reviews").Select("reviews.*, reviews.id as review_id, cars.id as car_id, users.username, users.id as user_id").
+	if err := visibleContent(c, db.Table("reviews").Where("reviews.deleted_at IS NULL"), "reviews").Select("reviews.*, reviews.id as review_id, cars.id as car_id, users.username, users.id as user_id").
 		Joins("left join cars on reviews.car_id = cars.id").
 		Joins("left join users on reviews.user_id = users.id").
 		Take(&review, "reviews.id = ?", reviewId).Error; err != nil {
@@ -194,31 +345,26 @@ func (service *reviewServiceImpl) FindByID(c *gin.Context, reviewId uint) (*resp
 		return nil, err
 	}
 
-	responseReview := response.FindReviewResponse{
-		ID:        uint(review["review_id"].(int64)),
-		Title:     review["title"].(string),
-		Content:   review["content"].(string),
-		ImageUrl:  review["image_url"].(string),
-		CreatedAt: review["created_at"].(time.Time),
-		UpdatedAt: review["updated_at"].(time.Time),
-		Car: response.ReviewCarResponse{
-			ID: uint(review["car_id"].(int64)),
-		},
-		User: response.ReviewUserResponse{
-			ID:       uint(review["user_id"].(int64)),
-			Username: review["username"].(string),
-		},
+	responseReviews := []response.FindReviewResponse{*service.toFindReviewResponse(review)}
+
+	if err := service.attachMentions(db, responseReviews); err != nil {
+		return nil, err
 	}
 
-	return &responseReview, nil
+	if err := service.attachGalleries(db, responseReviews); err != nil {
+		return nil, err
+	}
+
+	return &responseReviews[0], nil
 }
 func (service *reviewServiceImpl) FindByUserID(c *gin.Context, paging *web.PaginationRequest, userID uint) (*[]response.FindReviewResponse, *web.Metadata, error) {
 	db, _ := helper.GetDBAndLogger(c)
 
 	var reviews []map[string]interface{}
 
-	query := db.Table("reviews").
-		Order("created_at desc").
+	query := visibleContent(c, db.Table("reviews").Where("reviews.deleted_at IS NULL"), "reviews").
+		Where("reviews.user_id = ?", userID).
+		Order("reviews.created_at desc").
 		Select("reviews.*, reviews.id as review_id, cars.id as car_id, users.username, users.id as user_id").
 		Joins("left join cars on reviews.car_id = cars.id").
 		Joins("left join users on reviews.user_id = users.id")
@@ -227,7 +373,7 @@ func (service *reviewServiceImpl) FindByUserID(c *gin.Context, paging *web.Pagin
 	offset := (paging.Page - 1) * paging.Limit
 	query = query.Limit(paging.Limit).Offset(offset)
 
-	if err := query.Find(&reviews, "reviews.user_id = ?", userID).Error; err != nil {
+	if err := query.Find(&reviews).Error; err != nil {
 		return nil, nil, err
 	}
 
@@ -236,25 +382,19 @@ func (service *reviewServiceImpl) FindByUserID(c *gin.Context, paging *web.Pagin
 	var responseReviews []response.FindReviewResponse
 
 	for _, v := range reviews {
-		review := response.FindReviewResponse{
-			ID:        uint(v["review_id"].(int64)),
-			Title:     v["title"].(string),
-			Content:   v["content"].(string),
-			ImageUrl:  v["image_url"].(string),
-			CreatedAt: v["created_at"].(time.Time),
-			UpdatedAt: v["updated_at"].(time.Time),
-			Car: response.ReviewCarResponse{
-				ID: uint(v["car_id"].(int64)),
-			},
-			User: response.ReviewUserResponse{
-				ID:       uint(v["user_id"].(int64)),
-				Username: v["username"].(string),
-			},
-		}
+		review := *service.toFindReviewResponse(v)
 
 		responseReviews = append(responseReviews, review)
 	}
 
+	if err := service.attachMentions(db, responseReviews); err != nil {
+		return nil, nil, err
+	}
+
+	if err := service.attachGalleries(db, responseReviews); err != nil {
+		return nil, nil, err
+	}
+
 	metadata := web.Metadata{
 		Page:       &paging.Page,
 		Limit:      &paging.Limit,
@@ -264,3 +404,232 @@ func (service *reviewServiceImpl) FindByUserID(c *gin.Context, paging *web.Pagin
 
 	return &responseReviews, &metadata, nil
 }
+
+// Feed is the published reviews by the users, and of the brands and cars,
+// that the user follows, latest first. Each of the three is read on its own
+// index up to the page size before they are merged, so the cost depends on
+// the page size rather than on how much the user follows. The next cursor is
+// in the metadata while there are more reviews.
+func (service *reviewServiceImpl) Feed(c *gin.Context, userID uint, feedReq *request.FeedRequest) (*[]response.FindReviewResponse, *web.Metadata, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	limit := feedReq.Limit
+	if limit == 0 {
+		limit = 20
+	}
+
+	var after *feedCursor
+	if feedReq.Cursor != "" {
+		cursor, err := decodeFeedCursor(feedReq.Cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+		after = cursor
+	}
+
+	followed := func(targetType string) *gorm.DB {
+		return db.Model(&entity.Follow{}).Select("target_id").Where("follower_id = ? AND target_type = ?", userID, targetType)
+	}
+
+	source := func(condition string, args ...any) *gorm.DB {
+		query := db.Model(&entity.Review{}).
+			Select("id, created_at").
+			Where("status = ? AND user_id <> ?", entity.StatusPublished, userID).
+			Where(condition, args...)
+		if after != nil {
+			query = query.Where("(created_at, id) < (?, ?)", after.CreatedAt, after.ID)
+		}
+		// one more to know if there is a next page
+		return query.Order("created_at desc, id desc").Limit(limit + 1)
+	}
+
+	var page []struct {
+		ID        uint
+		CreatedAt time.Time
+	}
+
+	if err := db.Table("((?) UNION (?) UNION (?)) AS feed",
+		source("user_id IN (?)", followed(entity.FollowTargetUser)),
+		source("car_id IN (?)", followed(entity.FollowTargetCar)),
+		source("car_id IN (?)", db.Model(&entity.Car{}).Select("id").Where("brand_id IN (?)", followed(entity.FollowTargetBrand))),
+	).
+		Order("created_at desc, id desc").
+		Limit(limit + 1).
+		Scan(&page).Error; err != nil {
+		return nil, nil, err
+	}
+
+	metadata := web.Metadata{Limit: &limit}
+
+	if len(page) > limit {
+		page = page[:limit]
+		next := encodeFeedCursor(&feedCursor{CreatedAt: page[limit-1].CreatedAt, ID: page[limit-1].ID})
+		metadata.NextCursor = &next
+	}
+
+	responseReviews := []response.FindReviewResponse{}
+	if len(page) == 0 {
+		return &responseReviews, &metadata, nil
+	}
+
+	reviewIDs := make([]uint, len(page))
+	for i, review := range page {
+		reviewIDs[i] = review.ID
+	}
+
+	var reviews []map[string]interface{}
+
+	if err := db.Table("reviews").
+		Select("reviews.*, reviews.id as review_id, cars.id as car_id, users.username, users.id as user_id").
+		Joins("left join cars on reviews.car_id = cars.id").
+		Joins("left join users on reviews.user_id = users.id").
+		Where("reviews.id IN ?", reviewIDs).
+		Order("reviews.created_at desc, reviews.id desc").
+		Find(&reviews).Error; err != nil {
+		return nil, nil, err
+	}
+
+	for _, v := range reviews {
+		responseReviews = append(responseReviews, *service.toFindReviewResponse(v))
+	}
+
+	if err := service.attachMentions(db, responseReviews); err != nil {
+		return nil, nil, err
+	}
+
+	if err := service.attachGalleries(db, responseReviews); err != nil {
+		return nil, nil, err
+	}
+
+	return &responseReviews, &metadata, nil
+}
+
+func (service *reviewServiceImpl) toFindReviewResponse(review map[string]any) *response.FindReviewResponse {
+	var mediaID *uint
+	if id, ok := review["media_id"].(int64); ok {
+		value := uint(id)
+		mediaID = &value
+	}
+
+	var editedAt *time.Time
+	if at, ok := review["edited_at"].(time.Time); ok {
+		editedAt = &at
+	}
+
+	return &response.FindReviewResponse{
+		ID:             uint(review["review_id"].(int64)),
+		Title:          review["title"].(string),
+		Content:        review["content"].(string),
+		ImageUrl:       review["image_url"].(string),
+		MediaID:        mediaID,
+		Status:         review["status"].(string),
+		Gallery:        []response.GalleryImageResponse{},
+		Mentions:       []response.MentionResponse{},
+		HelpfulCount:   int(review["helpful_count"].(int64)),
+		UnhelpfulCount: int(review["unhelpful_count"].(int64)),
+		HelpfulScore:   review["helpful_score"].(float64),
+		Edited:         editedAt != nil,
+		EditedAt:       editedAt,
+		CreatedAt:      review["created_at"].(time.Time),
+		UpdatedAt:      review["updated_at"].(time.Time),
+		Car: response.ReviewCarResponse{
+			ID: uint(review["car_id"].(int64)),
+		},
+		User: response.ReviewUserResponse{
+			ID:       uint(review["user_id"].(int64)),
+			Username: review["username"].(string),
+		},
+	}
+}
+
+// screeningText is what the content filter reads of a review.
+func (service *reviewServiceImpl) screeningText(review *entity.Review) string {
+	return review.Title + "\n" + review.Content
+}
+
+// toReviewSnapshot is the review as recorded in its revisions.
+func (service *reviewServiceImpl) toReviewSnapshot(review *entity.Review) gin.H {
+	return gin.H{
+		"title":     review.Title,
+		"content":   review.Content,
+		"image_url": review.ImageUrl,
+		"media_id":  review.MediaID,
+	}
+}
+
+func (service *reviewServiceImpl) attachMentions(db *gorm.DB, reviews []response.FindReviewResponse) error {
+	reviewIDs := make([]uint, len(reviews))
+	for i, review := range reviews {
+		reviewIDs[i] = review.ID
+	}
+
+	mentions, err := loadMentions(db, entity.MentionOwnerReview, reviewIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range reviews {
+		if mentions, ok := mentions[reviews[i].ID]; ok {
+			reviews[i].Mentions = mentions
+		}
+	}
+
+	return nil
+}
+
+func (service *reviewServiceImpl) attachGalleries(db *gorm.DB, reviews []response.FindReviewResponse) error {
+	reviewIDs := make([]uint, len(reviews))
+	for i, review := range reviews {
+		reviewIDs[i] = review.ID
+	}
+
+	galleries, err := loadGalleries(db, entity.GalleryOwnerReview, reviewIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range reviews {
+		if gallery, ok := galleries[reviews[i].ID]; ok {
+			reviews[i].Gallery = gallery
+		}
+	}
+
+	return nil
+}
+
+// feedCursor is the last review of a feed page, the next page starts after it.
+type feedCursor struct {
+	CreatedAt time.Time
+	ID        uint
+}
+
+func encodeFeedCursor(cursor *feedCursor) string {
+	raw := strconv.FormatInt(cursor.CreatedAt.UnixMicro(), 10) + "." + strconv.FormatUint(uint64(cursor.ID), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeFeedCursor(encoded string) (*feedCursor, error) {
+	invalid := exceptions.NewCustomError(http.StatusBadRequest, "Invalid cursor")
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, invalid
+	}
+
+	micros, id, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return nil, invalid
+	}
+
+	createdAt, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, invalid
+	}
+
+	reviewID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, invalid
+	}
+
+	return &feedCursor{CreatedAt: time.UnixMicro(createdAt), ID: uint(reviewID)}, nil
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReviewVoteService interface {
+	Vote(*gin.Context, uint, uint, *request.ReviewVoteRequest) (*response.ReviewVoteResponse, error)
+	Unvote(*gin.Context, uint, uint) (*response.ReviewVoteResponse, error)
+}
+
+type reviewVoteServiceImpl struct{}
+
+func NewReviewVoteService() ReviewVoteService {
+	return &reviewVoteServiceImpl{}
+}
+
+// Vote records or changes the vote of the user on a published review of
+// someone else.
+func (service *reviewVoteServiceImpl) Vote(c *gin.Context, reviewID, userID uint, voteReq *request.ReviewVoteRequest) (*response.ReviewVoteResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	vote := entity.ReviewVote{
+		ReviewID: reviewID,
+		UserID:   userID,
+		Helpful:  *voteReq.Helpful,
+	}
+
+	var tally *response.ReviewVoteResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		review, err := findModerationTarget(tx, entity.ReportTargetReview, reviewID)
+		if err != nil {
+			return err
+		}
+
+		if review.Status != entity.StatusPublished {
+			return exceptions.NewCustomError(http.StatusNotFound, "review not found")
+		}
+
+		if review.UserID == userID {
+			return exceptions.NewCustomError(http.StatusBadRequest, "You cannot vote on your own review")
+		}
+
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "review_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"helpful", "updated_at"}),
+		}).Create(&vote).Error; err != nil {
+			return err
+		}
+
+		tally, err = refreshReviewVotes(tx, reviewID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("review voted successfully", zap.Uint("reviewID", reviewID), zap.Uint("userID", userID), zap.Bool("helpful", vote.Helpful))
+
+	tally.Helpful = &vote.Helpful
+
+	return tally, nil
+}
+
+func (service *reviewVoteServiceImpl) Unvote(c *gin.Context, reviewID, userID uint) (*response.ReviewVoteResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	var tally *response.ReviewVoteResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if _, err := findModerationTarget(tx, entity.ReportTargetReview, reviewID); err != nil {
+			return err
+		}
+
+		result := tx.Where("review_id = ? AND user_id = ?", reviewID, userID).Delete(&entity.ReviewVote{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return exceptions.NewCustomError(http.StatusNotFound, "Vote not found")
+		}
+
+		var err error
+		tally, err = refreshReviewVotes(tx, reviewID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("review vote removed successfully", zap.Uint("reviewID", reviewID), zap.Uint("userID", userID))
+
+	return tally, nil
+}
+
+// refreshReviewVotes recounts the votes of a review into its tallies. The
+// caller must hold a lock on the review row.
+func refreshReviewVotes(tx *gorm.DB, reviewID uint) (*response.ReviewVoteResponse, error) {
+	tally := response.ReviewVoteResponse{ReviewID: reviewID}
+
+	if err := tx.Model(&entity.ReviewVote{}).
+		Select("COUNT(*) FILTER (WHERE helpful) AS helpful_count, COUNT(*) FILTER (WHERE NOT helpful) AS unhelpful_count").
+		Where("review_id = ?", reviewID).
+		Scan(&tally).Error; err != nil {
+		return nil, err
+	}
+
+	tally.HelpfulScore = wilsonScore(tally.HelpfulCount, tally.HelpfulCount+tally.UnhelpfulCount)
+
+	if err := tx.Unscoped().Model(&entity.Review{}).Where("id = ?", reviewID).UpdateColumns(map[string]any{
+		"helpful_count":   tally.HelpfulCount,
+		"unhelpful_count": tally.UnhelpfulCount,
+		"helpful_score":   tally.HelpfulScore,
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	return &tally, nil
+}
+
+// deleteUserVotes removes the votes of a deleted user and recounts the
+// reviews they voted on.
+func deleteUserVotes(tx *gorm.DB, userID uint) error {
+	var reviewIDs []uint
+	if err := tx.Model(&entity.ReviewVote{}).Where("user_id = ?", userID).Order("review_id").Pluck("review_id", &reviewIDs).Error; err != nil {
+		return err
+	}
+
+	if len(reviewIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Unscoped().Model(&entity.Review{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", reviewIDs).Order("id").Pluck("id", &[]uint{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("user_id = ?", userID).Delete(&entity.ReviewVote{}).Error; err != nil {
+		return err
+	}
+
+	for _, reviewID := range reviewIDs {
+		if _, err := refreshReviewVotes(tx, reviewID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// wilsonScore is the lower bound of the 95% Wilson score interval of the
+// helpful share, so 95 helpful votes out of 100 outrank 1 out of 1.
+func wilsonScore(helpful, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	const z = 1.96
+
+	n := float64(total)
+	p := float64(helpful) / n
+
+	return (p + z*z/(2*n) - z*math.Sqrt((p*(1-p)+z*z/(4*n))/n)) / (1 + z*z/n)
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"gorm.io/gorm"
+)
+
+// RevisionService reads the edit history of cars and reviews, the owner type
+// is entity.RevisionOwnerCar or entity.RevisionOwnerReview. Revisions are
+// written by the car and review services through recordRevision.
+type RevisionService interface {
+	FindAll(*gin.Context, string, uint, *web.PaginationRequest) (*[]response.RevisionResponse, *web.Metadata, error)
+	FindByNumber(*gin.Context, string, uint, int) (*response.RevisionResponse, error)
+	Diff(*gin.Context, string, uint, *request.RevisionDiffRequest) (*response.RevisionDiffResponse, error)
+}
+
+type revisionServiceImpl struct{}
+
+func NewRevisionService() RevisionService {
+	return &revisionServiceImpl{}
+}
+
+type revisionRow struct {
+	entity.Revision
+	EditorUsername *string
+}
+
+func (service *revisionServiceImpl) FindAll(c *gin.Context, ownerType string, ownerID uint, paging *web.PaginationRequest) (*[]response.RevisionResponse, *web.Metadata, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	if err := findRevisionOwner(c, db, ownerType, ownerID); err != nil {
+		return nil, nil, err
+	}
+
+	var rows []revisionRow
+
+	query := service.query(db, ownerType, ownerID)
+
+	query.Count(&paging.TotalData)
+
+	offset := (paging.Page - 1) * paging.Limit
+
+	if err := service.selectEditor(query).Order("revisions.number desc").Limit(paging.Limit).Offset(offset).Scan(&rows).Error; err != nil {
+		return nil, nil, err
+	}
+
+	paging.TotalPages = int((paging.TotalData + int64(paging.Limit) - 1) / int64(paging.Limit))
+
+	revisions := []response.RevisionResponse{}
+	for _, row := range rows {
+		revisions = append(revisions, *toRevisionResponse(&row))
+	}
+
+	metadata := web.Metadata{
+		Page:       &paging.Page,
+		Limit:      &paging.Limit,
+		TotalPages: &paging.TotalPages,
+		TotalData:  &paging.TotalData,
+	}
+
+	return &revisions, &metadata, nil
+}
+
+func (service *revisionServiceImpl) FindByNumber(c *gin.Context, ownerType string, ownerID uint, number int) (*response.RevisionResponse, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	if err := findRevisionOwner(c, db, ownerType, ownerID); err != nil {
+		return nil, err
+	}
+
+	var row revisionRow
+
+	if err := service.selectEditor(service.query(db, ownerType, ownerID)).Where("revisions.number = ?", number).Take(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exceptions.NewCustomError(http.StatusNotFound, "Revision not found")
+		}
+		return nil, err
+	}
+
+	return toRevisionResponse(&row), nil
+}
+
+func (service *revisionServiceImpl) Diff(c *gin.Context, ownerType string, ownerID uint, revisionDiffReq *request.RevisionDiffRequest) (*response.RevisionDiffResponse, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	if err := findRevisionOwner(c, db, ownerType, ownerID); err != nil {
+		return nil, err
+	}
+
+	from, err := service.snapshot(db, ownerType, ownerID, revisionDiffReq.From)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := service.snapshot(db, ownerType, ownerID, revisionDiffReq.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.RevisionDiffResponse{
+		From: revisionDiffReq.From,
+		To:   revisionDiffReq.To,
+		Diff: snapshotDiff(from, to),
+	}, nil
+}
+
+func (service *revisionServiceImpl) query(db *gorm.DB, ownerType string, ownerID uint) *gorm.DB {
+	return db.Table("revisions").Where("revisions.owner_type = ? AND revisions.owner_id = ?", ownerType, ownerID)
+}
+
+func (service *revisionServiceImpl) selectEditor(query *gorm.DB) *gorm.DB {
+	return query.Select("revisions.*, users.username AS editor_username").
+		Joins("LEFT JOIN users ON users.id = revisions.editor_id")
+}
+
+// snapshot is the state after the given revision, 0 is the state before the
+// first one.
+func (service *revisionServiceImpl) snapshot(db *gorm.DB, ownerType string, ownerID uint, number int) (map[string]any, error) {
+	var revision entity.Revision
+
+	query := db.Where("owner_type = ? AND owner_id = ?", ownerType, ownerID)
+	if number == 0 {
+		query = query.Order("number")
+	} else {
+		query = query.Where("number = ?", number)
+	}
+
+	if err := query.Take(&revision).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exceptions.NewCustomError(http.StatusNotFound, "Revision not found")
+		}
+		return nil, err
+	}
+
+	raw := revision.After
+	if number == 0 {
+		raw = revision.Before
+	}
+
+	var snapshot map[string]any
+	if err := json.Unmarshal([]byte(raw), &snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// findRevisionOwner checks that the owner exists and can be seen by the
+// current user, hidden reviews keep their history to themselves.
+func findRevisionOwner(c *gin.Context, db *gorm.DB, ownerType string, ownerID uint) error {
+	var err error
+
+	switch ownerType {
+	case entity.RevisionOwnerCar:
+		if err = db.Select("id").Take(&entity.Car{}, ownerID).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return exceptions.NewCustomError(http.StatusNotFound, "Car not found")
+		}
+	case entity.RevisionOwnerReview:
+		if err = visibleContent(c, db.Select("id"), "reviews").Take(&entity.Review{}, ownerID).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+			return exceptions.NewCustomError(http.StatusNotFound, "review not found")
+		}
+	default:
+		return exceptions.NewCustomError(http.StatusNotFound, "Revisions not found")
+	}
+
+	return err
+}
+
+// recordRevision appends a revision inside the caller's transaction when the
+// snapshots before and after an edit differ, and reports whether it did. The
+// caller must hold a lock on the owner row so that revision numbers are not
+// taken twice.
+func recordRevision(tx *gorm.DB, ownerType string, ownerID uint, editorID *uint, before, after any) (bool, error) {
+	beforeMap, err := auditSnapshot(before)
+	if err != nil {
+		return false, err
+	}
+
+	afterMap, err := auditSnapshot(after)
+	if err != nil {
+		return false, err
+	}
+
+	diff := snapshotDiff(beforeMap, afterMap)
+	if len(diff) == 0 {
+		return false, nil
+	}
+
+	revision := entity.Revision{
+		OwnerType: ownerType,
+		OwnerID:   ownerID,
+		EditorID:  editorID,
+		CreatedAt: time.Now(),
+	}
+
+	if revision.Before, err = revisionJSON(beforeMap); err != nil {
+		return false, err
+	}
+
+	if revision.After, err = revisionJSON(afterMap); err != nil {
+		return false, err
+	}
+
+	if revision.Diff, err = revisionJSON(diff); err != nil {
+		return false, err
+	}
+
+	if err := tx.Model(&entity.Revision{}).
+		Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).
+		Select("COALESCE(MAX(number), 0) + 1").
+		Scan(&revision.Number).Error; err != nil {
+		return false, err
+	}
+
+	return true, tx.Create(&revision).Error
+}
+
+func revisionJSON(value any) (string, error) {
+	raw, err := json.Marshal(value)
+	return string(raw), err
+}
+
+// deleteRevisions removes the history of purged owners.
+func deleteRevisions(tx *gorm.DB, ownerType string, ownerIDs []uint) error {
+	return tx.Where("owner_type = ? AND owner_id IN ?", ownerType, ownerIDs).Delete(&entity.Revision{}).Error
+}
+
+func toRevisionResponse(row *revisionRow) *response.RevisionResponse {
+	return &response.RevisionResponse{
+		Number:         row.Number,
+		EditorID:       row.EditorID,
+		EditorUsername: row.EditorUsername,
+		Before:         json.RawMessage(row.Before),
+		After:          json.RawMessage(row.After),
+		Diff:           json.RawMessage(row.Diff),
+		CreatedAt:      row.CreatedAt,
+	}
+}
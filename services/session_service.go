@@ -0,0 +1,147 @@
+package services
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type SessionService interface {
+	FindAll(*gin.Context, uint) (*[]response.SessionResponse, error)
+	Revoke(*gin.Context, uint, uint) error
+	RevokeAll(*gin.Context, uint) error
+}
+
+type sessionServiceImpl struct{}
+
+func NewSessionService() SessionService {
+	return &sessionServiceImpl{}
+}
+
+func (service *sessionServiceImpl) FindAll(c *gin.Context, userID uint) (*[]response.SessionResponse, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	var sessions []entity.Session
+
+	if err := db.Where("user_id = ? AND expires_at > ?", userID, time.Now()).
+		Order("last_seen_at desc").
+		Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+
+	currentTokenID := utils.ExtractTokenID(c)
+
+	responseSessions := []response.SessionResponse{}
+	for _, session := range sessions {
+		responseSessions = append(responseSessions, response.SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			Current:    session.TokenID == currentTokenID,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+		})
+	}
+
+	return &responseSessions, nil
+}
+
+func (service *sessionServiceImpl) Revoke(c *gin.Context, userID, sessionID uint) error {
+	db, logger := helper.GetDBAndLogger(c)
+
+	result := db.Where("id = ? AND user_id = ?", sessionID, userID).Delete(&entity.Session{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return exceptions.NewCustomError(http.StatusNotFound, "Session not found")
+	}
+
+	logger.Info("session revoked successfully", zap.Uint("sessionID", sessionID), zap.Uint("userID", userID))
+
+	return nil
+}
+
+func (service *sessionServiceImpl) RevokeAll(c *gin.Context, userID uint) error {
+	db, logger := helper.GetDBAndLogger(c)
+
+	if err := revokeUserSessions(db, userID, ""); err != nil {
+		return err
+	}
+
+	logger.Info("all sessions revoked successfully", zap.Uint("userID", userID))
+
+	return nil
+}
+
+// issueSessionToken records a new session for the user and returns the jwt
+// bound to it. Expired sessions of the user are cleaned up on the way.
+// impersonatorID is the admin opening the session on behalf of the user, nil
+// for a regular login.
+func issueSessionToken(c *gin.Context, db *gorm.DB, user *entity.User, impersonatorID *uint) (string, error) {
+	if user.IsBanned(time.Now()) {
+		return "", exceptions.NewCustomError(http.StatusForbidden, "Account is suspended")
+	}
+
+	if user.PasswordResetRequired && impersonatorID == nil {
+		return "", exceptions.NewCustomError(http.StatusForbidden, "Password reset required, use forgot password to set a new one")
+	}
+
+	tokenID, err := utils.RandomURLSafeString(24)
+	if err != nil {
+		return "", err
+	}
+
+	expiresAt, err := utils.TokenExpiresAt()
+	if err != nil {
+		return "", err
+	}
+
+	userAgent := c.Request.UserAgent()
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND expires_at <= ?", user.ID, time.Now()).Delete(&entity.Session{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&entity.Session{
+			TokenID:        tokenID,
+			UserID:         user.ID,
+			ImpersonatorID: impersonatorID,
+			UserAgent:      userAgent,
+			IP:             c.ClientIP(),
+			LastSeenAt:     time.Now(),
+			ExpiresAt:      expiresAt,
+		}).Error
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return utils.GenerateToken(user.ID, user.Role, tokenID, expiresAt)
+}
+
+// revokeUserSessions deletes every session of the user except the one bound
+// to exceptTokenID, which may be empty to revoke them all.
+func revokeUserSessions(db *gorm.DB, userID uint, exceptTokenID string) error {
+	query := db.Where("user_id = ?", userID)
+	if exceptTokenID != "" {
+		query = query.Where("token_id <> ?", exceptTokenID)
+	}
+
+	return query.Delete(&entity.Session{}).Error
+}
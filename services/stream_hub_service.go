@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// StreamBackplane carries the stream events between the instances of the
+// application, every instance receives the events published by all of them,
+// its own included.
+type StreamBackplane interface {
+	Notify(tx *gorm.DB, event *entity.StreamEvent) error
+	Listen(ctx context.Context, deliver func(*entity.StreamEvent)) error
+}
+
+// NewStreamBackplane returns the backplane selected by STREAM_BACKPLANE,
+// either postgres (LISTEN/NOTIFY on DB_DSN) or memory for a single instance.
+func NewStreamBackplane(db *gorm.DB, logger *zap.Logger) StreamBackplane {
+	switch strings.ToLower(helper.GetEnv("STREAM_BACKPLANE", "postgres")) {
+	case "memory":
+		return NewMemoryStreamBackplane()
+	default:
+		return NewPostgresStreamBackplane(helper.MustGetEnv("DB_DSN"), db, logger)
+	}
+}
+
+type memoryStreamBackplane struct {
+	mutex     sync.RWMutex
+	listeners map[int]func(*entity.StreamEvent)
+	next      int
+}
+
+// NewMemoryStreamBackplane delivers the events in process as soon as they are
+// stored, before their transaction commits.
+func NewMemoryStreamBackplane() StreamBackplane {
+	return &memoryStreamBackplane{listeners: map[int]func(*entity.StreamEvent){}}
+}
+
+func (backplane *memoryStreamBackplane) Notify(tx *gorm.DB, event *entity.StreamEvent) error {
+	backplane.mutex.RLock()
+	defer backplane.mutex.RUnlock()
+
+	for _, deliver := range backplane.listeners {
+		deliver(event)
+	}
+
+	return nil
+}
+
+func (backplane *memoryStreamBackplane) Listen(ctx context.Context, deliver func(*entity.StreamEvent)) error {
+	backplane.mutex.Lock()
+	id := backplane.next
+	backplane.next++
+	backplane.listeners[id] = deliver
+	backplane.mutex.Unlock()
+
+	<-ctx.Done()
+
+	backplane.mutex.Lock()
+	delete(backplane.listeners, id)
+	backplane.mutex.Unlock()
+
+	return ctx.Err()
+}
+
+const streamChannel = "stream_events"
+
+// postgresStreamBackplane sends the ID of each event with NOTIFY inside the
+// transaction that stored it, so Postgres only delivers it on commit and the
+// listeners always find the row. The payload stays far below the 8000 bytes
+// NOTIFY accepts whatever the size of the event.
+type postgresStreamBackplane struct {
+	dsn    string
+	db     *gorm.DB
+	logger *zap.Logger
+}
+
+func NewPostgresStreamBackplane(dsn string, db *gorm.DB, logger *zap.Logger) StreamBackplane {
+	return &postgresStreamBackplane{dsn: dsn, db: db, logger: logger}
+}
+
+func (backplane *postgresStreamBackplane) Notify(tx *gorm.DB, event *entity.StreamEvent) error {
+	return tx.Exec("SELECT pg_notify(?, ?)", streamChannel, strconv.FormatUint(event.ID, 10)).Error
+}
+
+// Listen keeps a dedicated connection listening and reconnects when it drops,
+// waiting longer after each failure up to streamMaxBackoff. The events stored
+// in the meantime are caught up from the table.
+func (backplane *postgresStreamBackplane) Listen(ctx context.Context, deliver func(*entity.StreamEvent)) error {
+	cursor := &streamCursor{delivered: map[uint64]time.Time{}}
+	backoff := time.Second
+
+	for {
+		started := time.Now()
+		err := backplane.listen(ctx, cursor, deliver)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if time.Since(started) > streamMaxBackoff {
+			backoff = time.Second
+		}
+
+		backplane.logger.Error("stream backplane disconnected", zap.Error(err), zap.Duration("retryIn", backoff))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, streamMaxBackoff)
+	}
+}
+
+// streamCursor is the position of a listener in the stream events. An event
+// may commit after events with a higher ID, so the ones delivered within
+// streamLateWindow of the newest are remembered to catch up the late ones
+// without delivering any twice.
+type streamCursor struct {
+	started   bool
+	lastID    uint64
+	lastAt    time.Time
+	delivered map[uint64]time.Time
+}
+
+func (backplane *postgresStreamBackplane) listen(ctx context.Context, cursor *streamCursor, deliver func(*entity.StreamEvent)) error {
+	conn, err := pgx.Connect(ctx, backplane.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+streamChannel); err != nil {
+		return err
+	}
+
+	if !cursor.started {
+		// the events committed from now on are notified, the recent ones
+		// count as delivered so that only the late ones are caught up
+		var recent []entity.StreamEvent
+		if err := backplane.db.Select("id", "created_at").
+			Where("created_at > (SELECT MAX(created_at) FROM stream_events) - make_interval(secs => ?)", streamLateWindow.Seconds()).
+			Find(&recent).Error; err != nil {
+			return err
+		}
+
+		for i := range recent {
+			cursor.advance(&recent[i])
+		}
+		cursor.started = true
+	}
+
+	// events committed while no connection was listening, some of them may
+	// also be notified on the new connection
+	var missed []entity.StreamEvent
+	if err := backplane.db.Where("(id > ? OR created_at > ?)", cursor.lastID, cursor.lastAt.Add(-streamLateWindow)).Order("id").Find(&missed).Error; err != nil {
+		return err
+	}
+
+	for i := range missed {
+		backplane.deliver(cursor, &missed[i], deliver)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		id, err := strconv.ParseUint(notification.Payload, 10, 64)
+		if err != nil {
+			continue
+		}
+		if _, ok := cursor.delivered[id]; ok {
+			continue
+		}
+
+		var event entity.StreamEvent
+		if err := backplane.db.Take(&event, id).Error; err != nil {
+			// pruned already
+			if err == gorm.ErrRecordNotFound {
+				continue
+			}
+			return err
+		}
+
+		backplane.deliver(cursor, &event, deliver)
+	}
+}
+
+func (backplane *postgresStreamBackplane) deliver(cursor *streamCursor, event *entity.StreamEvent, deliver func(*entity.StreamEvent)) {
+	if _, ok := cursor.delivered[event.ID]; ok {
+		return
+	}
+
+	cursor.advance(event)
+	deliver(event)
+}
+
+// advance records the event as delivered and forgets the ones out of the
+// late window.
+func (cursor *streamCursor) advance(event *entity.StreamEvent) {
+	if event.ID > cursor.lastID {
+		cursor.lastID = event.ID
+	}
+	if event.CreatedAt.After(cursor.lastAt) {
+		cursor.lastAt = event.CreatedAt
+	}
+
+	cursor.delivered[event.ID] = event.CreatedAt
+	for id, createdAt := range cursor.delivered {
+		if createdAt.Before(cursor.lastAt.Add(-streamLateWindow)) {
+			delete(cursor.delivered, id)
+		}
+	}
+}
+
+// StreamSubscription receives the events of its topics until it is closed.
+// Events is closed when the subscriber is too slow to keep up, the client
+// then resumes from the last event it got.
+type StreamSubscription struct {
+	Topics []string
+	Events chan *entity.StreamEvent
+	closed bool
+}
+
+// StreamHub stores the events of a topic and fans them out to the
+// subscriptions of every instance through the backplane.
+type StreamHub interface {
+	Publish(tx *gorm.DB, topic, event string, data any) error
+	Subscribe(topics []string) *StreamSubscription
+	Unsubscribe(*StreamSubscription)
+	Replay(db *gorm.DB, topics []string, afterID uint64) ([]entity.StreamEvent, error)
+	Start(db *gorm.DB, logger *zap.Logger)
+}
+
+const (
+	streamBufferSize = 64
+	streamReplayMax  = 1000
+	streamLateWindow = 10 * time.Second
+	streamMaxBackoff = 30 * time.Second
+)
+
+type streamHubImpl struct {
+	backplane StreamBackplane
+	mutex     sync.RWMutex
+	topics    map[string]map[*StreamSubscription]bool
+}
+
+func NewStreamHub(backplane StreamBackplane) StreamHub {
+	return &streamHubImpl{
+		backplane: backplane,
+		topics:    map[string]map[*StreamSubscription]bool{},
+	}
+}
+
+// Publish stores the event in the caller's transaction and announces it on
+// the backplane.
+func (hub *streamHubImpl) Publish(tx *gorm.DB, topic, event string, data any) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	streamEvent := entity.StreamEvent{Topic: topic, Event: event, Data: string(encoded)}
+	if err := tx.Create(&streamEvent).Error; err != nil {
+		return err
+	}
+
+	return hub.backplane.Notify(tx, &streamEvent)
+}
+
+func (hub *streamHubImpl) Subscribe(topics []string) *StreamSubscription {
+	subscription := &StreamSubscription{Topics: topics, Events: make(chan *entity.StreamEvent, streamBufferSize)}
+
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	for _, topic := range topics {
+		if hub.topics[topic] == nil {
+			hub.topics[topic] = map[*StreamSubscription]bool{}
+		}
+		hub.topics[topic][subscription] = true
+	}
+
+	return subscription
+}
+
+func (hub *streamHubImpl) Unsubscribe(subscription *StreamSubscription) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	hub.close(subscription)
+}
+
+// close must be called with the lock held.
+func (hub *streamHubImpl) close(subscription *StreamSubscription) {
+	if subscription.closed {
+		return
+	}
+	subscription.closed = true
+
+	for _, topic := range subscription.Topics {
+		delete(hub.topics[topic], subscription)
+		if len(hub.topics[topic]) == 0 {
+			delete(hub.topics, topic)
+		}
+	}
+
+	close(subscription.Events)
+}
+
+// Replay returns the stored events of the topics after afterID, oldest first
+// and at most streamReplayMax of them. An event may commit after events with
+// a higher ID, so the ones stored within streamLateWindow before afterID are
+// returned too and the client drops those it already has.
+func (hub *streamHubImpl) Replay(db *gorm.DB, topics []string, afterID uint64) ([]entity.StreamEvent, error) {
+	var events []entity.StreamEvent
+	err := db.Where("topic IN ? AND id <> ?", topics, afterID).
+		Where("(id > ? OR created_at > (SELECT created_at FROM stream_events WHERE id = ?) - make_interval(secs => ?))", afterID, afterID, streamLateWindow.Seconds()).
+		Order("id").Limit(streamReplayMax).Find(&events).Error
+	return events, err
+}
+
+func (hub *streamHubImpl) deliver(event *entity.StreamEvent) {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+
+	for subscription := range hub.topics[event.Topic] {
+		select {
+		case subscription.Events <- event:
+		default:
+			hub.close(subscription)
+		}
+	}
+}
+
+// Start listens to the backplane and deletes the events older than
+// STREAM_RETENTION_MINUTES every minute.
+func (hub *streamHubImpl) Start(db *gorm.DB, logger *zap.Logger) {
+	retention := time.Duration(helper.GetEnvInt("STREAM_RETENTION_MINUTES", 60)) * time.Minute
+
+	go func() {
+		if err := hub.backplane.Listen(context.Background(), hub.deliver); err != nil {
+			logger.Error("stream backplane stopped", zap.Error(err))
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := db.Where("created_at < ?", time.Now().Add(-retention)).Delete(&entity.StreamEvent{}).Error; err != nil {
+				logger.Error("failed to prune stream events", zap.Error(err))
+			}
+		}
+	}()
+}
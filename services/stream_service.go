@@ -0,0 +1,216 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Stream topics, followed by a colon and an ID.
+const (
+	StreamTopicReview = "review"
+	StreamTopicUser   = "user"
+)
+
+// Stream event names.
+const (
+	StreamCommentCreated      = "comment.created"
+	StreamCommentUpdated      = "comment.updated"
+	StreamCommentDeleted      = "comment.deleted"
+	StreamNotificationCreated = "notification.created"
+)
+
+const streamMaxTopics = 20
+
+type StreamService interface {
+	Open(*gin.Context, *request.StreamRequest) (*StreamSubscription, []entity.StreamEvent, error)
+	Close(*StreamSubscription)
+}
+
+type streamServiceImpl struct {
+	hub StreamHub
+}
+
+// NewStreamService turns the domain events into stream events: the comments
+// of a review on review:<id> and the notifications of a user on user:<id>.
+func NewStreamService(hub StreamHub, events EventBus) StreamService {
+	service := &streamServiceImpl{hub: hub}
+
+	events.Subscribe(EventCommentPublished, service.onCommentPublished)
+	events.Subscribe(EventCommentUpdated, service.onCommentUpdated)
+	events.Subscribe(EventCommentDeleted, service.onCommentDeleted)
+	events.Subscribe(EventContentModerated, service.onContentModerated)
+	events.Subscribe(EventNotificationCreated, service.onNotificationCreated)
+
+	return service
+}
+
+// Open checks the topics and subscribes to them. With a last event ID, the
+// stored events after it are returned to be sent before the live ones.
+func (service *streamServiceImpl) Open(c *gin.Context, streamReq *request.StreamRequest) (*StreamSubscription, []entity.StreamEvent, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	topics, err := service.authorizeTopics(c, db, streamReq.Topics)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// subscribe first so that nothing is lost between the replay and the
+	// live events
+	subscription := service.hub.Subscribe(topics)
+
+	var backlog []entity.StreamEvent
+	if streamReq.LastEventID > 0 {
+		backlog, err = service.hub.Replay(db, topics, streamReq.LastEventID)
+		if err != nil {
+			service.hub.Unsubscribe(subscription)
+			return nil, nil, err
+		}
+	}
+
+	logger.Info("stream opened", zap.Strings("topics", topics), zap.Uint64("lastEventID", streamReq.LastEventID), zap.Int("replayed", len(backlog)))
+
+	return subscription, backlog, nil
+}
+
+func (service *streamServiceImpl) Close(subscription *StreamSubscription) {
+	service.hub.Unsubscribe(subscription)
+}
+
+// authorizeTopics resolves user:me and checks that the current user may read
+// every topic: reviews they can see and their own notifications.
+func (service *streamServiceImpl) authorizeTopics(c *gin.Context, db *gorm.DB, list string) ([]string, error) {
+	seen := map[string]bool{}
+	var topics []string
+
+	for _, topic := range strings.Split(list, ",") {
+		kind, id, _ := strings.Cut(strings.TrimSpace(topic), ":")
+
+		switch kind {
+		case StreamTopicReview:
+			reviewID, err := strconv.ParseUint(id, 10, 32)
+			if err != nil {
+				return nil, exceptions.NewCustomError(http.StatusBadRequest, "Review id must be an integer")
+			}
+
+			if err := visibleContent(c, db.Select("id"), "reviews").Take(&entity.Review{}, reviewID).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return nil, exceptions.NewCustomError(http.StatusNotFound, "Review not found")
+				}
+				return nil, err
+			}
+
+			topic = streamTopic(StreamTopicReview, uint(reviewID))
+		case StreamTopicUser:
+			if utils.ExtractToken(c) == "" {
+				return nil, exceptions.NewCustomError(http.StatusUnauthorized, "Login to subscribe to user topics")
+			}
+
+			userID, _, err := utils.ExtractTokenClaims(c)
+			if err != nil {
+				return nil, err
+			}
+
+			if id != "me" && id != strconv.FormatUint(uint64(userID), 10) {
+				return nil, exceptions.NewCustomError(http.StatusForbidden, "You can only subscribe to your own user topic")
+			}
+
+			topic = streamTopic(StreamTopicUser, userID)
+		default:
+			return nil, exceptions.NewCustomError(http.StatusBadRequest, fmt.Sprintf("Unknown topic %q", strings.TrimSpace(topic)))
+		}
+
+		if !seen[topic] {
+			seen[topic] = true
+			topics = append(topics, topic)
+		}
+	}
+
+	if len(topics) > streamMaxTopics {
+		return nil, exceptions.NewCustomError(http.StatusBadRequest, fmt.Sprintf("At most %d topics", streamMaxTopics))
+	}
+
+	return topics, nil
+}
+
+func (service *streamServiceImpl) onCommentPublished(tx *gorm.DB, event DomainEvent) error {
+	published := event.(*CommentPublishedEvent)
+	return service.publishComment(tx, StreamCommentCreated, published.CommentID, published.ReviewID)
+}
+
+func (service *streamServiceImpl) onCommentUpdated(tx *gorm.DB, event DomainEvent) error {
+	updated := event.(*CommentUpdatedEvent)
+	return service.publishComment(tx, StreamCommentUpdated, updated.CommentID, updated.ReviewID)
+}
+
+func (service *streamServiceImpl) onCommentDeleted(tx *gorm.DB, event DomainEvent) error {
+	deleted := event.(*CommentDeletedEvent)
+	return service.hub.Publish(tx, streamTopic(StreamTopicReview, deleted.ReviewID), StreamCommentDeleted, gin.H{"id": deleted.CommentID, "review_id": deleted.ReviewID})
+}
+
+// onContentModerated removes a comment hidden or rejected by a moderator from
+// the review and brings a hidden one back. Comments approved out of the queue
+// are pushed by onCommentPublished.
+func (service *streamServiceImpl) onContentModerated(tx *gorm.DB, event DomainEvent) error {
+	moderated := event.(*ContentModeratedEvent)
+
+	if moderated.TargetType != entity.ReportTargetComment {
+		return nil
+	}
+
+	var comment entity.Comment
+	if err := tx.Select("id", "review_id").Take(&comment, moderated.TargetID).Error; err != nil {
+		return err
+	}
+
+	switch {
+	case moderated.PreviousStatus == entity.StatusPublished && moderated.Status != entity.StatusPublished:
+		return service.onCommentDeleted(tx, &CommentDeletedEvent{CommentID: comment.ID, ReviewID: comment.ReviewID})
+	case moderated.PreviousStatus != entity.StatusPublished && moderated.PreviousStatus != entity.StatusPending && moderated.Status == entity.StatusPublished:
+		return service.publishComment(tx, StreamCommentCreated, comment.ID, comment.ReviewID)
+	default:
+		return nil
+	}
+}
+
+func (service *streamServiceImpl) onNotificationCreated(tx *gorm.DB, event DomainEvent) error {
+	created := event.(*NotificationCreatedEvent)
+
+	notifications, err := loadNotificationResponses(tx, created.NotificationIDs)
+	if err != nil {
+		return err
+	}
+
+	for userID, userNotifications := range notifications {
+		for _, notification := range userNotifications {
+			if err := service.hub.Publish(tx, streamTopic(StreamTopicUser, userID), StreamNotificationCreated, notification); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (service *streamServiceImpl) publishComment(tx *gorm.DB, name string, commentID, reviewID uint) error {
+	comment, err := loadCommentResponse(tx, commentID)
+	if err != nil {
+		return err
+	}
+
+	return service.hub.Publish(tx, streamTopic(StreamTopicReview, reviewID), name, comment)
+}
+
+func streamTopic(kind string, id uint) string {
+	return kind + ":" + strconv.FormatUint(uint64(id), 10)
+}
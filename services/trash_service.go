@@ -0,0 +1,377 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// The kinds of deleted records, named like their tables.
+const (
+	TrashCars     = "cars"
+	TrashBrands   = "brands"
+	TrashReviews  = "reviews"
+	TrashComments = "comments"
+)
+
+// trashLabels is the SQL expression shown for a deleted record of each kind.
+var trashLabels = map[string]string{
+	TrashCars:     "name",
+	TrashBrands:   "name",
+	TrashReviews:  "title",
+	TrashComments: "LEFT(content, 100)",
+}
+
+type TrashService interface {
+	FindAll(*gin.Context, string, *web.PaginationRequest) (*[]response.TrashItemResponse, *web.Metadata, error)
+	Restore(*gin.Context, string, uint) error
+	Purge(*gin.Context, time.Time) (*response.TrashPurgeResponse, error)
+}
+
+type trashServiceImpl struct {
+	retention time.Duration
+}
+
+// NewTrashService keeps deleted records for TRASH_RETENTION_DAYS.
+func NewTrashService() TrashService {
+	return &trashServiceImpl{
+		retention: trashRetention(),
+	}
+}
+
+func trashRetention() time.Duration {
+	return time.Duration(helper.GetEnvInt("TRASH_RETENTION_DAYS", 30)) * 24 * time.Hour
+}
+
+func (service *trashServiceImpl) FindAll(c *gin.Context, kind string, paging *web.PaginationRequest) (*[]response.TrashItemResponse, *web.Metadata, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	label, ok := trashLabels[kind]
+	if !ok {
+		return nil, nil, exceptions.NewCustomError(http.StatusNotFound, "Unknown trash")
+	}
+
+	items := []response.TrashItemResponse{}
+
+	query := db.Table(kind).Where("deleted_at IS NOT NULL")
+
+	query.Count(&paging.TotalData)
+
+	offset := (paging.Page - 1) * paging.Limit
+
+	if err := query.Select("id, " + label + " AS label, deleted_at").Order("deleted_at desc").Limit(paging.Limit).Offset(offset).Scan(&items).Error; err != nil {
+		return nil, nil, err
+	}
+
+	paging.TotalPages = int((paging.TotalData + int64(paging.Limit) - 1) / int64(paging.Limit))
+
+	for i := range items {
+		items[i].PurgeAt = items[i].DeletedAt.Add(service.retention)
+	}
+
+	metadata := web.Metadata{
+		Page:       &paging.Page,
+		Limit:      &paging.Limit,
+		TotalPages: &paging.TotalPages,
+		TotalData:  &paging.TotalData,
+	}
+
+	return &items, &metadata, nil
+}
+
+// Restore undeletes a record. A record whose parent is deleted cannot be
+// restored before the parent, and a review brings back the comments that
+// were deleted with it.
+func (service *trashServiceImpl) Restore(c *gin.Context, kind string, id uint) error {
+	db, logger := helper.GetDBAndLogger(c)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		switch kind {
+		case TrashCars:
+			var car entity.Car
+			if err := takeDeleted(tx, &car, id, "Car"); err != nil {
+				return err
+			}
+
+			if err := tx.Select("id").Take(&entity.Brand{}, car.BrandID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return exceptions.NewCustomError(http.StatusConflict, "Restore the brand of the car first")
+				}
+				return err
+			}
+
+			if err := tx.Unscoped().Model(&car).UpdateColumn("deleted_at", nil).Error; err != nil {
+				var pgErr *pgconn.PgError
+				if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+					return exceptions.NewCustomError(http.StatusConflict, "The car has been created again")
+				}
+				return err
+			}
+
+			reviews := tx.Unscoped().Model(&entity.Review{}).Select("id").Where("car_id = ? AND deleted_at = ?", id, car.DeletedAt.Time)
+			if err := tx.Unscoped().Model(&entity.Comment{}).
+				Where("review_id IN (?) AND deleted_at = ?", reviews, car.DeletedAt.Time).
+				UpdateColumn("deleted_at", nil).Error; err != nil {
+				return err
+			}
+
+			result := tx.Unscoped().Model(&entity.Review{}).
+				Where("car_id = ? AND deleted_at = ?", id, car.DeletedAt.Time).
+				UpdateColumn("deleted_at", nil)
+			if result.Error != nil {
+				return result.Error
+			}
+
+			return recordAudit(c, tx, &entity.AuditEvent{Action: AuditCarRestore, TargetType: AuditTargetCar, TargetID: id},
+				gin.H{"deleted_at": car.DeletedAt.Time}, gin.H{"deleted_at": nil, "restored_reviews": result.RowsAffected})
+		case TrashBrands:
+			var brand entity.Brand
+			if err := takeDeleted(tx, &brand, id, "Brand"); err != nil {
+				return err
+			}
+
+			if err := tx.Unscoped().Model(&brand).UpdateColumn("deleted_at", nil).Error; err != nil {
+				var pgErr *pgconn.PgError
+				if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+					return exceptions.NewCustomError(http.StatusConflict, "A brand with this name has been created again")
+				}
+				return err
+			}
+
+			return recordAudit(c, tx, &entity.AuditEvent{Action: AuditBrandRestore, TargetType: AuditTargetBrand, TargetID: id},
+				gin.H{"deleted_at": brand.DeletedAt.Time}, gin.H{"deleted_at": nil})
+		case TrashReviews:
+			var review entity.Review
+			if err := takeDeleted(tx, &review, id, "Review"); err != nil {
+				return err
+			}
+
+			if err := tx.Unscoped().Model(&review).UpdateColumn("deleted_at", nil).Error; err != nil {
+				var pgErr *pgconn.PgError
+				if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+					return exceptions.NewCustomError(http.StatusConflict, "The user has reviewed this car again")
+				}
+				return err
+			}
+
+			result := tx.Unscoped().Model(&entity.Comment{}).
+				Where("review_id = ? AND deleted_at = ?", id, review.DeletedAt.Time).
+				UpdateColumn("deleted_at", nil)
+			if result.Error != nil {
+				return result.Error
+			}
+
+			return recordAudit(c, tx, &entity.AuditEvent{Action: AuditReviewRestore, TargetType: AuditTargetReview, TargetID: id},
+				gin.H{"deleted_at": review.DeletedAt.Time}, gin.H{"deleted_at": nil, "restored_comments": result.RowsAffected})
+		case TrashComments:
+			var comment entity.Comment
+			if err := takeDeleted(tx, &comment, id, "Comment"); err != nil {
+				return err
+			}
+
+			if err := tx.Select("id").Take(&entity.Review{}, comment.ReviewID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return exceptions.NewCustomError(http.StatusConflict, "Restore the review of the comment first")
+				}
+				return err
+			}
+
+			if err := tx.Unscoped().Model(&comment).UpdateColumn("deleted_at", nil).Error; err != nil {
+				return err
+			}
+
+			return recordAudit(c, tx, &entity.AuditEvent{Action: AuditCommentRestore, TargetType: AuditTargetComment, TargetID: id},
+				gin.H{"deleted_at": comment.DeletedAt.Time}, gin.H{"deleted_at": nil})
+		default:
+			return exceptions.NewCustomError(http.StatusNotFound, "Unknown trash")
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("record restored successfully", zap.String("kind", kind), zap.Uint("id", id))
+
+	return nil
+}
+
+func takeDeleted(tx *gorm.DB, dest any, id uint, name string) error {
+	if err := tx.Unscoped().Where("deleted_at IS NOT NULL").Take(dest, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exceptions.NewCustomError(http.StatusNotFound, name+" not found in trash")
+		}
+		return err
+	}
+	return nil
+}
+
+func (service *trashServiceImpl) Purge(c *gin.Context, before time.Time) (*response.TrashPurgeResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	return PurgeTrash(db, logger, before)
+}
+
+// PurgeTrash permanently deletes the records deleted before the given time,
+// children first. Cars that still have reviews and brands that still have
+// cars are kept until those are gone.
+func PurgeTrash(db *gorm.DB, logger *zap.Logger, before time.Time) (*response.TrashPurgeResponse, error) {
+	var purged response.TrashPurgeResponse
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("comment_id IN (?)", tx.Unscoped().Model(&entity.Comment{}).Select("id").Where("deleted_at < ?", before)).
+			Delete(&entity.CommentReaction{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("owner_type = ? AND owner_id IN (?)", entity.MentionOwnerComment, tx.Unscoped().Model(&entity.Comment{}).Select("id").Where("deleted_at < ?", before)).
+			Delete(&entity.Mention{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("deleted_at < ?", before).Delete(&entity.Comment{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purged.Comments = result.RowsAffected
+
+		var reviewIDs []uint
+		if err := tx.Unscoped().Model(&entity.Review{}).Where("deleted_at < ?", before).Pluck("id", &reviewIDs).Error; err != nil {
+			return err
+		}
+
+		if len(reviewIDs) > 0 {
+			if err := tx.Where("comment_id IN (?)", tx.Unscoped().Model(&entity.Comment{}).Select("id").Where("review_id IN ?", reviewIDs)).
+				Delete(&entity.CommentReaction{}).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Where("owner_type = ? AND owner_id IN (?)", entity.MentionOwnerComment, tx.Unscoped().Model(&entity.Comment{}).Select("id").Where("review_id IN ?", reviewIDs)).
+				Delete(&entity.Mention{}).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Unscoped().Where("review_id IN ?", reviewIDs).Delete(&entity.Comment{}).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Unscoped().Where("owner_type = ? AND owner_id IN ?", entity.GalleryOwnerReview, reviewIDs).Delete(&entity.GalleryImage{}).Error; err != nil {
+				return err
+			}
+
+			if err := deleteRevisions(tx, entity.RevisionOwnerReview, reviewIDs); err != nil {
+				return err
+			}
+
+			if err := deleteMentions(tx, entity.MentionOwnerReview, reviewIDs); err != nil {
+				return err
+			}
+
+			if err := tx.Where("review_id IN ?", reviewIDs).Delete(&entity.ReviewVote{}).Error; err != nil {
+				return err
+			}
+
+			result = tx.Unscoped().Where("id IN ?", reviewIDs).Delete(&entity.Review{})
+			if result.Error != nil {
+				return result.Error
+			}
+			purged.Reviews = result.RowsAffected
+		}
+
+		var carIDs []uint
+		if err := tx.Unscoped().Model(&entity.Car{}).
+			Where("deleted_at < ?", before).
+			Where("NOT EXISTS (SELECT 1 FROM reviews WHERE reviews.car_id = cars.id)").
+			Pluck("id", &carIDs).Error; err != nil {
+			return err
+		}
+
+		if len(carIDs) > 0 {
+			if err := tx.Unscoped().Where("car_id IN ?", carIDs).Delete(&entity.Favourite{}).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Unscoped().Where("car_id IN ?", carIDs).Delete(&entity.CarSpecification{}).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Where("target_type = ? AND target_id IN ?", entity.FollowTargetCar, carIDs).Delete(&entity.Follow{}).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Unscoped().Where("owner_type = ? AND owner_id IN ?", entity.GalleryOwnerCar, carIDs).Delete(&entity.GalleryImage{}).Error; err != nil {
+				return err
+			}
+
+			if err := deleteRevisions(tx, entity.RevisionOwnerCar, carIDs); err != nil {
+				return err
+			}
+
+			result = tx.Unscoped().Where("id IN ?", carIDs).Delete(&entity.Car{})
+			if result.Error != nil {
+				return result.Error
+			}
+			purged.Cars = result.RowsAffected
+		}
+
+		var brandIDs []uint
+		if err := tx.Unscoped().Model(&entity.Brand{}).
+			Where("deleted_at < ?", before).
+			Where("NOT EXISTS (SELECT 1 FROM cars WHERE cars.brand_id = brands.id)").
+			Pluck("id", &brandIDs).Error; err != nil {
+			return err
+		}
+
+		if len(brandIDs) > 0 {
+			if err := tx.Where("target_type = ? AND target_id IN ?", entity.FollowTargetBrand, brandIDs).Delete(&entity.Follow{}).Error; err != nil {
+				return err
+			}
+
+			result = tx.Unscoped().Where("id IN ?", brandIDs).Delete(&entity.Brand{})
+			if result.Error != nil {
+				return result.Error
+			}
+			purged.Brands = result.RowsAffected
+		}
+
+		return deleteOrphanReports(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("trash purged successfully", zap.Time("before", before), zap.Any("purged", purged))
+
+	return &purged, nil
+}
+
+// StartTrashPurger purges the records older than TRASH_RETENTION_DAYS every
+// TRASH_PURGE_INTERVAL_MINUTES, 0 disables it.
+func StartTrashPurger(db *gorm.DB, logger *zap.Logger) {
+	interval := time.Duration(helper.GetEnvInt("TRASH_PURGE_INTERVAL_MINUTES", 60)) * time.Minute
+	if interval <= 0 {
+		return
+	}
+
+	retention := trashRetention()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for ; ; <-ticker.C {
+			if _, err := PurgeTrash(db, logger, time.Now().Add(-retention)); err != nil {
+				logger.Error("failed to purge trash", zap.Error(err))
+			}
+		}
+	}()
+}
@@ -20,22 +20,28 @@ type UserService interface {
 	ForgotPassword(*gin.Context, string, string) (*response.ForgotPasswordResponse, error)
 	ResetPassword(*gin.Context, string, string) error
 	Login(*gin.Context, string, string) (*response.LoginResponse, error)
-	UpdatePassword(*gin.Context, uint, string) error
+	UpdatePassword(*gin.Context, uint, string, string) error
 	GetUserProfile(*gin.Context, uint) (*response.UserProfileResponse, error)
 	UpdateUserProfile(*gin.Context, *entity.User, uint) (*response.UpdateUserProfileResponse, error)
 	DeleteUserProfile(*gin.Context, uint) error
 	GetCurrentUser(*gin.Context, uint) (*response.GetUserCurrentResponse, error)
 }
 
-type userServiceImpl struct{}
+type userServiceImpl struct {
+	passwordPolicy PasswordPolicyService
+}
 
-func NewUserService() UserService {
-	return &userServiceImpl{}
+func NewUserService(passwordPolicy PasswordPolicyService) UserService {
+	return &userServiceImpl{passwordPolicy}
 }
 
 func (service *userServiceImpl) Register(c *gin.Context, user *entity.User) (*response.RegisterResponse, error) {
 	db, logger := helper.GetDBAndLogger(c)
 
+	if err := service.passwordPolicy.Validate(user.Password, user.Username, user.Email); err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := helper.HashPassword(user.Password)
 	if err != nil {
 		return nil, err
@@ -71,7 +77,7 @@ func (service *userServiceImpl) Register(c *gin.Context, user *entity.User) (*re
 }
 
 func (service *userServiceImpl) Login(c *gin.Context, username, password string) (*response.LoginResponse, error) {
-	db, _ := helper.GetDBAndLogger(c)
+	db, logger := helper.GetDBAndLogger(c)
 
 	var err error
 
@@ -86,7 +92,17 @@ func (service *userServiceImpl) Login(c *gin.Context, username, password string)
 		return nil, exceptions.NewCustomError(http.StatusUnauthorized, "Email or password is incorrect")
 	}
 
-	token, err := utils.GenerateToken(user.ID, user.Role)
+	if helper.PasswordNeedsRehash(user.Password) {
+		if hashedPassword, err := helper.HashPassword(password); err == nil {
+			if err := db.Model(&entity.User{}).Where("id = ?", user.ID).Update("password", hashedPassword).Error; err != nil {
+				logger.Error("failed to rehash user password", zap.Uint("userID", user.ID), zap.Error(err))
+			} else {
+				logger.Info("user password rehashed", zap.Uint("userID", user.ID))
+			}
+		}
+	}
+
+	token, err := issueSessionToken(c, db, &user, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -101,15 +117,42 @@ func (service *userServiceImpl) Login(c *gin.Context, username, password string)
 	return &loginResponse, nil
 }
 
-func (service *userServiceImpl) UpdatePassword(c *gin.Context, userID uint, newPassword string) error {
+func (service *userServiceImpl) UpdatePassword(c *gin.Context, userID uint, currentPassword, newPassword string) error {
 	db, logger := helper.GetDBAndLogger(c)
 
+	var user entity.User
+	if err := db.Take(&user, "id = ?", userID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return exceptions.NewCustomError(http.StatusNotFound, "User not found")
+		}
+		return err
+	}
+
+	if err := helper.VerifyPassword(currentPassword, user.Password); err != nil {
+		return exceptions.NewCustomError(http.StatusBadRequest, "Current password is incorrect")
+	}
+
+	if err := service.passwordPolicy.Validate(newPassword, user.Username, user.Email); err != nil {
+		return err
+	}
+
 	hashedPassword, err := helper.HashPassword(newPassword)
 	if err != nil {
 		return err
 	}
 
-	if err := db.Model(&entity.User{}).Where("id = ?", userID).Update("password", hashedPassword).Error; err != nil {
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.User{}).Where("id = ?", userID).Update("password", hashedPassword).Error; err != nil {
+			return err
+		}
+
+		if err := revokeUserSessions(tx, userID, utils.ExtractTokenID(c)); err != nil {
+			return err
+		}
+
+		return recordAudit(c, tx, &entity.AuditEvent{ActorID: &userID, Action: AuditPasswordUpdate, TargetType: AuditTargetUser, TargetID: userID}, nil, nil)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -124,7 +167,7 @@ func (service *userServiceImpl) GetUserProfile(c *gin.Context, userID uint) (*re
 	var responseUser response.UserProfileResponse
 
 	if err := db.Model(&entity.User{}).
-		Select("users.id, users.username, users.role, users.email, profiles.user_id, profiles.full_name, profiles.bio, profiles.age, profiles.gender").
+		Select("users.id, users.username, users.role, users.email, users.follower_count, users.following_count, profiles.user_id, profiles.full_name, profiles.bio, profiles.age, profiles.gender").
 		Joins("left join profiles on users.id = profiles.user_id").
 		Where("users.id = ?", userID).
 		Scan(&responseUser).Error; err != nil {
@@ -182,11 +225,57 @@ func (service *userServiceImpl) DeleteUserProfile(c *gin.Context, userID uint) e
 			return err
 		}
 
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.UserIdentity{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("reporter_id = ?", userID).Delete(&entity.Report{}).Error; err != nil {
+			return err
+		}
+
+		if err := deleteUserVotes(tx, userID); err != nil {
+			return err
+		}
+
+		if err := deleteUserCommentReactions(tx, userID); err != nil {
+			return err
+		}
+
+		// the @username stays in the text as plain text
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.Mention{}).Error; err != nil {
+			return err
+		}
+
+		if err := deleteUserNotifications(tx, userID); err != nil {
+			return err
+		}
+
+		if err := deleteUserFollows(tx, userID); err != nil {
+			return err
+		}
+
+		if err := deleteUserWatches(tx, userID); err != nil {
+			return err
+		}
+
+		if err := deleteUserCollections(tx, userID); err != nil {
+			return err
+		}
+
+		if err := revokeUserSessions(tx, userID, ""); err != nil {
+			return err
+		}
+
+		var before response.GetUserCurrentResponse
+		if err := tx.Model(&entity.User{}).Take(&before, "id = ?", userID).Error; err != nil {
+			return err
+		}
+
 		if err := tx.Delete(&entity.User{ID: userID}).Error; err != nil {
 			return err
 		}
 
-		return nil
+		return recordAudit(c, tx, &entity.AuditEvent{ActorID: &userID, Action: AuditUserDelete, TargetType: AuditTargetUser, TargetID: userID}, &before, nil)
 	})
 	if err != nil {
 		return err
@@ -233,13 +322,30 @@ func (service *userServiceImpl) ResetPassword(c *gin.Context, token string, newP
 		return err
 	}
 
+	if err := service.passwordPolicy.Validate(newPassword, user.Username, user.Email); err != nil {
+		return err
+	}
+
 	hashedPassword, err := helper.HashPassword(newPassword)
 	if err != nil {
 		return err
 	}
 
 	user.Password = string(hashedPassword)
-	if err := db.Save(&user).Error; err != nil {
+	user.PasswordResetRequired = false
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+
+		if err := revokeUserSessions(tx, user.ID, ""); err != nil {
+			return err
+		}
+
+		return recordAudit(c, tx, &entity.AuditEvent{ActorID: &user.ID, Action: AuditPasswordReset, TargetType: AuditTargetUser, TargetID: user.ID}, nil, nil)
+	})
+	if err != nil {
 		return err
 	}
 
@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	watchDigestMaxItems = 50
+	watchDigestTimeout  = 30 * time.Second
+)
+
+// watchDigestPeriods is how long a digest waits after the previous one.
+var watchDigestPeriods = map[string]time.Duration{
+	entity.WatchDigestDaily:  24 * time.Hour,
+	entity.WatchDigestWeekly: 7 * 24 * time.Hour,
+}
+
+type WatchService interface {
+	Watch(*gin.Context, string, uint, uint) error
+	Unwatch(*gin.Context, string, uint, uint) error
+	FindAll(*gin.Context, uint, *request.WatchQueryRequest, *web.PaginationRequest) (*[]response.WatchResponse, *web.Metadata, error)
+	FindDigest(*gin.Context, uint) (*response.WatchDigestResponse, error)
+	UpdateDigest(*gin.Context, uint, *request.WatchDigestRequest) (*response.WatchDigestResponse, error)
+}
+
+type watchServiceImpl struct {
+	mailer utils.Mailer
+}
+
+// NewWatchService manages what users watch and their digest setting, the
+// notifications themselves come from the notification service. Watching a car
+// or a brand is following it with alerts, see entity.Follow. The digests
+// queued by SendWatchDigests are mailed by an outbox job.
+func NewWatchService(outbox Outbox, mailer utils.Mailer) WatchService {
+	service := &watchServiceImpl{mailer: mailer}
+
+	outbox.Register(OutboxWatchDigest, service.sendDigest)
+
+	return service
+}
+
+// Watch turns the alerts on for a car or a brand, following it first when the
+// user does not already.
+func (service *watchServiceImpl) Watch(c *gin.Context, targetType string, targetID, userID uint) error {
+	db, logger := helper.GetDBAndLogger(c)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := findFollowTarget(tx, targetType, targetID); err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "follower_id"}, {Name: "target_type"}, {Name: "target_id"}},
+			DoUpdates: clause.Assignments(map[string]any{"alerts": true}),
+			Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "NOT follows.alerts"}}},
+		}).Create(&entity.Follow{FollowerID: userID, TargetType: targetType, TargetID: targetID, Alerts: true})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return exceptions.NewCustomError(http.StatusConflict, "You already watch this "+followTargetNames[targetType])
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("watched successfully", zap.String("targetType", targetType), zap.Uint("targetID", targetID), zap.Uint("userID", userID))
+
+	return nil
+}
+
+// Unwatch turns the alerts off, the car or brand stays followed and in the
+// feed until it is unfollowed.
+func (service *watchServiceImpl) Unwatch(c *gin.Context, targetType string, targetID, userID uint) error {
+	db, logger := helper.GetDBAndLogger(c)
+
+	result := db.Model(&entity.Follow{}).
+		Where("follower_id = ? AND target_type = ? AND target_id = ? AND alerts", userID, targetType, targetID).
+		UpdateColumn("alerts", false)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return exceptions.NewCustomError(http.StatusNotFound, "You do not watch this "+followTargetNames[targetType])
+	}
+
+	logger.Info("unwatched successfully", zap.String("targetType", targetType), zap.Uint("targetID", targetID), zap.Uint("userID", userID))
+
+	return nil
+}
+
+// FindAll lists the cars and brands the user watches, latest followed first.
+// Deleted ones are left out until they are restored.
+func (service *watchServiceImpl) FindAll(c *gin.Context, userID uint, watchQueryReq *request.WatchQueryRequest, paging *web.PaginationRequest) (*[]response.WatchResponse, *web.Metadata, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	query := db.Model(&entity.Follow{}).
+		Joins("LEFT JOIN cars ON follows.target_type = ? AND cars.id = follows.target_id AND cars.deleted_at IS NULL", entity.FollowTargetCar).
+		Joins("LEFT JOIN brands ON follows.target_type = ? AND brands.id = follows.target_id AND brands.deleted_at IS NULL", entity.FollowTargetBrand).
+		Where("follows.follower_id = ? AND follows.alerts AND (cars.id IS NOT NULL OR brands.id IS NOT NULL)", userID)
+
+	if watchQueryReq.Type != "" {
+		query = query.Where("follows.target_type = ?", watchQueryReq.Type)
+	}
+
+	query.Count(&paging.TotalData)
+
+	offset := (paging.Page - 1) * paging.Limit
+
+	watches := []response.WatchResponse{}
+	if err := query.Select("follows.target_type AS type, follows.target_id AS id, COALESCE(brands.name, cars.name || ' ' || cars.model) AS name, follows.created_at AS watched_at").
+		Order("follows.created_at desc").
+		Limit(paging.Limit).Offset(offset).
+		Scan(&watches).Error; err != nil {
+		return nil, nil, err
+	}
+
+	paging.TotalPages = int((paging.TotalData + int64(paging.Limit) - 1) / int64(paging.Limit))
+
+	metadata := web.Metadata{
+		Page:       &paging.Page,
+		Limit:      &paging.Limit,
+		TotalPages: &paging.TotalPages,
+		TotalData:  &paging.TotalData,
+	}
+
+	return &watches, &metadata, nil
+}
+
+func (service *watchServiceImpl) FindDigest(c *gin.Context, userID uint) (*response.WatchDigestResponse, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	var digest entity.WatchDigest
+	if err := db.Take(&digest, "user_id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &response.WatchDigestResponse{Frequency: "off"}, nil
+		}
+		return nil, err
+	}
+
+	return &response.WatchDigestResponse{Frequency: digest.Frequency, LastSentAt: &digest.LastSentAt}, nil
+}
+
+// UpdateDigest turns the digest on, which starts its period now, changes its
+// frequency, which keeps its period start, or turns it off.
+func (service *watchServiceImpl) UpdateDigest(c *gin.Context, userID uint, watchDigestReq *request.WatchDigestRequest) (*response.WatchDigestResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	if watchDigestReq.Frequency == "off" {
+		if err := db.Where("user_id = ?", userID).Delete(&entity.WatchDigest{}).Error; err != nil {
+			return nil, err
+		}
+	} else {
+		if err := db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"frequency", "updated_at"}),
+		}).Create(&entity.WatchDigest{UserID: userID, Frequency: watchDigestReq.Frequency, LastSentAt: time.Now()}).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Info("watch digest updated successfully", zap.Uint("userID", userID), zap.String("frequency", watchDigestReq.Frequency))
+
+	return service.FindDigest(c, userID)
+}
+
+// sendDigest mails a digest queued by SendWatchDigests.
+func (service *watchServiceImpl) sendDigest(tx *gorm.DB, payload json.RawMessage) error {
+	var mail utils.Mail
+	if err := json.Unmarshal(payload, &mail); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), watchDigestTimeout)
+	defer cancel()
+
+	return service.mailer.Send(ctx, &mail)
+}
+
+// SendWatchDigests queues the digests due at now and returns how many were
+// queued. A digest has the unread watch notifications since the previous one
+// and is skipped when there are none, either way the next one is due a period
+// later. Each digest is claimed with SKIP LOCKED so several instances can run
+// the job at the same time.
+func SendWatchDigests(db *gorm.DB, outbox Outbox, logger *zap.Logger, now time.Time) (int, error) {
+	queued := 0
+
+	for {
+		var digest entity.WatchDigest
+		var mailed bool
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+				Where("(frequency = ? AND last_sent_at <= ?) OR (frequency = ? AND last_sent_at <= ?)",
+					entity.WatchDigestDaily, now.Add(-watchDigestPeriods[entity.WatchDigestDaily]),
+					entity.WatchDigestWeekly, now.Add(-watchDigestPeriods[entity.WatchDigestWeekly])).
+				Order("last_sent_at").
+				Limit(1).
+				Find(&digest)
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+
+			var err error
+			if mailed, err = queueWatchDigest(tx, outbox, &digest, now); err != nil {
+				return err
+			}
+
+			return tx.Model(&digest).UpdateColumn("last_sent_at", now).Error
+		})
+		if err != nil {
+			return queued, err
+		}
+
+		if digest.UserID == 0 {
+			break
+		}
+
+		if mailed {
+			queued++
+			logger.Info("watch digest queued", zap.Uint("userID", digest.UserID), zap.String("frequency", digest.Frequency))
+		}
+	}
+
+	return queued, nil
+}
+
+// queueWatchDigest enqueues the mail of a digest, unless there is nothing to
+// tell.
+func queueWatchDigest(tx *gorm.DB, outbox Outbox, digest *entity.WatchDigest, now time.Time) (bool, error) {
+	var user entity.User
+	if err := tx.Select("id", "username", "email").Take(&user, digest.UserID).Error; err != nil {
+		return false, err
+	}
+
+	query := tx.Model(&entity.Notification{}).
+		Where("user_id = ? AND type IN ? AND read_at IS NULL", digest.UserID, entity.NotificationWatchTypes).
+		Where("created_at > ? AND created_at <= ?", digest.LastSentAt, now)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return false, err
+	}
+
+	if total == 0 {
+		return false, nil
+	}
+
+	var notifications []entity.Notification
+	if err := query.Order("created_at, id").Limit(watchDigestMaxItems).Find(&notifications).Error; err != nil {
+		return false, err
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hi %s,\n\n", user.Username)
+	fmt.Fprintf(&body, "Here is what happened to the cars and brands you watch since %s:\n\n", digest.LastSentAt.UTC().Format("2 Jan 2006 15:04 MST"))
+	for _, notification := range notifications {
+		fmt.Fprintf(&body, "- %s (%s)\n", notification.Message, notification.CreatedAt.UTC().Format("2 Jan 15:04 MST"))
+	}
+	if total > int64(len(notifications)) {
+		fmt.Fprintf(&body, "- and %d more in your notifications\n", total-int64(len(notifications)))
+	}
+	fmt.Fprintf(&body, "\nYou get this email %s, change it or turn it off with PUT /api/users/watches/digest.\n", digest.Frequency)
+
+	updates := "updates"
+	if total == 1 {
+		updates = "update"
+	}
+
+	return true, outbox.Enqueue(tx, OutboxWatchDigest, &utils.Mail{
+		To:      user.Email,
+		Subject: fmt.Sprintf("Your %s digest: %d %s on what you watch", digest.Frequency, total, updates),
+		Body:    body.String(),
+	})
+}
+
+// StartWatchDigests queues the due digests every
+// WATCH_DIGEST_INTERVAL_MINUTES, 0 disables it and leaves them to "carreview
+// watch digest".
+func StartWatchDigests(db *gorm.DB, outbox Outbox, logger *zap.Logger) {
+	interval := time.Duration(helper.GetEnvInt("WATCH_DIGEST_INTERVAL_MINUTES", 60)) * time.Minute
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for ; ; <-ticker.C {
+			if _, err := SendWatchDigests(db, outbox, logger, time.Now()); err != nil {
+				logger.Error("failed to send watch digests", zap.Error(err))
+			}
+		}
+	}()
+}
+
+// deleteUserWatches removes the digest of a deleted user, what they watch
+// goes with their follows.
+func deleteUserWatches(tx *gorm.DB, userID uint) error {
+	return tx.Where("user_id = ?", userID).Delete(&entity.WatchDigest{}).Error
+}
@@ -0,0 +1,684 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/response"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// webhookEvents are the events a webhook can subscribe to.
+var webhookEvents = []string{EventCarCreated, EventCarUpdated, EventCarDeleted, EventBrandCreated, EventBrandUpdated, EventBrandDeleted, EventReviewPublished}
+
+const (
+	webhookMaxBackoff      = 6 * time.Hour
+	webhookMaxResponseBody = 1024
+)
+
+type WebhookService interface {
+	Create(*gin.Context, *request.WebhookCreateRequest) (*response.WebhookResponse, error)
+	Update(*gin.Context, *request.WebhookUpdateRequest, uint) (*response.WebhookResponse, error)
+	Delete(*gin.Context, uint) error
+	FindAll(*gin.Context) (*[]response.WebhookResponse, error)
+	FindByID(*gin.Context, uint) (*response.WebhookResponse, error)
+	FindDeliveries(*gin.Context, uint, *request.WebhookDeliveryQueryRequest, *web.PaginationRequest) (*[]response.WebhookDeliveryResponse, *web.Metadata, error)
+	Redeliver(*gin.Context, uint, uint) (*response.WebhookDeliveryResponse, error)
+}
+
+type webhookServiceImpl struct {
+	outbox Outbox
+	client *http.Client
+}
+
+// NewWebhookService queues a delivery for every active webhook subscribed to
+// a catalogue or review event, in the transaction that emitted it. Each
+// attempt is an outbox job posting the delivery with client, the deliveries
+// table being the log shown to the admins.
+func NewWebhookService(events EventBus, outbox Outbox, client *http.Client) WebhookService {
+	service := &webhookServiceImpl{outbox: outbox, client: client}
+
+	outbox.RegisterCall(OutboxWebhookDeliver, service.deliver)
+
+	for _, name := range []string{EventCarCreated, EventCarUpdated, EventCarDeleted, EventBrandCreated, EventBrandUpdated, EventBrandDeleted} {
+		events.Subscribe(name, service.onCatalogueChanged)
+	}
+	events.Subscribe(EventReviewPublished, service.onReviewPublished)
+
+	return service
+}
+
+func (service *webhookServiceImpl) Create(c *gin.Context, webhookCreateReq *request.WebhookCreateRequest) (*response.WebhookResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	if err := checkWebhookURL(c, webhookCreateReq.URL); err != nil {
+		return nil, err
+	}
+
+	events, err := webhookEventsJSON(webhookCreateReq.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := webhookCreateReq.Secret
+	if secret == "" {
+		if secret, err = utils.RandomURLSafeString(32); err != nil {
+			return nil, err
+		}
+	}
+
+	webhook := entity.Webhook{
+		URL:         webhookCreateReq.URL,
+		Secret:      secret,
+		Events:      events,
+		Description: webhookCreateReq.Description,
+		Active:      webhookCreateReq.Active == nil || *webhookCreateReq.Active,
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&webhook).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(c, tx, &entity.AuditEvent{Action: AuditWebhookCreate, TargetType: AuditTargetWebhook, TargetID: webhook.ID}, nil, toWebhookResponse(&webhook))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("webhook created successfully", zap.Uint("webhookID", webhook.ID))
+
+	// the secret is only shown once
+	webhookResponse := toWebhookResponse(&webhook)
+	webhookResponse.Secret = webhook.Secret
+
+	return webhookResponse, nil
+}
+
+func (service *webhookServiceImpl) Update(c *gin.Context, webhookUpdateReq *request.WebhookUpdateRequest, webhookID uint) (*response.WebhookResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	if webhookUpdateReq.URL != "" {
+		if err := checkWebhookURL(c, webhookUpdateReq.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	var webhook entity.Webhook
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&webhook, webhookID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return exceptions.NewCustomError(http.StatusNotFound, "Webhook not found")
+			}
+			return err
+		}
+
+		before := toWebhookResponse(&webhook)
+
+		if webhookUpdateReq.URL != "" {
+			webhook.URL = webhookUpdateReq.URL
+		}
+
+		if webhookUpdateReq.Events != nil {
+			events, err := webhookEventsJSON(webhookUpdateReq.Events)
+			if err != nil {
+				return err
+			}
+			webhook.Events = events
+		}
+
+		if webhookUpdateReq.Secret != "" {
+			webhook.Secret = webhookUpdateReq.Secret
+		}
+		if webhookUpdateReq.Description != nil {
+			webhook.Description = *webhookUpdateReq.Description
+		}
+		if webhookUpdateReq.Active != nil {
+			webhook.Active = *webhookUpdateReq.Active
+		}
+
+		if err := tx.Save(&webhook).Error; err != nil {
+			return err
+		}
+
+		after := toWebhookResponse(&webhook)
+		if webhookUpdateReq.Secret != "" {
+			// record that it changed, never the secret itself
+			after.Secret = "changed"
+		}
+
+		return recordAudit(c, tx, &entity.AuditEvent{Action: AuditWebhookUpdate, TargetType: AuditTargetWebhook, TargetID: webhookID}, before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("webhook updated successfully", zap.Uint("webhookID", webhookID))
+
+	return toWebhookResponse(&webhook), nil
+}
+
+// Delete removes the webhook with its delivery log.
+func (service *webhookServiceImpl) Delete(c *gin.Context, webhookID uint) error {
+	db, logger := helper.GetDBAndLogger(c)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var webhook entity.Webhook
+		if err := tx.Take(&webhook, webhookID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return exceptions.NewCustomError(http.StatusNotFound, "Webhook not found")
+			}
+			return err
+		}
+
+		if err := tx.Delete(&webhook).Error; err != nil {
+			return err
+		}
+
+		return recordAudit(c, tx, &entity.AuditEvent{Action: AuditWebhookDelete, TargetType: AuditTargetWebhook, TargetID: webhookID}, toWebhookResponse(&webhook), nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("webhook deleted successfully", zap.Uint("webhookID", webhookID))
+
+	return nil
+}
+
+func (service *webhookServiceImpl) FindAll(c *gin.Context) (*[]response.WebhookResponse, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	var webhooks []entity.Webhook
+	if err := db.Order("id").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+
+	webhookResponses := []response.WebhookResponse{}
+	for i := range webhooks {
+		webhookResponses = append(webhookResponses, *toWebhookResponse(&webhooks[i]))
+	}
+
+	return &webhookResponses, nil
+}
+
+func (service *webhookServiceImpl) FindByID(c *gin.Context, webhookID uint) (*response.WebhookResponse, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	var webhook entity.Webhook
+	if err := db.Take(&webhook, webhookID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, exceptions.NewCustomError(http.StatusNotFound, "Webhook not found")
+		}
+		return nil, err
+	}
+
+	return toWebhookResponse(&webhook), nil
+}
+
+// FindDeliveries is the delivery log of a webhook, newest first.
+func (service *webhookServiceImpl) FindDeliveries(c *gin.Context, webhookID uint, deliveryQueryReq *request.WebhookDeliveryQueryRequest, paging *web.PaginationRequest) (*[]response.WebhookDeliveryResponse, *web.Metadata, error) {
+	db, _ := helper.GetDBAndLogger(c)
+
+	if err := db.Select("id").Take(&entity.Webhook{}, webhookID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, exceptions.NewCustomError(http.StatusNotFound, "Webhook not found")
+		}
+		return nil, nil, err
+	}
+
+	query := db.Model(&entity.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if deliveryQueryReq.Status != "" {
+		query = query.Where("status = ?", deliveryQueryReq.Status)
+	}
+
+	query.Count(&paging.TotalData)
+
+	offset := (paging.Page - 1) * paging.Limit
+
+	var deliveries []entity.WebhookDelivery
+	if err := query.Order("id desc").Limit(paging.Limit).Offset(offset).Find(&deliveries).Error; err != nil {
+		return nil, nil, err
+	}
+
+	paging.TotalPages = int((paging.TotalData + int64(paging.Limit) - 1) / int64(paging.Limit))
+
+	deliveryResponses := []response.WebhookDeliveryResponse{}
+	for i := range deliveries {
+		deliveryResponses = append(deliveryResponses, *toWebhookDeliveryResponse(&deliveries[i]))
+	}
+
+	metadata := web.Metadata{
+		Page:       &paging.Page,
+		Limit:      &paging.Limit,
+		TotalPages: &paging.TotalPages,
+		TotalData:  &paging.TotalData,
+	}
+
+	return &deliveryResponses, &metadata, nil
+}
+
+// Redeliver queues the payload of a past delivery again as a new delivery,
+// the original stays in the log untouched.
+func (service *webhookServiceImpl) Redeliver(c *gin.Context, webhookID, deliveryID uint) (*response.WebhookDeliveryResponse, error) {
+	db, logger := helper.GetDBAndLogger(c)
+
+	var redelivery entity.WebhookDelivery
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var original entity.WebhookDelivery
+		if err := tx.Where("webhook_id = ?", webhookID).Take(&original, deliveryID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return exceptions.NewCustomError(http.StatusNotFound, "Delivery not found")
+			}
+			return err
+		}
+
+		now := time.Now()
+		redelivery = entity.WebhookDelivery{
+			WebhookID:     webhookID,
+			Event:         original.Event,
+			Payload:       original.Payload,
+			Status:        entity.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+			RedeliveryOf:  &original.ID,
+		}
+
+		if err := tx.Create(&redelivery).Error; err != nil {
+			return err
+		}
+
+		if err := service.outbox.Enqueue(tx, OutboxWebhookDeliver, webhookDeliveryJob{DeliveryID: redelivery.ID}); err != nil {
+			return err
+		}
+
+		return recordAudit(c, tx, &entity.AuditEvent{Action: AuditWebhookRedeliver, TargetType: AuditTargetWebhook, TargetID: webhookID}, nil, gin.H{"delivery_id": redelivery.ID, "redelivery_of": original.ID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("webhook delivery queued again", zap.Uint("webhookID", webhookID), zap.Uint("deliveryID", deliveryID))
+
+	return toWebhookDeliveryResponse(&redelivery), nil
+}
+
+func (service *webhookServiceImpl) onCatalogueChanged(tx *gorm.DB, event DomainEvent) error {
+	switch changed := event.(type) {
+	case *CarChangedEvent:
+		return service.queueDeliveries(tx, changed.Name, changed.Car)
+	case *BrandChangedEvent:
+		return service.queueDeliveries(tx, changed.Name, changed.Brand)
+	default:
+		return nil
+	}
+}
+
+func (service *webhookServiceImpl) onReviewPublished(tx *gorm.DB, event DomainEvent) error {
+	published := event.(*ReviewPublishedEvent)
+
+	var review entity.Review
+	if err := tx.Take(&review, published.ReviewID).Error; err != nil {
+		return err
+	}
+
+	mentions, err := loadMentions(tx, entity.MentionOwnerReview, []uint{review.ID})
+	if err != nil {
+		return err
+	}
+
+	return service.queueDeliveries(tx, EventReviewPublished, &response.ReviewResponse{
+		ID:        review.ID,
+		CarID:     review.CarID,
+		UserID:    review.UserID,
+		Title:     review.Title,
+		Content:   review.Content,
+		ImageUrl:  review.ImageUrl,
+		MediaID:   review.MediaID,
+		Status:    review.Status,
+		Mentions:  append([]response.MentionResponse{}, mentions[review.ID]...),
+		CreatedAt: review.CreatedAt,
+		UpdatedAt: review.UpdatedAt,
+	})
+}
+
+type webhookPayload struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// webhookDeliveryJob is the payload of an OutboxWebhookDeliver job.
+type webhookDeliveryJob struct {
+	DeliveryID uint `json:"delivery_id"`
+}
+
+// queueDeliveries stores a delivery of the event for every active webhook
+// subscribed to it, with the outbox job of its first attempt.
+func (service *webhookServiceImpl) queueDeliveries(tx *gorm.DB, event string, data any) error {
+	subscribed, _ := json.Marshal([]string{event})
+
+	var webhookIDs []uint
+	if err := tx.Model(&entity.Webhook{}).Where("active AND events @> ?::jsonb", string(subscribed)).Pluck("id", &webhookIDs).Error; err != nil {
+		return err
+	}
+
+	if len(webhookIDs) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+
+	payload, err := json.Marshal(webhookPayload{Event: event, CreatedAt: now, Data: data})
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]entity.WebhookDelivery, len(webhookIDs))
+	for i, webhookID := range webhookIDs {
+		deliveries[i] = entity.WebhookDelivery{
+			WebhookID:     webhookID,
+			Event:         event,
+			Payload:       string(payload),
+			Status:        entity.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		}
+	}
+
+	if err := tx.Create(&deliveries).Error; err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		if err := service.outbox.Enqueue(tx, OutboxWebhookDeliver, webhookDeliveryJob{DeliveryID: delivery.ID}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WebhookSignature is the X-Webhook-Signature of a delivery: the hex
+// HMAC-SHA256, keyed with the webhook secret, of the X-Webhook-Timestamp, a
+// dot and the body.
+func WebhookSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver is the outbox call attempting a delivery, outside of any
+// transaction so that a dead receiver only holds up its own deliveries. The
+// outcome is saved on the delivery and a failed attempt queues the next one
+// itself, after webhookBackoff, so that the log keeps every attempt. An error
+// is only returned when the outcome cannot be saved, the outbox then retries
+// the same attempt.
+func (service *webhookServiceImpl) deliver(db *gorm.DB, payload json.RawMessage) error {
+	var job webhookDeliveryJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+
+	var delivery entity.WebhookDelivery
+	if err := db.Take(&delivery, job.DeliveryID).Error; err != nil {
+		// the webhook was deleted with its log
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if delivery.Status != entity.WebhookDeliveryPending {
+		return nil
+	}
+
+	var webhook entity.Webhook
+	if err := db.Take(&webhook, delivery.WebhookID).Error; err != nil {
+		return err
+	}
+
+	attemptWebhookDelivery(service.client, &webhook, &delivery, time.Now())
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&delivery).
+			Where("status = ?", entity.WebhookDeliveryPending).
+			Select("status", "attempts", "next_attempt_at", "last_attempt_at", "response_status", "response_body", "error").
+			Updates(&delivery)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		if delivery.Status == entity.WebhookDeliveryPending {
+			return service.outbox.EnqueueAt(tx, OutboxWebhookDeliver, job, *delivery.NextAttemptAt)
+		}
+
+		return nil
+	})
+}
+
+// attemptWebhookDelivery posts the delivery and records the outcome, a
+// failed attempt is retried after webhookBackoff until WEBHOOK_MAX_ATTEMPTS.
+func attemptWebhookDelivery(client *http.Client, webhook *entity.Webhook, delivery *entity.WebhookDelivery, now time.Time) {
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = nil
+	delivery.ResponseBody = ""
+	delivery.Error = ""
+
+	err := postWebhookDelivery(client, webhook, delivery, now)
+	if err == nil {
+		delivery.Status = entity.WebhookDeliveryDelivered
+		delivery.NextAttemptAt = nil
+		return
+	}
+
+	delivery.Error = err.Error()
+
+	if webhook == nil || !webhook.Active || delivery.Attempts >= helper.GetEnvInt("WEBHOOK_MAX_ATTEMPTS", 8) {
+		delivery.Status = entity.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		return
+	}
+
+	next := now.Add(webhookBackoff(delivery.Attempts))
+	delivery.NextAttemptAt = &next
+}
+
+func postWebhookDelivery(client *http.Client, webhook *entity.Webhook, delivery *entity.WebhookDelivery, now time.Time) error {
+	if webhook == nil || !webhook.Active {
+		return errors.New("webhook is inactive")
+	}
+
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "carreview-webhooks")
+	req.Header.Set("X-Webhook-Event", delivery.Event)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", WebhookSignature(webhook.Secret, timestamp, body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, webhookMaxResponseBody))
+	delivery.ResponseStatus = &resp.StatusCode
+	delivery.ResponseBody = string(responseBody)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// webhookBackoff is WEBHOOK_BACKOFF_SECONDS doubled after each failed
+// attempt, at most webhookMaxBackoff.
+func webhookBackoff(attempts int) time.Duration {
+	delay := time.Duration(helper.GetEnvInt("WEBHOOK_BACKOFF_SECONDS", 30)) * time.Second
+	for i := 1; i < attempts && delay < webhookMaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, webhookMaxBackoff)
+}
+
+var errWebhookAddress = errors.New("webhook address is not public")
+
+// NewWebhookClient times out after WEBHOOK_TIMEOUT_SECONDS and does not
+// follow redirects, a redirect is a failed attempt. It only connects to
+// public addresses, checked when dialing so that a host resolving to another
+// address since the webhook was saved cannot reach internal services.
+func NewWebhookClient() *http.Client {
+	timeout := time.Duration(helper.GetEnvInt("WEBHOOK_TIMEOUT_SECONDS", 10)) * time.Second
+
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !webhookAddressAllowed(ip) {
+				return errWebhookAddress
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// webhookAddressAllowed rejects loopback, private, link-local and other
+// non public addresses, unless WEBHOOK_ALLOW_PRIVATE_ADDRESSES is set for
+// receivers on the same network.
+func webhookAddressAllowed(ip net.IP) bool {
+	if helper.GetEnvBool("WEBHOOK_ALLOW_PRIVATE_ADDRESSES", false) {
+		return true
+	}
+
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !webhookSharedAddresses.Contains(ip)
+}
+
+// webhookSharedAddresses is the carrier-grade NAT range, not covered by
+// net.IP.IsPrivate.
+var webhookSharedAddresses = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// checkWebhookURL checks the url and that its host only resolves to public
+// addresses.
+func checkWebhookURL(c *gin.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return exceptions.NewCustomError(http.StatusBadRequest, "Webhook url must be an http or https url")
+	}
+
+	addresses, err := net.DefaultResolver.LookupIPAddr(c.Request.Context(), parsed.Hostname())
+	if err != nil || len(addresses) == 0 {
+		return exceptions.NewCustomError(http.StatusBadRequest, "Webhook host cannot be resolved")
+	}
+
+	for _, address := range addresses {
+		if !webhookAddressAllowed(address.IP) {
+			return exceptions.NewCustomError(http.StatusBadRequest, "Webhook url must not point to a private address")
+		}
+	}
+
+	return nil
+}
+
+// webhookEventsJSON checks the events and stores them without duplicates.
+func webhookEventsJSON(events []string) (string, error) {
+	known := map[string]bool{}
+	for _, event := range webhookEvents {
+		known[event] = true
+	}
+
+	seen := map[string]bool{}
+	subscribed := []string{}
+	for _, event := range events {
+		if !known[event] {
+			return "", exceptions.NewCustomError(http.StatusBadRequest, fmt.Sprintf("Unknown webhook event %q", event))
+		}
+		if !seen[event] {
+			seen[event] = true
+			subscribed = append(subscribed, event)
+		}
+	}
+
+	encoded, err := json.Marshal(subscribed)
+	return string(encoded), err
+}
+
+func toWebhookResponse(webhook *entity.Webhook) *response.WebhookResponse {
+	events := []string{}
+	json.Unmarshal([]byte(webhook.Events), &events)
+
+	return &response.WebhookResponse{
+		ID:          webhook.ID,
+		URL:         webhook.URL,
+		Events:      events,
+		Description: webhook.Description,
+		Active:      webhook.Active,
+		CreatedAt:   webhook.CreatedAt,
+		UpdatedAt:   webhook.UpdatedAt,
+	}
+}
+
+func toWebhookDeliveryResponse(delivery *entity.WebhookDelivery) *response.WebhookDeliveryResponse {
+	return &response.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		Event:          delivery.Event,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		RedeliveryOf:   delivery.RedeliveryOf,
+		Payload:        json.RawMessage(delivery.Payload),
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
@@ -0,0 +1,178 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminUsers(t *testing.T) {
+	adminID := register(t, "moderator", "moderator@email.com", "carreview123")
+	DB.Model(&entity.User{}).Where("username = ?", "moderator").Update("role", entity.RoleAdmin)
+	adminToken := login(t, "moderator@email.com", "carreview123")
+
+	targetID := register(t, "troublemaker", "troublemaker@email.com", "carreview123")
+	targetToken := login(t, "troublemaker@email.com", "carreview123")
+
+	promotedID := register(t, "promoted", "promoted@email.com", "carreview123")
+	promotedToken := login(t, "promoted@email.com", "carreview123")
+
+	carID := createCar(t, adminToken, createBrand(t, adminToken, "Adminbrand"), "Adminline")
+
+	status, review := send(t, http.MethodPost, "/api/reviews/", targetToken, request.ReviewCreateRequest{
+		CarID: carID, Title: "Unfiltered", Content: "Loud opinions about a quiet car.", ImageUrl: "https://example.com/car.jpg",
+	})
+	assert.Equal(t, 201, status)
+
+	reviewID := review.(map[string]any)["id"]
+
+	t.Run("should filter users by role", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/api/admin/users?role=ADMIN&search=moderator", nil)
+		request.Header.Add("Authorization", "Bearer "+adminToken)
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		var jsonResult map[string]any
+
+		json.NewDecoder(recorder.Result().Body).Decode(&jsonResult)
+
+		assert.Equal(t, 200, recorder.Result().StatusCode)
+		assert.Len(t, jsonResult["payload"].([]any), 1)
+	})
+
+	t.Run("should forbid non admin", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/api/admin/users", nil)
+		request.Header.Add("Authorization", "Bearer "+targetToken)
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, 403, recorder.Result().StatusCode)
+	})
+
+	t.Run("should forbid non admin changes", func(t *testing.T) {
+		status, _ := send(t, http.MethodPatch, fmt.Sprintf("/api/admin/users/%d/role", targetID), targetToken, request.AdminUpdateRoleRequest{Role: entity.RoleAdmin})
+		assert.Equal(t, 403, status)
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/admin/users/%d/force-password-reset", promotedID), targetToken, nil)
+		assert.Equal(t, 403, status)
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/admin/users/%d/impersonate", promotedID), targetToken, nil)
+		assert.Equal(t, 403, status)
+	})
+
+	t.Run("should change roles", func(t *testing.T) {
+		status, user := send(t, http.MethodPatch, fmt.Sprintf("/api/admin/users/%d/role", promotedID), adminToken, request.AdminUpdateRoleRequest{Role: entity.RoleModerator})
+		assert.Equal(t, 200, status)
+		assert.Equal(t, entity.RoleModerator, user.(map[string]any)["role"])
+
+		// the old token still carries the old role
+		status, _ = send(t, http.MethodGet, "/api/users/sessions", promotedToken, nil)
+		assert.Equal(t, 401, status)
+
+		status, _ = send(t, http.MethodGet, "/api/moderation/queue", login(t, "promoted@email.com", "carreview123"), nil)
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodPatch, fmt.Sprintf("/api/admin/users/%d/role", adminID), adminToken, request.AdminUpdateRoleRequest{Role: entity.RoleUser})
+		assert.Equal(t, 400, status)
+
+		status, _ = send(t, http.MethodPatch, "/api/admin/users/999999/role", adminToken, request.AdminUpdateRoleRequest{Role: entity.RoleUser})
+		assert.Equal(t, 404, status)
+	})
+
+	t.Run("should impersonate a user", func(t *testing.T) {
+		status, _ := send(t, http.MethodPost, fmt.Sprintf("/api/admin/users/%d/impersonate", adminID), adminToken, nil)
+		assert.Equal(t, 400, status)
+
+		var root entity.User
+		DB.Take(&root, "email = ?", "root@email.com")
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/admin/users/%d/impersonate", root.ID), adminToken, nil)
+		assert.Equal(t, 403, status)
+
+		status, impersonation := send(t, http.MethodPost, fmt.Sprintf("/api/admin/users/%d/impersonate", promotedID), adminToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, "promoted", impersonation.(map[string]any)["username"])
+
+		impersonatedToken := impersonation.(map[string]any)["token"].(string)
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/moderation/reviews/%v", reviewID), impersonatedToken, request.ModerationDecisionRequest{Action: "hide"})
+		assert.Equal(t, 200, status)
+
+		status, events := send(t, http.MethodGet, fmt.Sprintf("/api/admin/audit?action=review.moderate&target_id=%v", reviewID), adminToken, nil)
+		assert.Equal(t, 200, status)
+
+		if assert.Len(t, events, 1) {
+			event := events.([]any)[0].(map[string]any)
+			assert.Equal(t, float64(promotedID), event["actor_id"])
+			assert.Equal(t, float64(adminID), event["impersonator_id"])
+		}
+
+		status, events = send(t, http.MethodGet, fmt.Sprintf("/api/admin/audit?action=user.impersonate&target_id=%d", promotedID), adminToken, nil)
+		assert.Equal(t, 200, status)
+
+		if assert.Len(t, events, 1) {
+			assert.Equal(t, float64(adminID), events.([]any)[0].(map[string]any)["actor_id"])
+		}
+	})
+
+	t.Run("should force a password reset", func(t *testing.T) {
+		forgetfulID := register(t, "forgetful", "forgetful@email.com", "carreview123")
+		forgetfulToken := login(t, "forgetful@email.com", "carreview123")
+
+		status, user := send(t, http.MethodPost, fmt.Sprintf("/api/admin/users/%d/force-password-reset", forgetfulID), adminToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, true, user.(map[string]any)["password_reset_required"])
+
+		status, _ = send(t, http.MethodGet, "/api/users/sessions", forgetfulToken, nil)
+		assert.Equal(t, 401, status)
+
+		status, _ = send(t, http.MethodPost, "/api/auth/login", "", request.LoginRequest{Email: "forgetful@email.com", Password: "carreview123"})
+		assert.Equal(t, 403, status)
+
+		// support can still look around as the user
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/admin/users/%d/impersonate", forgetfulID), adminToken, nil)
+		assert.Equal(t, 200, status)
+	})
+
+	t.Run("should reject banned user", func(t *testing.T) {
+		requestBody, _ := json.Marshal(request.AdminBanUserRequest{Reason: "Spam"})
+
+		request := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/admin/users/%d/ban", targetID), strings.NewReader(string(requestBody)))
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminToken)
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, 200, recorder.Result().StatusCode)
+
+		request = httptest.NewRequest(http.MethodGet, "/api/users/sessions", nil)
+		request.Header.Add("Authorization", "Bearer "+targetToken)
+
+		recorder = httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, 401, recorder.Result().StatusCode)
+	})
+
+	t.Run("should refuse login of banned user", func(t *testing.T) {
+		requestBody, _ := json.Marshal(request.LoginRequest{Email: "troublemaker@email.com", Password: "carreview123"})
+
+		request := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(string(requestBody)))
+		request.Header.Add("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, 403, recorder.Result().StatusCode)
+	})
+}
@@ -38,7 +38,7 @@ func TestRegister(t *testing.T) {
 		newUser := request.RegisterRequest{
 			Username: "test",
 			Email:    "test@email.com",
-			Password: "testtest",
+			Password: "carreview123",
 		}
 		requestBody, _ := json.Marshal(newUser)
 
@@ -86,11 +86,36 @@ func TestRegister(t *testing.T) {
 		assert.NotNil(t, jsonResult["errors"])
 	})
 
+	t.Run("should error if password violates the password policy", func(t *testing.T) {
+		newUser := request.RegisterRequest{
+			Username: "policy",
+			Email:    "policy@email.com",
+			Password: "policy123",
+		}
+		requestBody, _ := json.Marshal(newUser)
+
+		request := httptest.NewRequest(http.MethodPost, "/api/auth/register", strings.NewReader(string(requestBody)))
+		request.Header.Add("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		var jsonResult map[string]any
+
+		json.NewDecoder(response.Body).Decode(&jsonResult)
+
+		assert.Equal(t, 400, response.StatusCode)
+		violations := jsonResult["errors"].(map[string]any)["password"].([]any)
+		assert.Equal(t, "contains_username", violations[0].(map[string]any)["rule"])
+	})
+
 	t.Run("should error if username already exists", func(t *testing.T) {
 		newUser := request.RegisterRequest{
 			Username: "test",
 			Email:    "test@email.com",
-			Password: "testtest",
+			Password: "carreview123",
 		}
 		requestBody, _ := json.Marshal(newUser)
 
@@ -116,7 +141,7 @@ func TestLogin(t *testing.T) {
 	t.Run("should success login", func(t *testing.T) {
 		loginUser := request.LoginRequest{
 			Email:    "test@email.com",
-			Password: "testtest",
+			Password: "carreview123",
 		}
 		requestBody, _ := json.Marshal(loginUser)
 
@@ -194,7 +219,8 @@ func TestLogin(t *testing.T) {
 func TestUpdatePasswordUser(t *testing.T) {
 	t.Run("should success update password", func(t *testing.T) {
 		newPassword := request.UpdatePasswordRequest{
-			Password: "mynewpassword",
+			CurrentPassword: "carreview123",
+			Password:        "mynewpassword",
 		}
 		requestBody, _ := json.Marshal(newPassword)
 
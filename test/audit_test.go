@@ -0,0 +1,96 @@
+package test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudit(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	var root entity.User
+	DB.Take(&root, "email = ?", "root@email.com")
+
+	register(t, "audituser", "audituser@email.com", "carreview123")
+	userToken := login(t, "audituser@email.com", "carreview123")
+
+	brandID := createBrand(t, adminToken, "Auditbrand")
+
+	status, _ := send(t, http.MethodPatch, fmt.Sprintf("/api/brands/%d", brandID), adminToken, request.BrandRequest{Name: "Auditedbrand"})
+	assert.Equal(t, 200, status)
+
+	brandEvents := fmt.Sprintf("target_type=brand&target_id=%d", brandID)
+
+	t.Run("should only allow admins", func(t *testing.T) {
+		status, _ := send(t, http.MethodGet, "/api/admin/audit", userToken, nil)
+		assert.Equal(t, 403, status)
+
+		status, _ = send(t, http.MethodGet, "/api/admin/audit/export", userToken, nil)
+		assert.Equal(t, 403, status)
+	})
+
+	t.Run("should record the changes", func(t *testing.T) {
+		status, events := send(t, http.MethodGet, "/api/admin/audit?action=brand.update&"+brandEvents, adminToken, nil)
+		assert.Equal(t, 200, status)
+
+		if assert.Len(t, events, 1) {
+			event := events.([]any)[0].(map[string]any)
+			assert.Equal(t, float64(root.ID), event["actor_id"])
+			assert.Equal(t, "Auditbrand", event["before"].(map[string]any)["name"])
+			assert.Equal(t, "Auditedbrand", event["after"].(map[string]any)["name"])
+			assert.Contains(t, event["diff"], "name")
+		}
+	})
+
+	t.Run("should filter the events", func(t *testing.T) {
+		status, events := send(t, http.MethodGet, "/api/admin/audit?"+brandEvents, adminToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, events, 2)
+
+		status, events = send(t, http.MethodGet, fmt.Sprintf("/api/admin/audit?actor=%d&action=brand.delete&target_id=%d", root.ID, brandID), adminToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, events, 0)
+	})
+
+	t.Run("should export the events", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/api/admin/audit/export?"+brandEvents, nil)
+		request.Header.Add("Authorization", "Bearer "+adminToken)
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "application/x-ndjson", recorder.Header().Get("Content-Type"))
+		assert.Empty(t, recorder.Result().Trailer.Get("X-Stream-Error"))
+
+		var actions []string
+		scanner := bufio.NewScanner(recorder.Body)
+		for scanner.Scan() {
+			var event map[string]any
+			if assert.NoError(t, json.Unmarshal(scanner.Bytes(), &event)) {
+				actions = append(actions, event["action"].(string))
+			}
+		}
+		assert.Equal(t, []string{"brand.create", "brand.update"}, actions)
+	})
+
+	t.Run("should be append only", func(t *testing.T) {
+		var event entity.AuditEvent
+		DB.Take(&event, "target_type = ? AND target_id = ?", "brand", brandID)
+
+		assert.Error(t, DB.Exec("UPDATE audit_events SET action = ? WHERE id = ?", "brand.delete", event.ID).Error)
+		assert.Error(t, DB.Exec("DELETE FROM audit_events WHERE id = ?", event.ID).Error)
+
+		var count int64
+		DB.Model(&entity.AuditEvent{}).Where("target_type = ? AND target_id = ?", "brand", brandID).Count(&count)
+		assert.Equal(t, int64(2), count)
+	})
+}
@@ -0,0 +1,189 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/raihanmd/fp-superbootcamp-go/app"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+)
+
+func TestCarImport(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	csvFile := "brand,name,model,year,image_url,width,height,length,engine,torque,transmission,acceleration,horse_power,breaking_system_front,breaking_system_back,fuel\n" +
+		"Importbrand,Supra,GR,2020,https://example.com/supra.jpg,1854,1292,4379,3.0L I6,500,automatic,4.1,382,disc,disc,gasoline\n" +
+		"Importbrand,Supra,GR,1700,https://example.com/supra.jpg,1854,1292,4379,3.0L I6,500,automatic,4.1,382,disc,disc,gasoline\n"
+
+	upload := func(query string) (int, map[string]any) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, _ := writer.CreateFormFile("file", "cars.csv")
+		part.Write([]byte(csvFile))
+		writer.Close()
+
+		request := httptest.NewRequest(http.MethodPost, "/api/admin/cars/import?"+query, &body)
+		request.Header.Add("Content-Type", writer.FormDataContentType())
+		request.Header.Add("Authorization", "Bearer "+adminToken)
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		var jsonResult map[string]any
+
+		json.NewDecoder(recorder.Result().Body).Decode(&jsonResult)
+
+		return recorder.Result().StatusCode, jsonResult["payload"].(map[string]any)
+	}
+
+	t.Run("should report rows on dry run", func(t *testing.T) {
+		status, job := upload("dry_run=true&create_brands=true")
+
+		assert.Equal(t, 200, status)
+		assert.Equal(t, 1.0, job["created"])
+		assert.Equal(t, 1.0, job["failed"])
+		assert.Equal(t, "error", job["rows"].([]any)[1].(map[string]any)["action"])
+	})
+
+	t.Run("should upsert cars", func(t *testing.T) {
+		_, job := upload("create_brands=true")
+		assert.Equal(t, 1.0, job["created"])
+
+		_, job = upload("create_brands=true")
+		assert.Equal(t, 1.0, job["updated"])
+	})
+
+	t.Run("should refuse a duplicate car", func(t *testing.T) {
+		var car entity.Car
+		DB.Joins("Brand").Take(&car, `"Brand".name = ? AND cars.name = ?`, "Importbrand", "Supra")
+
+		status, _ := send(t, http.MethodPost, "/api/cars/", adminToken, request.CarCreateRequest{
+			BrandID: car.BrandID, Name: "Supra", Model: "gr", Year: 2020, ImageUrl: "https://example.com/supra.jpg",
+			Width: 1854, Height: 1292, Length: 4379, Engine: "3.0L I6", Torque: 500, Transmission: "automatic",
+			Acceleration: 4.1, HorsePower: 382, BreakingSystemFront: "disc", BreakingSystemBack: "disc", Fuel: "gasoline",
+		})
+		assert.Equal(t, 409, status)
+	})
+
+	t.Run("should import large files in the background", func(t *testing.T) {
+		t.Setenv("CAR_IMPORT_ASYNC_ROWS", "1")
+
+		events := services.NewEventBus()
+		importService := services.NewCarImportService(services.NewCarService(events), services.NewBrandService(events))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/admin/cars/import", nil).WithContext(ctx)
+		c.Request.Header.Add("Authorization", "Bearer "+adminToken)
+		c.Set("db", DB)
+		c.Set("logger", zap.NewNop())
+
+		file := "brand,name,model,year,image_url,width,height,length,engine,torque,transmission,acceleration,horse_power,breaking_system_front,breaking_system_back,fuel\n" +
+			"Asyncbrand,Celica,GT,1990,https://example.com/celica.jpg,1705,1295,4420,2.0L I4,250,manual,7.9,165,disc,disc,gasoline\n" +
+			"Asyncbrand,Celica,GT,1990,https://example.com/celica.jpg,1705,1295,4420,2.0L I4,250,manual,7.5,175,disc,disc,gasoline\n" +
+			"Asyncbrand,MR2,GT,1990,https://example.com/mr2.jpg,1695,1240,4170,2.0L I4,304,manual,6.1,200,disc,disc,gasoline\n"
+
+		job, err := importService.Import(c, &request.CarImportRequest{CreateBrands: true}, strings.NewReader(file))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Nil(t, job.FinishedAt)
+
+		// the job outlives the request
+		cancel()
+
+		var result map[string]any
+		assert.Eventually(t, func() bool {
+			status, payload := send(t, http.MethodGet, fmt.Sprintf("/api/admin/cars/import/%d", job.ID), adminToken, nil)
+			if status != 200 {
+				return false
+			}
+			result = payload.(map[string]any)
+			return result["status"] == entity.ImportJobCompleted
+		}, 10*time.Second, 50*time.Millisecond)
+
+		assert.Equal(t, 3.0, result["processed"])
+		assert.Equal(t, 2.0, result["created"])
+		assert.Equal(t, 1.0, result["updated"])
+		assert.Equal(t, 0.0, result["failed"])
+
+		var importedBy entity.AuditEvent
+		DB.Take(&importedBy, "action = ? AND target_type = ? AND target_id = ?", services.AuditCarCreate, services.AuditTargetCar, uint(result["rows"].([]any)[0].(map[string]any)["id"].(float64)))
+		assert.NotNil(t, importedBy.ActorID)
+	})
+}
+
+func TestMigrateDuplicateCars(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	carID := createCar(t, adminToken, createBrand(t, adminToken, "Twinbrand"), "Twin")
+
+	// cars could be created twice before idx_car_identity
+	assert.NoError(t, DB.Exec("DROP INDEX idx_car_identity").Error)
+
+	var duplicate entity.Car
+	DB.Take(&duplicate, carID)
+	duplicate.ID = 0
+	assert.NoError(t, DB.Omit(clause.Associations).Create(&duplicate).Error)
+
+	firstID := register(t, "twinfirst", "twinfirst@email.com", "carreview123")
+	firstToken := login(t, "twinfirst@email.com", "carreview123")
+	register(t, "twinsecond", "twinsecond@email.com", "carreview123")
+	secondToken := login(t, "twinsecond@email.com", "carreview123")
+
+	review := func(token string, carID uint) uint {
+		status, review := send(t, http.MethodPost, "/api/reviews/", token, request.ReviewCreateRequest{
+			CarID: carID, Title: "Seeing double", Content: "Two of them.", ImageUrl: "https://example.com/twin.jpg",
+		})
+		assert.Equal(t, 201, status)
+		return uint(review.(map[string]any)["id"].(float64))
+	}
+
+	movedReviewID := review(firstToken, duplicate.ID)
+	review(secondToken, carID)
+	clashingReviewID := review(secondToken, duplicate.ID)
+
+	status, _ := send(t, http.MethodPost, fmt.Sprintf("/api/favourites/%d", duplicate.ID), firstToken, nil)
+	assert.Equal(t, 200, status)
+
+	assert.NoError(t, DB.Create(&entity.Follow{FollowerID: firstID, TargetType: entity.FollowTargetCar, TargetID: duplicate.ID}).Error)
+
+	assert.NoError(t, app.Migrate(DB))
+
+	var car entity.Car
+	assert.NoError(t, DB.Unscoped().Take(&car, duplicate.ID).Error)
+	assert.True(t, car.DeletedAt.Valid)
+
+	var moved, clashing entity.Review
+	DB.Unscoped().Take(&moved, movedReviewID)
+	assert.Equal(t, carID, moved.CarID)
+	DB.Unscoped().Take(&clashing, clashingReviewID)
+	assert.True(t, clashing.DeletedAt.Valid)
+
+	var count int64
+	DB.Model(&entity.Favourite{}).Where("user_id = ? AND car_id = ?", firstID, carID).Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	DB.Model(&entity.Follow{}).Where("follower_id = ? AND target_type = ? AND target_id = ?", firstID, entity.FollowTargetCar, carID).Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	// the index is back
+	duplicate.ID = 0
+	assert.Error(t, DB.Omit(clause.Associations).Create(&duplicate).Error)
+}
@@ -0,0 +1,129 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCLI(t *testing.T) {
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "carreview")
+
+	if output, err := exec.Command("go", "build", "-o", binary, "../cmd/carreview").CombinedOutput(); err != nil {
+		t.Fatalf("build carreview: %v\n%s", err, output)
+	}
+
+	// carreview runs the command against the test database and returns its
+	// output and exit code.
+	carreview := func(args ...string) (string, int) {
+		command := exec.Command(binary, args...)
+		command.Dir = dir
+		command.Env = append(os.Environ(), "ENVIRONMENT=production")
+
+		output, err := command.CombinedOutput()
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return string(output), exitErr.ExitCode()
+		}
+		assert.NoError(t, err)
+
+		return string(output), 0
+	}
+
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("should print the usage", func(t *testing.T) {
+		output, code := carreview("user", "fly")
+		assert.Equal(t, 2, code)
+		assert.Contains(t, output, "Usage: carreview")
+	})
+
+	t.Run("should create users and change their role", func(t *testing.T) {
+		output, code := carreview("user", "create", "--username", "cliadmin", "--email", "cliadmin@email.com", "--password", "carreview123", "--admin")
+		assert.Equal(t, 0, code, output)
+		assert.Contains(t, output, "created ADMIN cliadmin (cliadmin@email.com)")
+
+		output, code = carreview("user", "create", "--username", "cliadmin", "--email", "cliadmin@email.com", "--password", "carreview123")
+		assert.Equal(t, 1, code, output)
+
+		output, code = carreview("user", "set-role", "--email", "cliadmin@email.com", "--role", entity.RoleModerator)
+		assert.Equal(t, 0, code, output)
+		assert.Contains(t, output, "cliadmin is now MODERATOR")
+
+		var user entity.User
+		DB.Take(&user, "email = ?", "cliadmin@email.com")
+		assert.Equal(t, entity.RoleModerator, user.Role)
+
+		output, code = carreview("user", "set-role", "--email", "cliadmin@email.com", "--role", "OWNER")
+		assert.Equal(t, 1, code, output)
+	})
+
+	t.Run("should seed brands once", func(t *testing.T) {
+		brands := writeFile("brands.json", `[{"name": "Clibrand"}, {"name": "Cliotherbrand"}]`)
+
+		output, code := carreview("seed", "brands", "--file", brands)
+		assert.Equal(t, 0, code, output)
+		assert.Contains(t, output, "brands: 2 created, 0 already existed")
+
+		output, code = carreview("seed", "brands", "--file", brands)
+		assert.Equal(t, 0, code, output)
+		assert.Contains(t, output, "brands: 0 created, 2 already existed")
+	})
+
+	t.Run("should seed cars and notify their watchers", func(t *testing.T) {
+		header := "brand,name,model,year,image_url,width,height,length,engine,torque,transmission,acceleration,horse_power,breaking_system_front,breaking_system_back,fuel\n"
+
+		cars := writeFile("cars.csv", header+"Clibrand,Clicar,GT,2021,https://example.com/clicar.jpg,1800,1300,4400,2.0L I4,350,manual,6.0,250,disc,disc,gasoline\n")
+
+		output, code := carreview("seed", "cars", "--file", cars)
+		assert.Equal(t, 0, code, output)
+		assert.Contains(t, output, "cars: 1 created, 0 updated, 0 failed")
+
+		var brand entity.Brand
+		DB.Take(&brand, "name = ?", "Clibrand")
+
+		register(t, "cliwatcher", "cliwatcher@email.com", "carreview123")
+		watcherToken := login(t, "cliwatcher@email.com", "carreview123")
+
+		status, _ := send(t, http.MethodPost, fmt.Sprintf("/api/brands/%d/watch", brand.ID), watcherToken, nil)
+		assert.Equal(t, 201, status)
+
+		cars = writeFile("cars.csv", header+"Clibrand,Clicar,GT,2021,https://example.com/clicar.jpg,1800,1300,4400,2.0L I4,350,manual,6.0,280,disc,disc,gasoline\n")
+
+		output, code = carreview("seed", "cars", "--file", cars)
+		assert.Equal(t, 0, code, output)
+		assert.Contains(t, output, "cars: 0 created, 1 updated, 0 failed")
+
+		status, notifications := send(t, http.MethodGet, "/api/users/notifications", watcherToken, nil)
+		assert.Equal(t, 200, status)
+
+		if assert.Len(t, notifications, 1) {
+			assert.Equal(t, entity.NotificationWatchSpecs, notifications.([]any)[0].(map[string]any)["type"])
+		}
+
+		output, code = carreview("seed", "cars", "--file", writeFile("broken.csv", "brand,wheels\nClibrand,4\n"))
+		assert.Equal(t, 1, code, output)
+	})
+
+	t.Run("should purge the trash", func(t *testing.T) {
+		output, code := carreview("trash", "purge", "--days", "30")
+		assert.Equal(t, 0, code, output)
+		assert.Contains(t, output, "purged")
+
+		output, code = carreview("trash", "purge", "--days", "-1")
+		assert.Equal(t, 1, code, output)
+	})
+}
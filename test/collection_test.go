@@ -0,0 +1,185 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/app"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollection(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	brandID := createBrand(t, adminToken, "Collectionbrand")
+	firstCarID := createCar(t, adminToken, brandID, "Collectioncar")
+	secondCarID := createCar(t, adminToken, brandID, "Secondcar")
+	thirdCarID := createCar(t, adminToken, brandID, "Thirdcar")
+
+	ownerID := register(t, "collector", "collector@email.com", "carreview123")
+	ownerToken := login(t, "collector@email.com", "carreview123")
+
+	register(t, "visitor", "visitor@email.com", "carreview123")
+	visitorToken := login(t, "visitor@email.com", "carreview123")
+
+	var slug string
+
+	t.Run("should keep favourites in the default collection", func(t *testing.T) {
+		for _, carID := range []uint{secondCarID, firstCarID} {
+			status, _ := send(t, http.MethodPost, fmt.Sprintf("/api/favourites/%d", carID), ownerToken, nil)
+			assert.Equal(t, 200, status)
+		}
+
+		status, _ := send(t, http.MethodPost, fmt.Sprintf("/api/favourites/%d", firstCarID), ownerToken, nil)
+		assert.Equal(t, 400, status)
+
+		status, favourites := send(t, http.MethodGet, "/api/users/favourites?limit=1", ownerToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, favourites, 1)
+		assert.Equal(t, float64(secondCarID), favourites.([]any)[0].(map[string]any)["car_id"])
+
+		status, collections := send(t, http.MethodGet, fmt.Sprintf("/api/users/%d/collections", ownerID), ownerToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, collections, 1)
+
+		defaults := collections.([]any)[0].(map[string]any)
+		assert.Equal(t, true, defaults["is_default"])
+		assert.Equal(t, float64(2), defaults["item_count"])
+
+		status, _ = send(t, http.MethodDelete, fmt.Sprintf("/api/collections/%s", defaults["slug"]), ownerToken, nil)
+		assert.Equal(t, 400, status)
+
+		status, _ = send(t, http.MethodDelete, fmt.Sprintf("/api/favourites/%d", thirdCarID), ownerToken, nil)
+		assert.Equal(t, 404, status)
+	})
+
+	t.Run("should manage a named collection", func(t *testing.T) {
+		status, _ := send(t, http.MethodPost, "/api/collections/", ownerToken, request.CollectionCreateRequest{Name: "Family SUVs", Visibility: "secret"})
+		assert.Equal(t, 400, status)
+
+		status, collection := send(t, http.MethodPost, "/api/collections/", ownerToken, request.CollectionCreateRequest{Name: "Family SUVs!", Visibility: entity.CollectionUnlisted})
+		assert.Equal(t, 201, status)
+		slug = collection.(map[string]any)["slug"].(string)
+		assert.Regexp(t, `^family-suvs-[a-z0-9]{10}$`, slug)
+
+		for _, carID := range []uint{firstCarID, secondCarID, thirdCarID} {
+			status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/collections/%s/items", slug), ownerToken, request.CollectionItemCreateRequest{CarID: carID, Note: "Seats seven"})
+			assert.Equal(t, 201, status)
+		}
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/collections/%s/items", slug), ownerToken, request.CollectionItemCreateRequest{CarID: firstCarID})
+		assert.Equal(t, 409, status)
+
+		status, item := send(t, http.MethodPatch, fmt.Sprintf("/api/collections/%s/items/%d", slug, secondCarID), ownerToken, request.CollectionItemUpdateRequest{Note: "Too small for the dog"})
+		assert.Equal(t, 200, status)
+		assert.Equal(t, "Too small for the dog", item.(map[string]any)["note"])
+
+		status, _ = send(t, http.MethodPut, fmt.Sprintf("/api/collections/%s/items/order", slug), ownerToken, request.CollectionReorderRequest{CarIDs: []uint{thirdCarID, firstCarID}})
+		assert.Equal(t, 400, status)
+
+		status, items := send(t, http.MethodPut, fmt.Sprintf("/api/collections/%s/items/order", slug), ownerToken, request.CollectionReorderRequest{CarIDs: []uint{thirdCarID, firstCarID, secondCarID}})
+		assert.Equal(t, 200, status)
+		if assert.Len(t, items, 3) {
+			assert.Equal(t, float64(thirdCarID), items.([]any)[0].(map[string]any)["car_id"])
+		}
+
+		status, _ = send(t, http.MethodDelete, fmt.Sprintf("/api/collections/%s/items/%d", slug, firstCarID), ownerToken, nil)
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodDelete, fmt.Sprintf("/api/collections/%s/items/%d", slug, firstCarID), ownerToken, nil)
+		assert.Equal(t, 404, status)
+
+		status, collection = send(t, http.MethodPatch, fmt.Sprintf("/api/collections/%s", slug), ownerToken, request.CollectionUpdateRequest{Name: "Dream garage"})
+		assert.Equal(t, 200, status)
+		assert.Equal(t, "Dream garage", collection.(map[string]any)["name"])
+		assert.Equal(t, slug, collection.(map[string]any)["slug"])
+		assert.Equal(t, float64(2), collection.(map[string]any)["item_count"])
+	})
+
+	t.Run("should share a collection by its visibility", func(t *testing.T) {
+		status, items := send(t, http.MethodGet, fmt.Sprintf("/api/collections/%s/items", slug), "", nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, items, 2)
+
+		// unlisted collections are not listed on the profile
+		status, collections := send(t, http.MethodGet, fmt.Sprintf("/api/users/%d/collections", ownerID), visitorToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, collections, 0)
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/collections/%s/items", slug), visitorToken, request.CollectionItemCreateRequest{CarID: firstCarID})
+		assert.Equal(t, 403, status)
+
+		status, _ = send(t, http.MethodPatch, fmt.Sprintf("/api/collections/%s", slug), ownerToken, request.CollectionUpdateRequest{Visibility: entity.CollectionPrivate})
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodGet, fmt.Sprintf("/api/collections/%s", slug), visitorToken, nil)
+		assert.Equal(t, 404, status)
+
+		status, _ = send(t, http.MethodGet, fmt.Sprintf("/api/collections/%s", slug), ownerToken, nil)
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodPatch, fmt.Sprintf("/api/collections/%s", slug), ownerToken, request.CollectionUpdateRequest{Visibility: entity.CollectionPublic})
+		assert.Equal(t, 200, status)
+
+		status, collections = send(t, http.MethodGet, fmt.Sprintf("/api/users/%d/collections", ownerID), "", nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, collections, 1)
+	})
+
+	t.Run("should delete a collection", func(t *testing.T) {
+		status, _ := send(t, http.MethodDelete, fmt.Sprintf("/api/collections/%s", slug), visitorToken, nil)
+		assert.Equal(t, 403, status)
+
+		status, _ = send(t, http.MethodDelete, fmt.Sprintf("/api/collections/%s", slug), ownerToken, nil)
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodGet, fmt.Sprintf("/api/collections/%s", slug), ownerToken, nil)
+		assert.Equal(t, 404, status)
+
+		// the default collection keeps its cars
+		status, favourites := send(t, http.MethodGet, "/api/users/favourites", ownerToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, favourites, 2)
+	})
+}
+
+func TestMigrateLegacyFavourites(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	brandID := createBrand(t, adminToken, "Legacybrand")
+	firstCarID := createCar(t, adminToken, brandID, "Legacycar")
+	secondCarID := createCar(t, adminToken, brandID, "Oldcar")
+
+	userID := register(t, "legacy", "legacy@email.com", "carreview123")
+	token := login(t, "legacy@email.com", "carreview123")
+
+	// favourites made before collections have no collection
+	err := DB.Exec("INSERT INTO favourites (user_id, car_id) VALUES (?, ?), (?, ?)", userID, secondCarID, userID, firstCarID).Error
+	assert.NoError(t, err)
+
+	assert.NoError(t, app.Migrate(DB))
+
+	status, favourites := send(t, http.MethodGet, "/api/users/favourites", token, nil)
+	assert.Equal(t, 200, status)
+	if assert.Len(t, favourites, 2) {
+		assert.Equal(t, float64(firstCarID), favourites.([]any)[0].(map[string]any)["car_id"])
+	}
+
+	var collection entity.FavouriteCollection
+	assert.NoError(t, DB.Take(&collection, "user_id = ? AND is_default", userID).Error)
+	assert.Equal(t, entity.CollectionPrivate, collection.Visibility)
+	assert.Regexp(t, `^favourites-`, collection.Slug)
+
+	// nothing is left to migrate
+	assert.NoError(t, app.Migrate(DB))
+
+	var count int64
+	DB.Model(&entity.FavouriteCollection{}).Where("user_id = ?", userID).Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/favourites/%d", firstCarID), token, nil)
+	assert.Equal(t, 400, status)
+}
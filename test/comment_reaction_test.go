@@ -0,0 +1,80 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentReaction(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	brandID := createBrand(t, adminToken, "Reactionbrand")
+	carID := createCar(t, adminToken, brandID, "MX-5")
+
+	register(t, "reacter", "reacter@email.com", "carreview123")
+	token := login(t, "reacter@email.com", "carreview123")
+
+	status, review := send(t, http.MethodPost, "/api/reviews/", token, request.ReviewCreateRequest{
+		CarID: carID, Title: "Tiny and fun", Content: "Light, balanced and honest.", ImageUrl: "https://example.com/mx5.jpg",
+	})
+	assert.Equal(t, 201, status)
+
+	reviewID := review.(map[string]any)["id"]
+
+	status, comment := send(t, http.MethodPost, "/api/comments/", token, request.CommentCreateRequest{ReviewID: uint(reviewID.(float64)), Content: "Agreed, best roadster"})
+	assert.Equal(t, 201, status)
+	assert.Equal(t, []any{}, comment.(map[string]any)["reactions"])
+
+	reactionPath := func(emoji string) string {
+		return fmt.Sprintf("/api/comments/%v/reactions/%s", comment.(map[string]any)["id"], url.PathEscape(emoji))
+	}
+
+	t.Run("should only accept the configured emojis", func(t *testing.T) {
+		status, _ := send(t, http.MethodPut, reactionPath("🍕"), token, nil)
+		assert.Equal(t, 400, status)
+	})
+
+	t.Run("should count reactions once per user", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			status, reactions := send(t, http.MethodPut, reactionPath("👍"), token, nil)
+			assert.Equal(t, 200, status)
+			assert.Equal(t, []any{map[string]any{"emoji": "👍", "count": float64(1), "reacted": true}}, reactions)
+		}
+
+		status, reactions := send(t, http.MethodPut, reactionPath("👍"), adminToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, float64(2), reactions.([]any)[0].(map[string]any)["count"])
+
+		// without the variation selector
+		status, _ = send(t, http.MethodPut, reactionPath("❤"), adminToken, nil)
+		assert.Equal(t, 200, status)
+	})
+
+	t.Run("should list the counts with the comments", func(t *testing.T) {
+		status, comments := send(t, http.MethodGet, fmt.Sprintf("/api/reviews/%v/comments", reviewID), token, nil)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, []any{
+			map[string]any{"emoji": "👍", "count": float64(2), "reacted": true},
+			map[string]any{"emoji": "❤️", "count": float64(1), "reacted": false},
+		}, comments.([]any)[0].(map[string]any)["reactions"])
+
+		status, comments = send(t, http.MethodGet, fmt.Sprintf("/api/reviews/%v/comments", reviewID), "", nil)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, false, comments.([]any)[0].(map[string]any)["reactions"].([]any)[0].(map[string]any)["reacted"])
+	})
+
+	t.Run("should remove a reaction", func(t *testing.T) {
+		status, reactions := send(t, http.MethodDelete, reactionPath("👍"), token, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, reactions, 2)
+		assert.Equal(t, float64(1), reactions.([]any)[0].(map[string]any)["count"])
+
+		status, _ = send(t, http.MethodDelete, reactionPath("👍"), token, nil)
+		assert.Equal(t, 404, status)
+	})
+}
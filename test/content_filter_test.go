@@ -0,0 +1,100 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentFilter(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	brandID := createBrand(t, adminToken, "Filterbrand")
+	carID := createCar(t, adminToken, brandID, "Civic")
+
+	register(t, "filtered", "filtered@email.com", "carreview123")
+	token := login(t, "filtered@email.com", "carreview123")
+
+	t.Run("should reject a banned word written in leetspeak", func(t *testing.T) {
+		status, _ := send(t, http.MethodPost, "/api/reviews/", token, request.ReviewCreateRequest{
+			CarID: carID, Title: "Great deal", Content: "This dealer is a $c-4-m, stay away", ImageUrl: "https://example.com/civic.jpg",
+		})
+		assert.Equal(t, 422, status)
+	})
+
+	var reviewID any
+
+	t.Run("should publish a clean review", func(t *testing.T) {
+		status, review := send(t, http.MethodPost, "/api/reviews/", token, request.ReviewCreateRequest{
+			CarID: carID, Title: "Track toy", Content: "Sharp steering and a gearbox that loves to be rushed.", ImageUrl: "https://example.com/civic.jpg",
+		})
+		assert.Equal(t, 201, status)
+		assert.Equal(t, entity.StatusPublished, review.(map[string]any)["status"])
+
+		reviewID = review.(map[string]any)["id"]
+	})
+
+	t.Run("should hold a comment with too many links", func(t *testing.T) {
+		status, comment := send(t, http.MethodPost, "/api/comments/", token, request.CommentCreateRequest{
+			ReviewID: uint(reviewID.(float64)), Content: "Parts at https://a.example.com https://b.example.com www.c.example.com",
+		})
+		assert.Equal(t, 201, status)
+		assert.Equal(t, entity.StatusPending, comment.(map[string]any)["status"])
+
+		var stored entity.Comment
+		DB.Take(&stored, uint(comment.(map[string]any)["id"].(float64)))
+		assert.Contains(t, *stored.Screening, `"rule":"links"`)
+	})
+
+	t.Run("should hold the same text posted again and again", func(t *testing.T) {
+		for i, content := range []string{"Best exhaust upgrade on the market", "best EXHAUST upgrade, on the market!", "Best  exhaust upgrade on the market..."} {
+			status, comment := send(t, http.MethodPost, "/api/comments/", token, request.CommentCreateRequest{ReviewID: uint(reviewID.(float64)), Content: content})
+			assert.Equal(t, 201, status)
+
+			expected := entity.StatusPublished
+			if i == 2 {
+				expected = entity.StatusPending
+			}
+			assert.Equal(t, expected, comment.(map[string]any)["status"], fmt.Sprintf("copy %d", i+1))
+		}
+	})
+
+	t.Run("should hold an edit that adds too many links", func(t *testing.T) {
+		content := "Updated: https://a.example.com https://b.example.com https://c.example.com"
+
+		status, _ := send(t, http.MethodPatch, fmt.Sprintf("/api/reviews/%v", reviewID), token, request.ReviewUpdateRequest{Content: &content})
+		assert.Equal(t, 200, status)
+
+		status, review := send(t, http.MethodGet, fmt.Sprintf("/api/reviews/%v", reviewID), token, nil)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, entity.StatusPending, review.(map[string]any)["status"])
+	})
+
+	t.Run("should hold a new account posting too often", func(t *testing.T) {
+		register(t, "hasty", "hasty@email.com", "carreview123")
+		hastyToken := login(t, "hasty@email.com", "carreview123")
+
+		for i := 1; i <= 9; i++ {
+			status, comment := send(t, http.MethodPost, "/api/comments/", hastyToken, request.CommentCreateRequest{
+				ReviewID: uint(reviewID.(float64)), Content: fmt.Sprintf("Hot take number %d", i),
+			})
+			assert.Equal(t, 201, status)
+
+			expected := entity.StatusPublished
+			if i == 9 {
+				expected = entity.StatusPending
+			}
+			assert.Equal(t, expected, comment.(map[string]any)["status"], fmt.Sprintf("comment %d", i))
+
+			if i == 9 {
+				var stored entity.Comment
+				DB.Take(&stored, uint(comment.(map[string]any)["id"].(float64)))
+				assert.Contains(t, *stored.Screening, `"rule":"velocity"`)
+			}
+		}
+	})
+}
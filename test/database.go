@@ -1,11 +1,17 @@
 package test
 
-import "gorm.io/gorm"
+import (
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"gorm.io/gorm"
+)
 
 func TruncateUser(db *gorm.DB) {
 	db.Exec("TRUNCATE TABLE users RESTART IDENTITY CASCADE")
 }
 
 func CreateRootUser(db *gorm.DB) {
-	db.Exec("INSERT INTO users (username, password, email, role) VALUES ('root', 'root@email.com', 'root', 'ADMIN')")
+	hashedPassword, err := helper.HashPassword("rootpassword")
+	helper.PanicIfError(err)
+
+	db.Exec("INSERT INTO users (username, email, password, role) VALUES ('root', 'root@email.com', ?, 'ADMIN')", hashedPassword)
 }
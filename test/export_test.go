@@ -0,0 +1,69 @@
+package test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport(t *testing.T) {
+	t.Run("should stream cars as csv", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/api/cars?format=csv", nil)
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		header, _ := bufio.NewReader(response.Body).ReadString('\n')
+
+		assert.Equal(t, 200, response.StatusCode)
+		assert.Equal(t, "text/csv; charset=utf-8", response.Header.Get("Content-Type"))
+		assert.Equal(t, "id,brand_id,brand_name,name,model,year,image_url,media_id,width,height,length,engine,torque,transmission,acceleration,horse_power,breaking_system_front,breaking_system_back,fuel\n", header)
+	})
+
+	t.Run("should keep formulas out of csv cells", func(t *testing.T) {
+		register(t, "exporter", "exporter@email.com", "carreview123")
+		DB.Model(&entity.User{}).Where("username = ?", "exporter").Update("role", entity.RoleAdmin)
+		adminToken := login(t, "exporter@email.com", "carreview123")
+
+		brandID := createBrand(t, adminToken, "Formulabrand")
+		createCar(t, adminToken, brandID, "=1+1")
+
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/cars?format=csv&brand_id=%d", brandID), nil)
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		body, _ := io.ReadAll(recorder.Result().Body)
+
+		assert.Equal(t, 200, recorder.Result().StatusCode)
+		assert.Contains(t, string(body), ",Formulabrand,'=1+1,GT,")
+	})
+
+	t.Run("should negotiate ndjson from accept header", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/api/reviews", nil)
+		request.Header.Add("Accept", "application/x-ndjson")
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, 200, recorder.Result().StatusCode)
+		assert.Equal(t, "application/x-ndjson", recorder.Result().Header.Get("Content-Type"))
+	})
+
+	t.Run("should require admin for admin exports", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/api/admin/export/comments", nil)
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, 401, recorder.Result().StatusCode)
+	})
+}
@@ -0,0 +1,122 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFollow(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	brandID := createBrand(t, adminToken, "Followbrand")
+	firstCarID := createCar(t, adminToken, brandID, "Followcar")
+	secondCarID := createCar(t, adminToken, brandID, "Othercar")
+
+	followerID := register(t, "follower", "follower@email.com", "carreview123")
+	followerToken := login(t, "follower@email.com", "carreview123")
+
+	authorID := register(t, "followed", "followed@email.com", "carreview123")
+	authorToken := login(t, "followed@email.com", "carreview123")
+
+	t.Run("should follow a user once", func(t *testing.T) {
+		status, _ := send(t, http.MethodPost, fmt.Sprintf("/api/users/%d/follow", authorID), followerToken, nil)
+		assert.Equal(t, 201, status)
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/users/%d/follow", authorID), followerToken, nil)
+		assert.Equal(t, 409, status)
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/users/%d/follow", followerID), followerToken, nil)
+		assert.Equal(t, 400, status)
+
+		status, profile := send(t, http.MethodGet, fmt.Sprintf("/api/users/profile/%d", authorID), "", nil)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, float64(1), profile.(map[string]any)["follower_count"])
+
+		status, profile = send(t, http.MethodGet, fmt.Sprintf("/api/users/profile/%d", followerID), "", nil)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, float64(1), profile.(map[string]any)["following_count"])
+	})
+
+	t.Run("should list followers and following", func(t *testing.T) {
+		status, _ := send(t, http.MethodPost, fmt.Sprintf("/api/brands/%d/follow", brandID), followerToken, nil)
+		assert.Equal(t, 201, status)
+
+		status, _ = send(t, http.MethodPost, "/api/cars/999999/follow", followerToken, nil)
+		assert.Equal(t, 404, status)
+
+		status, followers := send(t, http.MethodGet, fmt.Sprintf("/api/users/%d/followers", authorID), "", nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, followers, 1)
+		assert.Equal(t, "follower", followers.([]any)[0].(map[string]any)["name"])
+
+		status, following := send(t, http.MethodGet, fmt.Sprintf("/api/users/%d/following?type=brands", followerID), "", nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, following, 1)
+		assert.Equal(t, "Followbrand", following.([]any)[0].(map[string]any)["name"])
+
+		status, _ = send(t, http.MethodGet, fmt.Sprintf("/api/users/%d/following?type=planes", followerID), "", nil)
+		assert.Equal(t, 400, status)
+	})
+
+	t.Run("should page the feed newest first", func(t *testing.T) {
+		for _, carID := range []uint{firstCarID, secondCarID} {
+			status, _ := send(t, http.MethodPost, "/api/reviews/", authorToken, request.ReviewCreateRequest{
+				CarID: carID, Title: "Followed review", Content: "Worth a look.", ImageUrl: "https://example.com/car.jpg",
+			})
+			assert.Equal(t, 201, status)
+		}
+
+		// the reviews come from a followed user and a followed brand, but
+		// show up once each
+		feed := func(cursor string) ([]any, string) {
+			request := httptest.NewRequest(http.MethodGet, "/api/feed?limit=1&cursor="+url.QueryEscape(cursor), nil)
+			request.Header.Add("Authorization", "Bearer "+followerToken)
+
+			recorder := httptest.NewRecorder()
+			Router.ServeHTTP(recorder, request)
+			assert.Equal(t, 200, recorder.Code)
+
+			var result struct {
+				Payload  []any `json:"payload"`
+				Metadata struct {
+					NextCursor string `json:"next_cursor"`
+				} `json:"metadata"`
+			}
+			json.NewDecoder(recorder.Body).Decode(&result)
+
+			return result.Payload, result.Metadata.NextCursor
+		}
+
+		page, cursor := feed("")
+		assert.Len(t, page, 1)
+		assert.Equal(t, float64(secondCarID), page[0].(map[string]any)["car"].(map[string]any)["id"])
+		assert.NotEmpty(t, cursor)
+
+		page, cursor = feed(cursor)
+		assert.Len(t, page, 1)
+		assert.Equal(t, float64(firstCarID), page[0].(map[string]any)["car"].(map[string]any)["id"])
+		assert.Empty(t, cursor)
+
+		status, _ := send(t, http.MethodGet, "/api/feed?cursor=bogus", followerToken, nil)
+		assert.Equal(t, 400, status)
+	})
+
+	t.Run("should unfollow", func(t *testing.T) {
+		status, _ := send(t, http.MethodDelete, fmt.Sprintf("/api/users/%d/follow", authorID), followerToken, nil)
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodDelete, fmt.Sprintf("/api/users/%d/follow", authorID), followerToken, nil)
+		assert.Equal(t, 404, status)
+
+		status, profile := send(t, http.MethodGet, fmt.Sprintf("/api/users/profile/%d", authorID), "", nil)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, float64(0), profile.(map[string]any)["follower_count"])
+	})
+}
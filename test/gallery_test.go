@@ -0,0 +1,81 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/app"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGallery(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	carID := createCar(t, adminToken, createBrand(t, adminToken, "Gallerybrand"), "Civic")
+	images := fmt.Sprintf("/api/cars/%v/images", carID)
+
+	t.Run("should start with the image as cover", func(t *testing.T) {
+		status, gallery := send(t, http.MethodGet, images, "", nil)
+		assert.Equal(t, 200, status)
+
+		assert.Len(t, gallery, 1)
+		assert.Equal(t, true, gallery.([]any)[0].(map[string]any)["is_cover"])
+	})
+
+	t.Run("should forbid non admin", func(t *testing.T) {
+		register(t, "gallerist", "gallerist@email.com", "carreview123")
+		userToken := login(t, "gallerist@email.com", "carreview123")
+
+		status, _ := send(t, http.MethodPost, images, userToken, request.GalleryImageCreateRequest{ImageUrl: "https://example.com/interior.jpg"})
+
+		assert.Equal(t, 403, status)
+	})
+
+	t.Run("should add, reorder, change cover and remove", func(t *testing.T) {
+		send(t, http.MethodPost, images, adminToken, request.GalleryImageCreateRequest{ImageUrl: "https://example.com/interior.jpg", Caption: "Interior"})
+		status, gallery := send(t, http.MethodPost, images, adminToken, request.GalleryImageCreateRequest{ImageUrl: "https://example.com/engine.jpg", Caption: "Engine bay"})
+
+		assert.Equal(t, 201, status)
+		assert.Len(t, gallery, 3)
+
+		var ids []uint
+		for _, image := range gallery.([]any) {
+			ids = append(ids, uint(image.(map[string]any)["id"].(float64)))
+		}
+
+		status, gallery = send(t, http.MethodPut, images+"/order", adminToken, request.GalleryReorderRequest{ImageIDs: []uint{ids[2], ids[0], ids[1]}})
+		assert.Equal(t, 200, status)
+		assert.Equal(t, "Engine bay", gallery.([]any)[0].(map[string]any)["caption"])
+
+		status, _ = send(t, http.MethodPut, images+"/order", adminToken, request.GalleryReorderRequest{ImageIDs: []uint{ids[0]}})
+		assert.Equal(t, 400, status)
+
+		cover := true
+		send(t, http.MethodPatch, fmt.Sprintf("%s/%d", images, ids[2]), adminToken, request.GalleryImageUpdateRequest{IsCover: &cover})
+
+		_, car := send(t, http.MethodGet, fmt.Sprintf("/api/cars/%v", carID), "", nil)
+		assert.Equal(t, "https://example.com/engine.jpg", car.(map[string]any)["image_url"])
+
+		status, gallery = send(t, http.MethodDelete, fmt.Sprintf("%s/%d", images, ids[2]), adminToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, gallery, 2)
+		assert.Equal(t, true, gallery.([]any)[0].(map[string]any)["is_cover"])
+		assert.Equal(t, 0.0, gallery.([]any)[0].(map[string]any)["position"])
+	})
+
+	t.Run("should keep an emptied gallery empty after a restart", func(t *testing.T) {
+		_, gallery := send(t, http.MethodGet, images, "", nil)
+		for _, image := range gallery.([]any) {
+			status, _ := send(t, http.MethodDelete, fmt.Sprintf("%s/%v", images, image.(map[string]any)["id"]), adminToken, nil)
+			assert.Equal(t, 200, status)
+		}
+
+		assert.NoError(t, app.Migrate(DB))
+
+		status, gallery := send(t, http.MethodGet, images, "", nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, gallery, 0)
+	})
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+// send makes a JSON request and returns the status and the payload.
+func send(t *testing.T, method, path, token string, body any) (int, any) {
+	var requestBody string
+	if body != nil {
+		raw, _ := json.Marshal(body)
+		requestBody = string(raw)
+	}
+
+	request := httptest.NewRequest(method, path, strings.NewReader(requestBody))
+	request.Header.Add("Content-Type", "application/json")
+	if token != "" {
+		request.Header.Add("Authorization", "Bearer "+token)
+	}
+
+	recorder := httptest.NewRecorder()
+	Router.ServeHTTP(recorder, request)
+
+	var jsonResult map[string]any
+
+	json.NewDecoder(recorder.Result().Body).Decode(&jsonResult)
+
+	return recorder.Result().StatusCode, jsonResult["payload"]
+}
+
+// createBrand creates a brand as the admin behind token and returns its ID,
+// brand names are unique across the tests.
+func createBrand(t *testing.T, token, name string) uint {
+	status, brand := send(t, http.MethodPost, "/api/brands/", token, request.BrandRequest{Name: name})
+	assert.Equal(t, 201, status)
+
+	return uint(brand.(map[string]any)["id"].(float64))
+}
+
+// createCar creates a car of the brand as the admin behind token and returns
+// its ID. Only the name differs between the cars of the tests.
+func createCar(t *testing.T, token string, brandID uint, name string) uint {
+	status, car := send(t, http.MethodPost, "/api/cars/", token, request.CarCreateRequest{
+		BrandID: brandID, Name: name, Model: "GT", Year: 2024, ImageUrl: "https://example.com/car.jpg",
+		Width: 1850, Height: 1400, Length: 4500, Engine: "2.0L Turbo", Torque: 400, Transmission: "manual",
+		Acceleration: 5.5, HorsePower: 300, BreakingSystemFront: "disc", BreakingSystemBack: "disc", Fuel: "gasoline",
+	})
+	assert.Equal(t, 201, status)
+
+	return uint(car.(map[string]any)["id"].(float64))
+}
+
+// register signs a user up and returns their ID.
+func register(t *testing.T, username, email, password string) uint {
+	requestBody, _ := json.Marshal(request.RegisterRequest{Username: username, Email: email, Password: password})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/auth/register", strings.NewReader(string(requestBody)))
+	request.Header.Add("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+	Router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, 201, recorder.Result().StatusCode)
+
+	var user entity.User
+	DB.Take(&user, "username = ?", username)
+
+	return user.ID
+}
+
+// login signs the user in and returns the session token.
+func login(t *testing.T, email, password string) string {
+	requestBody, _ := json.Marshal(request.LoginRequest{Email: email, Password: password})
+
+	request := httptest.NewRequest(http.MethodPost, "/api/auth/login", strings.NewReader(string(requestBody)))
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("User-Agent", "session-test")
+
+	recorder := httptest.NewRecorder()
+	Router.ServeHTTP(recorder, request)
+
+	var jsonResult map[string]any
+
+	json.NewDecoder(recorder.Result().Body).Decode(&jsonResult)
+
+	assert.Equal(t, 200, recorder.Result().StatusCode)
+
+	return jsonResult["payload"].(map[string]any)["token"].(string)
+}
@@ -3,19 +3,18 @@ package test
 import (
 	"fmt"
 	"net/http"
-	"net/url"
-	"strings"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"github.com/gin-gonic/gin/binding"
-	"github.com/go-playground/validator/v10"
+	"github.com/raihanmd/fp-superbootcamp-go/app"
 	"github.com/raihanmd/fp-superbootcamp-go/controllers"
 	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
 	"github.com/raihanmd/fp-superbootcamp-go/helper"
 	"github.com/raihanmd/fp-superbootcamp-go/middlewares"
 	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
 	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"go.uber.org/zap"
@@ -28,13 +27,7 @@ func NewUnitTestDatabase() *gorm.DB {
 	db, err := gorm.Open(postgres.Open(helper.MustGetEnv("DB_DSN")), &gorm.Config{})
 	helper.PanicIfError(err)
 
-	err = db.AutoMigrate(&entity.User{}, &entity.Car{}, &entity.CarSpecification{}, &entity.Brand{}, &entity.Review{}, &entity.Comment{}, &entity.Favourite{}, &entity.Profile{})
-	helper.PanicIfError(err)
-
-	db.Exec("CREATE INDEX IF NOT EXISTS idx_title_fulltext ON reviews USING GIN (to_tsvector('english', title))")
-
-	db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm;")
-	db.Exec("CREATE INDEX idx_model_gin ON cars USING GIN (model gin_trgm_ops);")
+	helper.PanicIfError(app.Migrate(db))
 
 	return db
 }
@@ -42,21 +35,7 @@ func NewUnitTestDatabase() *gorm.DB {
 func NewRouter(db *gorm.DB) *gin.Engine {
 	r := gin.Default()
 
-	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		v.RegisterValidation("no_space", func(fl validator.FieldLevel) bool {
-			return !strings.Contains(fl.Field().String(), " ")
-		})
-		v.RegisterValidation("lowercase", func(fl validator.FieldLevel) bool {
-			return fl.Field().String() == strings.ToLower(fl.Field().String())
-		})
-		v.RegisterValidation("uppercase", func(fl validator.FieldLevel) bool {
-			return fl.Field().String() == strings.ToUpper(fl.Field().String())
-		})
-		v.RegisterValidation("url", func(fl validator.FieldLevel) bool {
-			_, err := url.ParseRequestURI(fl.Field().String())
-			return err == nil
-		})
-	}
+	app.RegisterValidations()
 
 	cfg := zap.Config{
 		OutputPaths: []string{"./log/log.log"},
@@ -74,40 +53,106 @@ func NewRouter(db *gorm.DB) *gin.Engine {
 	helper.PanicIfError(err)
 	defer logger.Sync()
 
-	userService := services.NewUserService()
-	carService := services.NewCarService()
-	reviewService := services.NewreviewService()
-	brandService := services.NewBrandService()
+	passwordPolicyService := services.NewPasswordPolicyService()
+	userService := services.NewUserService(passwordPolicyService)
+	eventBus := services.NewEventBus()
+	outbox := services.NewOutbox()
+	carService := services.NewCarService(eventBus)
+	contentFilter := services.NewContentFilter(
+		services.NewBannedWordsRule([]string{"scam", "free money"}, services.FilterReject),
+		services.NewLinkLimitRule(2, services.FilterHold),
+		services.NewDuplicateContentRule(24*time.Hour, 20, 2, services.FilterHold),
+		services.NewVelocityRule(7*24*time.Hour, time.Hour, 8, services.FilterHold),
+	)
+	reviewService := services.NewreviewService(contentFilter, eventBus)
+	brandService := services.NewBrandService(eventBus)
 	favouriteService := services.NewFavouriteService()
-	commentService := services.NewCommentService()
+	collectionService := services.NewCollectionService()
+	reviewVoteService := services.NewReviewVoteService()
+	commentReactionService := services.NewCommentReactionService()
+	notificationService := services.NewNotificationService(eventBus)
+	commentService := services.NewCommentService(contentFilter, eventBus)
+	oidcService := services.NewOIDCService()
+	sessionService := services.NewSessionService()
+	auditService := services.NewAuditService()
+	adminUserService := services.NewAdminUserService()
+	carImportService := services.NewCarImportService(carService, brandService)
+	exportService := services.NewExportService()
+	mediaService := services.NewMediaService(utils.NewBlobStore(), outbox)
+	galleryService := services.NewGalleryService()
+	revisionService := services.NewRevisionService()
+	moderationService := services.NewModerationService(eventBus)
+	streamHub := services.NewStreamHub(services.NewStreamBackplane(db, logger))
+	streamService := services.NewStreamService(streamHub, eventBus)
+	streamHub.Start(db, logger)
+	trashService := services.NewTrashService()
+	followService := services.NewFollowService()
+	watchService := services.NewWatchService(outbox, utils.NewDiscardMailer())
+	webhookService := services.NewWebhookService(eventBus, outbox, services.NewWebhookClient())
 
 	// ======================== USER =======================
 
 	userController := controllers.NewUserController(userService, favouriteService, reviewService)
+	userFollowController := controllers.NewFollowController(followService, entity.FollowTargetUser)
+	watchController := controllers.NewWatchController(watchService, "")
+	oidcController := controllers.NewOIDCController(oidcService)
+	sessionController := controllers.NewSessionController(sessionService)
+	auditController := controllers.NewAuditController(auditService)
+	adminUserController := controllers.NewAdminUserController(adminUserService)
+	carImportController := controllers.NewCarImportController(carImportService)
+	exportController := controllers.NewExportController(exportService)
+	mediaController := controllers.NewMediaController(mediaService)
 
 	// ======================== CARD =======================
 
-	carController := controllers.NewCarController(carService)
+	carController := controllers.NewCarController(carService, exportService)
+	carGalleryController := controllers.NewGalleryController(galleryService, entity.GalleryOwnerCar)
+	carRevisionController := controllers.NewRevisionController(revisionService, entity.RevisionOwnerCar)
+	carTrashController := controllers.NewTrashController(trashService, services.TrashCars)
+	carFollowController := controllers.NewFollowController(followService, entity.FollowTargetCar)
+	carWatchController := controllers.NewWatchController(watchService, entity.FollowTargetCar)
 
 	// ======================== REVIEW =======================
 
-	reviewController := controllers.NewreviewController(reviewService, commentService)
+	reviewController := controllers.NewreviewController(reviewService, commentService, exportService)
+	reviewGalleryController := controllers.NewGalleryController(galleryService, entity.GalleryOwnerReview)
+	reviewRevisionController := controllers.NewRevisionController(revisionService, entity.RevisionOwnerReview)
+	reviewReportController := controllers.NewReportController(moderationService, entity.ReportTargetReview)
+	reviewVoteController := controllers.NewReviewVoteController(reviewVoteService)
+	reviewTrashController := controllers.NewTrashController(trashService, services.TrashReviews)
 
 	// ======================== BRAND =======================
 
 	brandController := controllers.NewBrandController(brandService)
+	brandTrashController := controllers.NewTrashController(trashService, services.TrashBrands)
+	brandFollowController := controllers.NewFollowController(followService, entity.FollowTargetBrand)
+	brandWatchController := controllers.NewWatchController(watchService, entity.FollowTargetBrand)
 
 	// ======================== FAVOURITE =======================
 
 	favouriteController := controllers.NewFavouriteController(favouriteService)
+	collectionController := controllers.NewCollectionController(collectionService)
 
 	// ======================== COMMENT =======================
 
 	commentController := controllers.NewCommentController(commentService)
+	commentTrashController := controllers.NewTrashController(trashService, services.TrashComments)
+	commentReportController := controllers.NewReportController(moderationService, entity.ReportTargetComment)
+	commentReactionController := controllers.NewCommentReactionController(commentReactionService)
+	notificationController := controllers.NewNotificationController(notificationService)
+	moderationController := controllers.NewModerationController(moderationService)
+
+	// ======================== STREAM =======================
+
+	streamController := controllers.NewStreamController(streamService)
+
+	// ======================== WEBHOOK =======================
+
+	webhookController := controllers.NewWebhookController(webhookService)
 
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowAllOrigins = true
-	corsConfig.AllowHeaders = []string{"Content-Type", "X-XSRF-TOKEN", "Accept", "Origin", "X-Requested-With", "Authorization"}
+	corsConfig.AllowHeaders = []string{"Content-Type", "X-XSRF-TOKEN", "Accept", "Origin", "X-Requested-With", "Authorization", "Last-Event-ID"}
 
 	corsConfig.AllowCredentials = true
 	corsConfig.AddAllowMethods("OPTIONS")
@@ -119,6 +164,8 @@ func NewRouter(db *gorm.DB) *gin.Engine {
 		c.Set("logger", logger)
 	})
 
+	r.Use(middlewares.RequestIDMiddleware)
+
 	r.Use(exceptions.GlobalErrorHandler)
 
 	r.NoRoute(func(c *gin.Context) {
@@ -131,6 +178,8 @@ func NewRouter(db *gorm.DB) *gin.Engine {
 
 	apiRouter.POST("/auth/register", userController.Register)
 	apiRouter.POST("/auth/login", userController.Login)
+	apiRouter.GET("/auth/oidc/:provider/start", oidcController.Start)
+	apiRouter.GET("/auth/oidc/:provider/callback", oidcController.Callback)
 
 	// ======================== USERS ROUTE =======================
 
@@ -138,11 +187,27 @@ func NewRouter(db *gorm.DB) *gin.Engine {
 
 	apiRouter.GET("/users/profile/:id", userController.GetUserProfile)
 	apiRouter.GET("/users/favourites", userController.GetFavourites)
+	apiRouter.GET("/users/:id/followers", userFollowController.FindFollowers)
+	apiRouter.GET("/users/:id/following", userFollowController.FindFollowing)
+	apiRouter.GET("/users/:id/collections", collectionController.FindByUser)
 
 	userRouter.Use(middlewares.JwtAuthMiddleware)
 
 	userRouter.PATCH("/password", userController.UpdatePassword)
 	userRouter.PATCH("/profile", userController.UpdateUserProfile)
+	userRouter.GET("/sessions", sessionController.FindAll)
+	userRouter.DELETE("/sessions", sessionController.RevokeAll)
+	userRouter.DELETE("/sessions/:id", sessionController.Revoke)
+	userRouter.GET("/notifications", notificationController.FindAll)
+	userRouter.PATCH("/notifications/:id/read", notificationController.MarkRead)
+	userRouter.POST("/notifications/read-all", notificationController.MarkAllRead)
+	userRouter.GET("/notifications/preferences", notificationController.FindPreferences)
+	userRouter.PUT("/notifications/preferences", notificationController.UpdatePreferences)
+	userRouter.GET("/watches", watchController.FindAll)
+	userRouter.GET("/watches/digest", watchController.FindDigest)
+	userRouter.PUT("/watches/digest", watchController.UpdateDigest)
+	userRouter.POST("/:id/follow", userFollowController.Follow)
+	userRouter.DELETE("/:id/follow", userFollowController.Unfollow)
 	userRouter.DELETE("/", userController.DeleteUserProfile)
 
 	// ======================== CARS ROUTE =======================
@@ -151,12 +216,26 @@ func NewRouter(db *gorm.DB) *gin.Engine {
 
 	carRouter.GET("/", carController.FindAll)
 	carRouter.GET("/:id", carController.FindById)
+	carRouter.GET("/:id/images", carGalleryController.FindAll)
+	carRouter.GET("/:id/revisions", carRevisionController.FindAll)
+	carRouter.GET("/:id/revisions/diff", carRevisionController.Diff)
+	carRouter.GET("/:id/revisions/:number", carRevisionController.FindByNumber)
+	carRouter.GET("/:id/followers", carFollowController.FindFollowers)
 
 	carRouter.Use(middlewares.JwtAuthMiddleware)
 
 	carRouter.POST("/", carController.Create)
 	carRouter.PATCH("/:id", carController.Update)
 	carRouter.DELETE("/:id", carController.Delete)
+	carRouter.POST("/:id/restore", carTrashController.Restore)
+	carRouter.POST("/:id/follow", carFollowController.Follow)
+	carRouter.DELETE("/:id/follow", carFollowController.Unfollow)
+	carRouter.POST("/:id/watch", carWatchController.Watch)
+	carRouter.DELETE("/:id/watch", carWatchController.Unwatch)
+	carRouter.POST("/:id/images", carGalleryController.Add)
+	carRouter.PUT("/:id/images/order", carGalleryController.Reorder)
+	carRouter.PATCH("/:id/images/:imageID", carGalleryController.Update)
+	carRouter.DELETE("/:id/images/:imageID", carGalleryController.Remove)
 
 	// ======================== REVIEW ROUTE =======================
 
@@ -164,6 +243,10 @@ func NewRouter(db *gorm.DB) *gin.Engine {
 
 	reviewRouter.GET("/", reviewController.FindAll)
 	reviewRouter.GET("/:id", reviewController.FindById)
+	reviewRouter.GET("/:id/images", reviewGalleryController.FindAll)
+	reviewRouter.GET("/:id/revisions", reviewRevisionController.FindAll)
+	reviewRouter.GET("/:id/revisions/diff", reviewRevisionController.Diff)
+	reviewRouter.GET("/:id/revisions/:number", reviewRevisionController.FindByNumber)
 
 	reviewRouter.GET("/:id/comments", reviewController.FindComments) // comment controller
 
@@ -172,18 +255,32 @@ func NewRouter(db *gorm.DB) *gin.Engine {
 	reviewRouter.POST("/", reviewController.Create)
 	reviewRouter.PATCH("/:id", reviewController.Update)
 	reviewRouter.DELETE("/:id", reviewController.Delete)
+	reviewRouter.POST("/:id/restore", reviewTrashController.Restore)
+	reviewRouter.POST("/:id/report", reviewReportController.Create)
+	reviewRouter.PUT("/:id/vote", reviewVoteController.Vote)
+	reviewRouter.DELETE("/:id/vote", reviewVoteController.Unvote)
+	reviewRouter.POST("/:id/images", reviewGalleryController.Add)
+	reviewRouter.PUT("/:id/images/order", reviewGalleryController.Reorder)
+	reviewRouter.PATCH("/:id/images/:imageID", reviewGalleryController.Update)
+	reviewRouter.DELETE("/:id/images/:imageID", reviewGalleryController.Remove)
 
 	// ======================== BRAND ROUTE =======================
 
 	brandRouter := apiRouter.Group("/brands")
 
 	brandRouter.GET("/", brandController.FindAll)
+	brandRouter.GET("/:id/followers", brandFollowController.FindFollowers)
 
 	brandRouter.Use(middlewares.JwtAuthMiddleware)
 
 	brandRouter.POST("/", brandController.Create)
 	brandRouter.PATCH("/:id", brandController.Update)
 	brandRouter.DELETE("/:id", brandController.Delete)
+	brandRouter.POST("/:id/restore", brandTrashController.Restore)
+	brandRouter.POST("/:id/follow", brandFollowController.Follow)
+	brandRouter.DELETE("/:id/follow", brandFollowController.Unfollow)
+	brandRouter.POST("/:id/watch", brandWatchController.Watch)
+	brandRouter.DELETE("/:id/watch", brandWatchController.Unwatch)
 
 	// ======================== FAVOURITE ROUTE =======================
 
@@ -192,6 +289,23 @@ func NewRouter(db *gorm.DB) *gin.Engine {
 	favouriteRouter.POST("/:carID", favouriteController.FavouriteCar)
 	favouriteRouter.DELETE("/:carID", favouriteController.UnfavouriteCar)
 
+	// ======================== COLLECTION ROUTE =======================
+
+	collectionRouter := apiRouter.Group("/collections")
+
+	collectionRouter.GET("/:slug", collectionController.FindBySlug)
+	collectionRouter.GET("/:slug/items", collectionController.FindItems)
+
+	collectionRouter.Use(middlewares.JwtAuthMiddleware)
+
+	collectionRouter.POST("/", collectionController.Create)
+	collectionRouter.PATCH("/:slug", collectionController.Update)
+	collectionRouter.DELETE("/:slug", collectionController.Delete)
+	collectionRouter.POST("/:slug/items", collectionController.AddItem)
+	collectionRouter.PUT("/:slug/items/order", collectionController.Reorder)
+	collectionRouter.PATCH("/:slug/items/:carID", collectionController.UpdateItem)
+	collectionRouter.DELETE("/:slug/items/:carID", collectionController.RemoveItem)
+
 	// ======================== COMMENT ROUTE =======================
 
 	commentRouter := apiRouter.Group("/comments")
@@ -199,6 +313,71 @@ func NewRouter(db *gorm.DB) *gin.Engine {
 	commentRouter.POST("/", commentController.Create)
 	commentRouter.PATCH("/:id", commentController.Update)
 	commentRouter.DELETE("/:id", commentController.Delete)
+	commentRouter.POST("/:id/restore", commentTrashController.Restore)
+	commentRouter.POST("/:id/report", commentReportController.Create)
+	commentRouter.PUT("/:id/reactions/:emoji", commentReactionController.React)
+	commentRouter.DELETE("/:id/reactions/:emoji", commentReactionController.Unreact)
+
+	// ======================== FEED ROUTE =======================
+
+	apiRouter.GET("/feed", middlewares.JwtAuthMiddleware, reviewController.Feed)
+
+	// ======================== STREAM ROUTE =======================
+
+	apiRouter.GET("/stream", streamController.Stream)
+
+	// ======================== MODERATION ROUTE =======================
+
+	moderationRouter := apiRouter.Group("/moderation")
+
+	moderationRouter.Use(middlewares.JwtAuthMiddleware)
+
+	moderationRouter.GET("/queue", moderationController.Queue)
+	moderationRouter.POST("/:type/:id", moderationController.Decide)
+
+	// ======================== MEDIA ROUTE =======================
+
+	mediaRouter := apiRouter.Group("/media")
+
+	mediaRouter.GET("/:id", mediaController.FindByID)
+	mediaRouter.GET("/:id/:variant", mediaController.File)
+
+	mediaRouter.Use(middlewares.JwtAuthMiddleware)
+
+	mediaRouter.POST("/", mediaController.Upload)
+	mediaRouter.DELETE("/:id", mediaController.Delete)
+
+	// ======================== ADMIN ROUTE =======================
+
+	adminRouter := apiRouter.Group("/admin")
+
+	adminRouter.Use(middlewares.JwtAuthMiddleware)
+
+	adminRouter.GET("/audit", auditController.FindAll)
+	adminRouter.GET("/audit/export", auditController.Export)
+	adminRouter.GET("/users", adminUserController.FindAll)
+	adminRouter.GET("/users/:id", adminUserController.FindByID)
+	adminRouter.PATCH("/users/:id/role", adminUserController.UpdateRole)
+	adminRouter.POST("/users/:id/ban", adminUserController.Ban)
+	adminRouter.DELETE("/users/:id/ban", adminUserController.Unban)
+	adminRouter.POST("/users/:id/force-password-reset", adminUserController.ForcePasswordReset)
+	adminRouter.POST("/users/:id/impersonate", adminUserController.Impersonate)
+	adminRouter.POST("/cars/import", carImportController.Import)
+	adminRouter.GET("/cars/import/:id", carImportController.FindJob)
+	adminRouter.GET("/export/cars", exportController.Cars)
+	adminRouter.GET("/export/reviews", exportController.Reviews)
+	adminRouter.GET("/export/comments", exportController.Comments)
+	adminRouter.GET("/trash/cars", carTrashController.FindAll)
+	adminRouter.GET("/trash/brands", brandTrashController.FindAll)
+	adminRouter.GET("/trash/reviews", reviewTrashController.FindAll)
+	adminRouter.GET("/trash/comments", commentTrashController.FindAll)
+	adminRouter.GET("/webhooks", webhookController.FindAll)
+	adminRouter.POST("/webhooks", webhookController.Create)
+	adminRouter.GET("/webhooks/:id", webhookController.FindByID)
+	adminRouter.PATCH("/webhooks/:id", webhookController.Update)
+	adminRouter.DELETE("/webhooks/:id", webhookController.Delete)
+	adminRouter.GET("/webhooks/:id/deliveries", webhookController.FindDeliveries)
+	adminRouter.POST("/webhooks/:id/deliveries/:deliveryID/redeliver", webhookController.Redeliver)
 
 	r.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.DefaultModelsExpandDepth(-1)))
 
@@ -0,0 +1,137 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedia(t *testing.T) {
+	token := login(t, "root@email.com", "rootpassword")
+
+	img := image.NewRGBA(image.Rect(0, 0, 800, 400))
+	for x := 0; x < 800; x++ {
+		img.Set(x, x%400, color.RGBA{255, 0, 0, 255})
+	}
+
+	var file bytes.Buffer
+	png.Encode(&file, img)
+
+	upload := func(data []byte) (int, map[string]any) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, _ := writer.CreateFormFile("file", "photo.jpg")
+		part.Write(data)
+		writer.Close()
+
+		request := httptest.NewRequest(http.MethodPost, "/api/media/", &body)
+		request.Header.Add("Content-Type", writer.FormDataContentType())
+		request.Header.Add("Authorization", "Bearer "+token)
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		var jsonResult map[string]any
+
+		json.NewDecoder(recorder.Result().Body).Decode(&jsonResult)
+
+		payload, _ := jsonResult["payload"].(map[string]any)
+
+		return recorder.Result().StatusCode, payload
+	}
+
+	t.Run("should sniff the content type and make thumbnails", func(t *testing.T) {
+		status, media := upload(file.Bytes())
+
+		assert.Equal(t, 201, status)
+		assert.Equal(t, "image/png", media["content_type"])
+		assert.Equal(t, 800.0, media["width"])
+
+		// 1024 is larger than the image
+		variants := media["variants"].([]any)
+		assert.Len(t, variants, 3)
+		assert.Equal(t, "thumb_160", variants[1].(map[string]any)["name"])
+		assert.Equal(t, 80.0, variants[1].(map[string]any)["height"])
+
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/media/%v/thumb_160", media["id"]), nil)
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, 200, recorder.Result().StatusCode)
+		assert.Equal(t, "image/png", recorder.Result().Header.Get("Content-Type"))
+
+		thumbnail, _, err := image.DecodeConfig(recorder.Result().Body)
+		assert.Nil(t, err)
+		assert.Equal(t, 160, thumbnail.Width)
+	})
+
+	t.Run("should reject files that are not images", func(t *testing.T) {
+		status, _ := upload([]byte("<html><body>not an image</body></html>"))
+
+		assert.Equal(t, 415, status)
+	})
+}
+
+func TestS3BlobStore(t *testing.T) {
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+
+	// a minimal MinIO style stand-in, path style bucket/key addressing
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") || r.Header.Get("X-Amz-Content-Sha256") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPut:
+			objects[r.URL.Path], _ = io.ReadAll(r.Body)
+		case http.MethodGet:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(data)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	store := utils.NewS3BlobStore(utils.S3Config{Endpoint: server.URL, Region: "us-east-1", Bucket: "media", AccessKey: "access", SecretKey: "secret"})
+	ctx := context.Background()
+
+	assert.Nil(t, store.Put(ctx, "a/b.png", []byte("data"), "image/png"))
+	assert.Contains(t, objects, "/media/a/b.png")
+
+	blob, err := store.Get(ctx, "a/b.png")
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(blob)
+	blob.Close()
+	assert.Equal(t, "data", string(data))
+
+	assert.Nil(t, store.Delete(ctx, "a/b.png"))
+
+	_, err = store.Get(ctx, "a/b.png")
+	assert.ErrorIs(t, err, utils.ErrBlobNotFound)
+}
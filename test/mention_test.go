@@ -0,0 +1,67 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMention(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	brandID := createBrand(t, adminToken, "Mentionbrand")
+	carID := createCar(t, adminToken, brandID, "Model 3")
+
+	mentionedID := register(t, "mentioned", "mentioned@email.com", "carreview123")
+	mentionedToken := login(t, "mentioned@email.com", "carreview123")
+
+	register(t, "mentioner", "mentioner@email.com", "carreview123")
+	token := login(t, "mentioner@email.com", "carreview123")
+
+	status, review := send(t, http.MethodPost, "/api/reviews/", token, request.ReviewCreateRequest{
+		CarID: carID, Title: "Quick", Content: "Ask @mentioned, not me (@mentioner).", ImageUrl: "https://example.com/model3.jpg",
+	})
+	assert.Equal(t, 201, status)
+	assert.Len(t, review.(map[string]any)["mentions"], 2)
+
+	reviewID := review.(map[string]any)["id"]
+
+	var commentID any
+
+	t.Run("should link existing users only", func(t *testing.T) {
+		status, comment := send(t, http.MethodPost, "/api/comments/", token, request.CommentCreateRequest{
+			ReviewID: uint(reviewID.(float64)), Content: "Thanks @Mentioned and @ghostuser, mail me at me@example.com.",
+		})
+		assert.Equal(t, 201, status)
+		assert.Equal(t, []any{map[string]any{"user_id": float64(mentionedID), "username": "mentioned", "start": float64(7), "end": float64(17)}}, comment.(map[string]any)["mentions"])
+
+		commentID = comment.(map[string]any)["id"]
+
+		status, comments := send(t, http.MethodGet, fmt.Sprintf("/api/reviews/%v/comments", reviewID), "", nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, comments.([]any)[0].(map[string]any)["mentions"], 1)
+	})
+
+	t.Run("should notify the mentioned user once", func(t *testing.T) {
+		content := "Thanks again @mentioned."
+		status, _ := send(t, http.MethodPatch, fmt.Sprintf("/api/comments/%v", commentID), token, request.CommentUpdateRequest{Content: content})
+		assert.Equal(t, 200, status)
+
+		status, notifications := send(t, http.MethodGet, "/api/users/notifications", mentionedToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, notifications, 2)
+
+		latest := notifications.([]any)[0].(map[string]any)
+		assert.Equal(t, "mention", latest["type"])
+		assert.Equal(t, "comments", latest["target_type"])
+		assert.Equal(t, commentID, latest["target_id"])
+		assert.Equal(t, "mentioner", latest["actor_username"])
+
+		status, notifications = send(t, http.MethodGet, "/api/users/notifications", token, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, notifications, 0)
+	})
+}
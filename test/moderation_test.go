@@ -0,0 +1,124 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModeration(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	brandID := createBrand(t, adminToken, "Moderationbrand")
+	carID := createCar(t, adminToken, brandID, "Golf")
+
+	register(t, "spammer", "spammer@email.com", "carreview123")
+	authorToken := login(t, "spammer@email.com", "carreview123")
+
+	var reporterTokens []string
+	for i := 1; i <= 3; i++ {
+		register(t, fmt.Sprintf("reporter%d", i), fmt.Sprintf("reporter%d@email.com", i), "carreview123")
+		reporterTokens = append(reporterTokens, login(t, fmt.Sprintf("reporter%d@email.com", i), "carreview123"))
+	}
+
+	status, review := send(t, http.MethodPost, "/api/reviews/", authorToken, request.ReviewCreateRequest{
+		CarID: carID, Title: "Cheap parts", Content: "Buy them at my shop", ImageUrl: "https://example.com/shop.jpg",
+	})
+	assert.Equal(t, 201, status)
+	assert.Equal(t, entity.StatusPublished, review.(map[string]any)["status"])
+
+	reviewID := review.(map[string]any)["id"]
+	reviewPath := fmt.Sprintf("/api/reviews/%v", reviewID)
+
+	t.Run("should not report your own review", func(t *testing.T) {
+		status, _ := send(t, http.MethodPost, reviewPath+"/report", authorToken, request.ReportCreateRequest{Reason: "spam"})
+		assert.Equal(t, 400, status)
+	})
+
+	t.Run("should hide a review after enough reports", func(t *testing.T) {
+		for i, token := range reporterTokens {
+			status, _ := send(t, http.MethodPost, reviewPath+"/report", token, request.ReportCreateRequest{Reason: "spam", Details: "Advertising"})
+			assert.Equal(t, 201, status)
+
+			if i == 0 {
+				status, _ = send(t, http.MethodPost, reviewPath+"/report", token, request.ReportCreateRequest{Reason: "spam"})
+				assert.Equal(t, 409, status)
+			}
+		}
+
+		status, _ := send(t, http.MethodGet, reviewPath, "", nil)
+		assert.Equal(t, 404, status)
+
+		status, own := send(t, http.MethodGet, reviewPath, authorToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, entity.StatusHidden, own.(map[string]any)["status"])
+	})
+
+	t.Run("should hide the images and revisions of a hidden review", func(t *testing.T) {
+		for _, path := range []string{reviewPath + "/images", reviewPath + "/revisions"} {
+			status, _ := send(t, http.MethodGet, path, "", nil)
+			assert.Equal(t, 404, status)
+
+			status, _ = send(t, http.MethodGet, path, reporterTokens[0], nil)
+			assert.Equal(t, 404, status)
+
+			status, _ = send(t, http.MethodGet, path, authorToken, nil)
+			assert.Equal(t, 200, status)
+
+			status, _ = send(t, http.MethodGet, path, adminToken, nil)
+			assert.Equal(t, 200, status)
+		}
+	})
+
+	t.Run("should list the hidden review in the queue", func(t *testing.T) {
+		status, _ := send(t, http.MethodGet, "/api/moderation/queue", authorToken, nil)
+		assert.Equal(t, 403, status)
+
+		status, items := send(t, http.MethodGet, "/api/moderation/queue?type=reviews", adminToken, nil)
+		assert.Equal(t, 200, status)
+
+		found := false
+		for _, item := range items.([]any) {
+			if item.(map[string]any)["target_id"] == reviewID {
+				found = true
+				assert.Equal(t, float64(3), item.(map[string]any)["reports"])
+				assert.Equal(t, []any{"spam"}, item.(map[string]any)["reasons"])
+				assert.Equal(t, "spammer", item.(map[string]any)["author_username"])
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("should publish an approved review again", func(t *testing.T) {
+		status, decision := send(t, http.MethodPost, fmt.Sprintf("/api/moderation/reviews/%v", reviewID), adminToken, request.ModerationDecisionRequest{Action: "approve", Note: "Not spam"})
+		assert.Equal(t, 200, status)
+		assert.Equal(t, float64(3), decision.(map[string]any)["resolved_reports"])
+
+		status, _ = send(t, http.MethodGet, reviewPath, "", nil)
+		assert.Equal(t, 200, status)
+
+		var count int64
+		DB.Model(&entity.AuditEvent{}).Where("action = ? AND target_id = ?", "review.moderate", reviewID).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("should reject a reported comment", func(t *testing.T) {
+		status, comment := send(t, http.MethodPost, "/api/comments/", authorToken, request.CommentCreateRequest{ReviewID: uint(reviewID.(float64)), Content: "Visit my shop"})
+		assert.Equal(t, 201, status)
+
+		commentID := comment.(map[string]any)["id"]
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/comments/%v/report", commentID), reporterTokens[0], request.ReportCreateRequest{Reason: "off_topic"})
+		assert.Equal(t, 201, status)
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/moderation/comments/%v", commentID), adminToken, request.ModerationDecisionRequest{Action: "reject"})
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/comments/%v/report", commentID), reporterTokens[1], request.ReportCreateRequest{Reason: "spam"})
+		assert.Equal(t, 404, status)
+	})
+}
@@ -0,0 +1,133 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotification(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	brandID := createBrand(t, adminToken, "Notifybrand")
+	carID := createCar(t, adminToken, brandID, "Civic")
+
+	register(t, "notifyauthor", "notifyauthor@email.com", "carreview123")
+	authorToken := login(t, "notifyauthor@email.com", "carreview123")
+
+	register(t, "notifyreader", "notifyreader@email.com", "carreview123")
+	readerToken := login(t, "notifyreader@email.com", "carreview123")
+
+	notifications := func(t *testing.T, token, query string) []any {
+		status, notifications := send(t, http.MethodGet, "/api/users/notifications"+query, token, nil)
+		assert.Equal(t, 200, status)
+		return notifications.([]any)
+	}
+
+	status, _ := send(t, http.MethodPost, fmt.Sprintf("/api/favourites/%v", carID), readerToken, nil)
+	assert.Equal(t, 200, status)
+
+	status, review := send(t, http.MethodPost, "/api/reviews/", authorToken, request.ReviewCreateRequest{
+		CarID: carID, Title: "Hot hatch king", Content: "Sharp, loud and practical.", ImageUrl: "https://example.com/civic.jpg",
+	})
+	assert.Equal(t, 201, status)
+
+	reviewID := review.(map[string]any)["id"]
+
+	var commentID any
+
+	t.Run("should notify users who favourited the car", func(t *testing.T) {
+		latest := notifications(t, readerToken, "")
+		assert.Len(t, latest, 1)
+		assert.Equal(t, entity.NotificationFavouriteReview, latest[0].(map[string]any)["type"])
+		assert.Equal(t, reviewID, latest[0].(map[string]any)["target_id"])
+	})
+
+	t.Run("should notify the review author of a comment", func(t *testing.T) {
+		status, comment := send(t, http.MethodPost, "/api/comments/", readerToken, request.CommentCreateRequest{ReviewID: uint(reviewID.(float64)), Content: "How is it on track?"})
+		assert.Equal(t, 201, status)
+
+		commentID = comment.(map[string]any)["id"]
+
+		latest := notifications(t, authorToken, "")
+		assert.Len(t, latest, 1)
+		assert.Equal(t, entity.NotificationReviewComment, latest[0].(map[string]any)["type"])
+		assert.Equal(t, commentID, latest[0].(map[string]any)["target_id"])
+		assert.Equal(t, "notifyreader", latest[0].(map[string]any)["actor_username"])
+	})
+
+	t.Run("should notify the parent author of a reply", func(t *testing.T) {
+		parentID := uint(commentID.(float64))
+
+		status, reply := send(t, http.MethodPost, "/api/comments/", authorToken, request.CommentCreateRequest{ReviewID: uint(reviewID.(float64)), ParentID: &parentID, Content: "Great, it loves corners."})
+		assert.Equal(t, 201, status)
+		assert.Equal(t, commentID, reply.(map[string]any)["parent_id"])
+
+		latest := notifications(t, readerToken, "")
+		assert.Len(t, latest, 2)
+		assert.Equal(t, entity.NotificationCommentReply, latest[0].(map[string]any)["type"])
+
+		// the review author replying is not notified of their own comment
+		assert.Len(t, notifications(t, authorToken, ""), 1)
+
+		missing := uint(0)
+		status, _ = send(t, http.MethodPost, "/api/comments/", authorToken, request.CommentCreateRequest{ReviewID: uint(reviewID.(float64)), ParentID: &missing, Content: "Lost reply"})
+		assert.Equal(t, 404, status)
+	})
+
+	t.Run("should mark notifications as read", func(t *testing.T) {
+		unread := notifications(t, readerToken, "?unread=true")
+		assert.Len(t, unread, 2)
+
+		status, read := send(t, http.MethodPatch, fmt.Sprintf("/api/users/notifications/%v/read", unread[0].(map[string]any)["id"]), readerToken, nil)
+		assert.Equal(t, 200, status)
+		assert.NotNil(t, read.(map[string]any)["read_at"])
+
+		status, _ = send(t, http.MethodPatch, fmt.Sprintf("/api/users/notifications/%v/read", unread[0].(map[string]any)["id"]), authorToken, nil)
+		assert.Equal(t, 404, status)
+
+		assert.Len(t, notifications(t, readerToken, "?unread=true"), 1)
+		assert.Len(t, notifications(t, readerToken, "?unread=false"), 1)
+
+		status, readAll := send(t, http.MethodPost, "/api/users/notifications/read-all", readerToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, float64(1), readAll.(map[string]any)["read"])
+
+		assert.Len(t, notifications(t, readerToken, "?unread=true"), 0)
+	})
+
+	t.Run("should respect the preferences", func(t *testing.T) {
+		status, _ := send(t, http.MethodPut, "/api/users/notifications/preferences", authorToken, request.NotificationPreferenceRequest{Preferences: map[string]bool{"digest": false}})
+		assert.Equal(t, 400, status)
+
+		status, preferences := send(t, http.MethodPut, "/api/users/notifications/preferences", authorToken, request.NotificationPreferenceRequest{Preferences: map[string]bool{entity.NotificationReviewComment: false}})
+		assert.Equal(t, 200, status)
+		assert.Contains(t, preferences, map[string]any{"type": entity.NotificationReviewComment, "enabled": false})
+		assert.Contains(t, preferences, map[string]any{"type": entity.NotificationModeration, "enabled": true})
+
+		status, _ = send(t, http.MethodPost, "/api/comments/", readerToken, request.CommentCreateRequest{ReviewID: uint(reviewID.(float64)), Content: "One more question"})
+		assert.Equal(t, 201, status)
+
+		assert.Len(t, notifications(t, authorToken, ""), 1)
+	})
+
+	t.Run("should notify the outcome of moderation", func(t *testing.T) {
+		status, comment := send(t, http.MethodPost, "/api/comments/", readerToken, request.CommentCreateRequest{
+			ReviewID: uint(reviewID.(float64)), Content: "Compare https://a.example.com https://b.example.com https://c.example.com",
+		})
+		assert.Equal(t, 201, status)
+		assert.Equal(t, entity.StatusPending, comment.(map[string]any)["status"])
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/moderation/comments/%v", comment.(map[string]any)["id"]), adminToken, request.ModerationDecisionRequest{Action: "reject", Note: "Too many links"})
+		assert.Equal(t, 200, status)
+
+		latest := notifications(t, readerToken, "?unread=true")
+		assert.Len(t, latest, 1)
+		assert.Equal(t, entity.NotificationModeration, latest[0].(map[string]any)["type"])
+		assert.Equal(t, "Your comment is now rejected: Too many links", latest[0].(map[string]any)["message"])
+	})
+}
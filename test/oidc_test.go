@@ -0,0 +1,182 @@
+package test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockOIDCIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	nonces map[string]string
+	email  string
+	sub    string
+}
+
+func newMockOIDCIssuer(email string) *mockOIDCIssuer {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	issuer := &mockOIDCIssuer{key: key, nonces: map[string]string{}, email: email, sub: "mock-subject"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer.server.URL,
+			"authorization_endpoint": issuer.server.URL + "/authorize",
+			"token_endpoint":         issuer.server.URL + "/token",
+			"jwks_uri":               issuer.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		nonce, ok := issuer.nonces[r.Form.Get("code")]
+		if !ok || r.Form.Get("code_verifier") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":            issuer.server.URL,
+			"sub":            issuer.sub,
+			"aud":            "test-client",
+			"exp":            time.Now().Add(time.Minute).Unix(),
+			"iat":            time.Now().Unix(),
+			"nonce":          nonce,
+			"email":          issuer.email,
+			"email_verified": true,
+		})
+		token.Header["kid"] = "test"
+		idToken, _ := token.SignedString(key)
+
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idToken, "token_type": "Bearer"})
+	})
+
+	issuer.server = httptest.NewServer(mux)
+
+	return issuer
+}
+
+// login goes through the authorization code flow of the API with the issuer
+// and returns the status and payload of the callback.
+func (issuer *mockOIDCIssuer) login(t *testing.T) (int, map[string]any) {
+	recorder := httptest.NewRecorder()
+	Router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/auth/oidc/mock/start", nil))
+
+	response := recorder.Result()
+	assert.Equal(t, 302, response.StatusCode)
+
+	location, err := url.Parse(response.Header.Get("Location"))
+	assert.Nil(t, err)
+	assert.Equal(t, "S256", location.Query().Get("code_challenge_method"))
+
+	code := "mock-code-" + issuer.sub
+	issuer.nonces[code] = location.Query().Get("nonce")
+
+	callback := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/mock/callback?code="+code+"&state="+url.QueryEscape(location.Query().Get("state")), nil)
+	for _, cookie := range response.Cookies() {
+		callback.AddCookie(cookie)
+	}
+
+	recorder = httptest.NewRecorder()
+	Router.ServeHTTP(recorder, callback)
+
+	var jsonResult map[string]any
+	json.NewDecoder(recorder.Result().Body).Decode(&jsonResult)
+
+	payload, _ := jsonResult["payload"].(map[string]any)
+	return recorder.Result().StatusCode, payload
+}
+
+func TestOIDCLogin(t *testing.T) {
+	issuer := newMockOIDCIssuer("oidc@email.com")
+	defer issuer.server.Close()
+
+	t.Setenv("OIDC_PROVIDERS", "mock")
+	t.Setenv("OIDC_MOCK_ISSUER", issuer.server.URL)
+	t.Setenv("OIDC_MOCK_CLIENT_ID", "test-client")
+	t.Setenv("OIDC_MOCK_REDIRECT_URL", "http://localhost/api/auth/oidc/mock/callback")
+
+	t.Run("should redirect to provider and login on callback", func(t *testing.T) {
+		status, payload := issuer.login(t)
+
+		assert.Equal(t, 200, status)
+		assert.Equal(t, "oidc@email.com", payload["email"])
+		assert.NotNil(t, payload["token"])
+	})
+
+	t.Run("should take over an unverified local account", func(t *testing.T) {
+		userID := register(t, "squatter", "victim@email.com", "carreview123")
+		squatterToken := login(t, "victim@email.com", "carreview123")
+
+		issuer.email, issuer.sub = "Victim@email.com", "victim-subject"
+		defer func() { issuer.email, issuer.sub = "oidc@email.com", "mock-subject" }()
+
+		status, payload := issuer.login(t)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, "victim@email.com", payload["email"])
+
+		var identity entity.UserIdentity
+		assert.NoError(t, DB.Take(&identity, "provider = ? AND subject = ?", "mock", "victim-subject").Error)
+		assert.Equal(t, userID, identity.UserID)
+
+		// the password and the sessions of whoever registered the email are gone
+		status, _ = send(t, http.MethodGet, "/api/users/sessions", squatterToken, nil)
+		assert.Equal(t, 401, status)
+
+		status, _ = send(t, http.MethodPost, "/api/auth/login", "", request.LoginRequest{Email: "victim@email.com", Password: "carreview123"})
+		assert.Equal(t, 401, status)
+
+		status, _ = send(t, http.MethodGet, "/api/users/sessions", payload["token"].(string), nil)
+		assert.Equal(t, 200, status)
+	})
+
+	t.Run("should error if state does not match", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/mock/start", nil)
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		callback := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/mock/callback?code=mock-code&state=wrong", nil)
+		for _, cookie := range recorder.Result().Cookies() {
+			callback.AddCookie(cookie)
+		}
+
+		recorder = httptest.NewRecorder()
+		Router.ServeHTTP(recorder, callback)
+
+		assert.Equal(t, 400, recorder.Result().StatusCode)
+	})
+
+	t.Run("should error if provider is not configured", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/unknown/start", nil)
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, 404, recorder.Result().StatusCode)
+	})
+}
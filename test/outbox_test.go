@@ -0,0 +1,160 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestOutbox(t *testing.T) {
+	t.Setenv("OUTBOX_MAX_ATTEMPTS", "2")
+
+	type brandPayload struct {
+		Name string `json:"name"`
+	}
+
+	calls := 0
+
+	// creates the brand but fails the first time
+	outbox := services.NewOutbox()
+	outbox.Register("test.create_brand", func(tx *gorm.DB, payload json.RawMessage) error {
+		var brand brandPayload
+		json.Unmarshal(payload, &brand)
+
+		if err := tx.Create(&entity.Brand{Name: brand.Name}).Error; err != nil {
+			return err
+		}
+
+		calls++
+		if calls == 1 {
+			return errors.New("first attempt fails")
+		}
+		return nil
+	})
+	outbox.Register("test.broken", func(tx *gorm.DB, payload json.RawMessage) error {
+		panic("broken")
+	})
+
+	findJob := func(kind string) entity.OutboxJob {
+		var job entity.OutboxJob
+		DB.Where("kind = ?", kind).Last(&job)
+		return job
+	}
+
+	brands := func(name string) int64 {
+		var count int64
+		DB.Model(&entity.Brand{}).Where("name = ?", name).Count(&count)
+		return count
+	}
+
+	t.Run("should only enqueue with the commit", func(t *testing.T) {
+		DB.Transaction(func(tx *gorm.DB) error {
+			assert.NoError(t, outbox.Enqueue(tx, "test.create_brand", brandPayload{Name: "Rolledback"}))
+			return errors.New("rollback")
+		})
+
+		err := DB.Transaction(func(tx *gorm.DB) error {
+			return outbox.Enqueue(tx, "test.create_brand", brandPayload{Name: "Outboxbrand"})
+		})
+		assert.NoError(t, err)
+
+		var count int64
+		DB.Model(&entity.OutboxJob{}).Where("kind = ?", "test.create_brand").Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("should retry and roll back a failed attempt", func(t *testing.T) {
+		now := time.Now()
+
+		dispatched, err := outbox.Dispatch(DB, zap.NewNop(), now)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, dispatched)
+
+		job := findJob("test.create_brand")
+		assert.Equal(t, entity.OutboxJobPending, job.Status)
+		assert.Equal(t, 1, job.Attempts)
+		assert.Equal(t, "first attempt fails", job.LastError)
+		assert.Equal(t, int64(0), brands("Outboxbrand"))
+
+		// backing off
+		dispatched, _ = outbox.Dispatch(DB, zap.NewNop(), now)
+		assert.Equal(t, 0, dispatched)
+
+		dispatched, err = outbox.Dispatch(DB, zap.NewNop(), now.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, dispatched)
+
+		job = findJob("test.create_brand")
+		assert.Equal(t, entity.OutboxJobDone, job.Status)
+		assert.Equal(t, 2, job.Attempts)
+		assert.Equal(t, int64(1), brands("Outboxbrand"))
+	})
+
+	t.Run("should dead-letter and replay", func(t *testing.T) {
+		assert.NoError(t, outbox.Enqueue(DB, "test.broken", nil))
+
+		for i := 1; i <= 2; i++ {
+			_, err := outbox.Dispatch(DB, zap.NewNop(), time.Now().Add(time.Duration(i)*time.Hour))
+			assert.NoError(t, err)
+		}
+
+		job := findJob("test.broken")
+		assert.Equal(t, entity.OutboxJobDead, job.Status)
+		assert.Equal(t, "panic: broken", job.LastError)
+
+		replayed, err := services.ReplayOutboxJobs(DB, &services.OutboxFilter{Status: entity.OutboxJobDead, Kind: "test.broken"}, time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), replayed)
+
+		job = findJob("test.broken")
+		assert.Equal(t, entity.OutboxJobPending, job.Status)
+		assert.Equal(t, 0, job.Attempts)
+	})
+
+	t.Run("should run calls outside the job transaction", func(t *testing.T) {
+		attempts := 0
+		outbox.RegisterCall("test.call", func(db *gorm.DB, payload json.RawMessage) error {
+			attempts++
+
+			// the job is leased, not locked
+			var leased []entity.OutboxJob
+			db.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).Where("kind = ? AND run_at > ?", "test.call", time.Now().Add(time.Minute)).Find(&leased)
+			assert.Len(t, leased, 1)
+
+			if attempts == 1 {
+				return errors.New("first call fails")
+			}
+			return nil
+		})
+
+		assert.NoError(t, outbox.Enqueue(DB, "test.call", nil))
+
+		// calls are left to DispatchCalls
+		_, err := outbox.Dispatch(DB, zap.NewNop(), time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, findJob("test.call").Attempts)
+
+		dispatched, err := outbox.DispatchCalls(DB, zap.NewNop(), time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, dispatched)
+
+		job := findJob("test.call")
+		assert.Equal(t, entity.OutboxJobPending, job.Status)
+		assert.Equal(t, "first call fails", job.LastError)
+
+		_, err = outbox.DispatchCalls(DB, zap.NewNop(), time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+
+		job = findJob("test.call")
+		assert.Equal(t, entity.OutboxJobDone, job.Status)
+		assert.Equal(t, 2, attempts)
+	})
+}
@@ -0,0 +1,63 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordHashing(t *testing.T) {
+	t.Run("should hash and verify with bcrypt", func(t *testing.T) {
+		t.Setenv("PASSWORD_HASH_ALGORITHM", "bcrypt")
+
+		hashedPassword, err := helper.HashPassword("carreview123")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hashedPassword, "$2a$"))
+
+		assert.NoError(t, helper.VerifyPassword("carreview123", hashedPassword))
+		assert.ErrorIs(t, helper.VerifyPassword("carreview124", hashedPassword), helper.ErrPasswordMismatch)
+		assert.False(t, helper.PasswordNeedsRehash(hashedPassword))
+	})
+
+	t.Run("should hash and verify with argon2id", func(t *testing.T) {
+		t.Setenv("PASSWORD_HASH_ALGORITHM", "argon2id")
+
+		hashedPassword, err := helper.HashPassword("carreview123")
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hashedPassword, "$argon2id$v=19$m=65536,t=3,p=2$"))
+
+		assert.NoError(t, helper.VerifyPassword("carreview123", hashedPassword))
+		assert.ErrorIs(t, helper.VerifyPassword("carreview124", hashedPassword), helper.ErrPasswordMismatch)
+		assert.False(t, helper.PasswordNeedsRehash(hashedPassword))
+
+		// stronger parameters ask for a rehash
+		t.Setenv("PASSWORD_ARGON2_TIME", "4")
+		assert.True(t, helper.PasswordNeedsRehash(hashedPassword))
+	})
+
+	t.Run("should reject unknown hashes", func(t *testing.T) {
+		assert.ErrorIs(t, helper.VerifyPassword("carreview123", "carreview123"), helper.ErrPasswordMismatch)
+		assert.Error(t, helper.VerifyPassword("carreview123", "$argon2id$v=19$m=65536$broken"))
+	})
+
+	t.Run("should rehash a bcrypt password on login", func(t *testing.T) {
+		t.Setenv("PASSWORD_HASH_ALGORITHM", "bcrypt")
+		userID := register(t, "rehashed", "rehashed@email.com", "carreview123")
+
+		var user entity.User
+		DB.Take(&user, userID)
+		assert.True(t, strings.HasPrefix(user.Password, "$2a$"))
+
+		t.Setenv("PASSWORD_HASH_ALGORITHM", "argon2id")
+		login(t, "rehashed@email.com", "carreview123")
+
+		DB.Take(&user, userID)
+		assert.True(t, strings.HasPrefix(user.Password, "$argon2id$"))
+
+		// the new hash keeps working
+		login(t, "rehashed@email.com", "carreview123")
+	})
+}
@@ -0,0 +1,94 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewVote(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	brandID := createBrand(t, adminToken, "Votebrand")
+	carID := createCar(t, adminToken, brandID, "Supra")
+
+	var reviewIDs []any
+	var authorTokens []string
+	for i, title := range []string{"Lucky first vote", "Well liked"} {
+		register(t, fmt.Sprintf("voteauthor%d", i), fmt.Sprintf("voteauthor%d@email.com", i), "carreview123")
+		token := login(t, fmt.Sprintf("voteauthor%d@email.com", i), "carreview123")
+
+		status, review := send(t, http.MethodPost, "/api/reviews/", token, request.ReviewCreateRequest{
+			CarID: carID, Title: title, Content: fmt.Sprintf("Review number %d of the Supra", i), ImageUrl: "https://example.com/supra.jpg",
+		})
+		assert.Equal(t, 201, status)
+
+		reviewIDs = append(reviewIDs, review.(map[string]any)["id"])
+		authorTokens = append(authorTokens, token)
+	}
+
+	var voterTokens []string
+	for i := 1; i <= 4; i++ {
+		register(t, fmt.Sprintf("voter%d", i), fmt.Sprintf("voter%d@email.com", i), "carreview123")
+		voterTokens = append(voterTokens, login(t, fmt.Sprintf("voter%d@email.com", i), "carreview123"))
+	}
+
+	helpful, unhelpful := true, false
+
+	t.Run("should not vote on your own review", func(t *testing.T) {
+		status, _ := send(t, http.MethodPut, fmt.Sprintf("/api/reviews/%v/vote", reviewIDs[0]), authorTokens[0], request.ReviewVoteRequest{Helpful: &helpful})
+		assert.Equal(t, 400, status)
+	})
+
+	t.Run("should change a vote", func(t *testing.T) {
+		path := fmt.Sprintf("/api/reviews/%v/vote", reviewIDs[1])
+
+		status, tally := send(t, http.MethodPut, path, voterTokens[0], request.ReviewVoteRequest{Helpful: &unhelpful})
+		assert.Equal(t, 200, status)
+		assert.Equal(t, float64(0), tally.(map[string]any)["helpful_count"])
+		assert.Equal(t, float64(1), tally.(map[string]any)["unhelpful_count"])
+
+		status, tally = send(t, http.MethodPut, path, voterTokens[0], request.ReviewVoteRequest{Helpful: &helpful})
+		assert.Equal(t, 200, status)
+		assert.Equal(t, float64(1), tally.(map[string]any)["helpful_count"])
+		assert.Equal(t, float64(0), tally.(map[string]any)["unhelpful_count"])
+
+		status, tally = send(t, http.MethodDelete, path, voterTokens[0], nil)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, float64(0), tally.(map[string]any)["helpful_count"])
+
+		status, _ = send(t, http.MethodDelete, path, voterTokens[0], nil)
+		assert.Equal(t, 404, status)
+	})
+
+	t.Run("should rank by the Wilson score", func(t *testing.T) {
+		status, _ := send(t, http.MethodPut, fmt.Sprintf("/api/reviews/%v/vote", reviewIDs[0]), voterTokens[0], request.ReviewVoteRequest{Helpful: &helpful})
+		assert.Equal(t, 200, status)
+
+		for i, token := range voterTokens {
+			vote := request.ReviewVoteRequest{Helpful: &helpful}
+			if i == 3 {
+				vote.Helpful = &unhelpful
+			}
+
+			status, _ := send(t, http.MethodPut, fmt.Sprintf("/api/reviews/%v/vote", reviewIDs[1]), token, vote)
+			assert.Equal(t, 200, status)
+		}
+
+		status, reviews := send(t, http.MethodGet, fmt.Sprintf("/api/reviews?car_id=%d&sort=helpful", carID), "", nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, reviews, 2)
+		assert.Equal(t, reviewIDs[1], reviews.([]any)[0].(map[string]any)["id"])
+		assert.Equal(t, float64(3), reviews.([]any)[0].(map[string]any)["helpful_count"])
+
+		status, reviews = send(t, http.MethodGet, fmt.Sprintf("/api/reviews?car_id=%d&sort=oldest", carID), "", nil)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, reviewIDs[0], reviews.([]any)[0].(map[string]any)["id"])
+
+		status, _ = send(t, http.MethodGet, "/api/reviews?sort=random", "", nil)
+		assert.Equal(t, 400, status)
+	})
+}
@@ -0,0 +1,86 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevision(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	brandID := createBrand(t, adminToken, "Revisionbrand")
+	carID := createCar(t, adminToken, brandID, "Model 3")
+
+	carPath := fmt.Sprintf("/api/cars/%v", carID)
+
+	t.Run("should record a car edit", func(t *testing.T) {
+		status, _ := send(t, http.MethodPatch, carPath, adminToken, request.CarUpdateRequest{HorsePower: 510})
+		assert.Equal(t, 200, status)
+
+		status, revisions := send(t, http.MethodGet, carPath+"/revisions", "", nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, revisions, 1)
+
+		revision := revisions.([]any)[0].(map[string]any)
+		assert.Equal(t, float64(1), revision["number"])
+		assert.Equal(t, "root", revision["editor_username"])
+		assert.Equal(t, map[string]any{"horse_power": map[string]any{"from": float64(300), "to": float64(510)}}, revision["diff"])
+	})
+
+	t.Run("should not record an edit that changes nothing", func(t *testing.T) {
+		status, _ := send(t, http.MethodPatch, carPath, adminToken, request.CarUpdateRequest{HorsePower: 510})
+		assert.Equal(t, 200, status)
+
+		_, revisions := send(t, http.MethodGet, carPath+"/revisions", "", nil)
+		assert.Len(t, revisions, 1)
+	})
+
+	t.Run("should diff any two revisions", func(t *testing.T) {
+		status, _ := send(t, http.MethodPatch, carPath, adminToken, request.CarUpdateRequest{HorsePower: 530, Torque: 700})
+		assert.Equal(t, 200, status)
+
+		status, diff := send(t, http.MethodGet, carPath+"/revisions/diff?from=0&to=2", "", nil)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, map[string]any{
+			"horse_power": map[string]any{"from": float64(460), "to": float64(530)},
+			"torque":      map[string]any{"from": float64(660), "to": float64(700)},
+		}, diff.(map[string]any)["diff"])
+
+		status, _ = send(t, http.MethodGet, carPath+"/revisions/diff?from=1&to=3", "", nil)
+		assert.Equal(t, 404, status)
+
+		status, revision := send(t, http.MethodGet, carPath+"/revisions/2", "", nil)
+		assert.Equal(t, 200, status)
+		assert.Equal(t, float64(530), revision.(map[string]any)["after"].(map[string]any)["horse_power"])
+	})
+
+	t.Run("should mark an edited review", func(t *testing.T) {
+		register(t, "reviser", "reviser@email.com", "carreview123")
+		userToken := login(t, "reviser@email.com", "carreview123")
+
+		status, review := send(t, http.MethodPost, "/api/reviews/", userToken, request.ReviewCreateRequest{
+			CarID: carID, Title: "Quick", Content: "Very quick", ImageUrl: "https://example.com/review.jpg",
+		})
+		assert.Equal(t, 201, status)
+
+		reviewPath := fmt.Sprintf("/api/reviews/%v", review.(map[string]any)["id"])
+
+		_, found := send(t, http.MethodGet, reviewPath, "", nil)
+		assert.Equal(t, false, found.(map[string]any)["edited"])
+		assert.Nil(t, found.(map[string]any)["edited_at"])
+
+		title := "Ludicrously quick"
+		status, updated := send(t, http.MethodPatch, reviewPath, userToken, request.ReviewUpdateRequest{Title: &title})
+		assert.Equal(t, 200, status)
+		assert.Equal(t, true, updated.(map[string]any)["edited"])
+		assert.NotNil(t, updated.(map[string]any)["edited_at"])
+
+		_, revisions := send(t, http.MethodGet, reviewPath+"/revisions", "", nil)
+		assert.Len(t, revisions, 1)
+		assert.Equal(t, map[string]any{"title": map[string]any{"from": "Quick", "to": title}}, revisions.([]any)[0].(map[string]any)["diff"])
+	})
+}
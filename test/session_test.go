@@ -0,0 +1,50 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessions(t *testing.T) {
+	firstToken := login(t, "test@email.com", "mynewpassword")
+	secondToken := login(t, "test@email.com", "mynewpassword")
+
+	t.Run("should list sessions of current user", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/api/users/sessions", nil)
+		request.Header.Add("Authorization", "Bearer "+firstToken)
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		var jsonResult map[string]any
+
+		json.NewDecoder(response.Body).Decode(&jsonResult)
+
+		assert.Equal(t, 200, response.StatusCode)
+		assert.GreaterOrEqual(t, len(jsonResult["payload"].([]any)), 2)
+	})
+
+	t.Run("should reject token after log out everywhere", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, "/api/users/sessions", nil)
+		request.Header.Add("Authorization", "Bearer "+firstToken)
+
+		recorder := httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, 200, recorder.Result().StatusCode)
+
+		request = httptest.NewRequest(http.MethodGet, "/api/users/sessions", nil)
+		request.Header.Add("Authorization", "Bearer "+secondToken)
+
+		recorder = httptest.NewRecorder()
+		Router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, 401, recorder.Result().StatusCode)
+	})
+}
@@ -0,0 +1,169 @@
+package test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+type streamEvent struct {
+	ID    string
+	Event string
+	Topic string
+	Data  map[string]any
+}
+
+type stream struct {
+	cancel  context.CancelFunc
+	scanner *bufio.Scanner
+}
+
+func openStream(t *testing.T, server *httptest.Server, topics, token, lastEventID string) *stream {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+
+	request, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/stream?topics="+topics, nil)
+	if token != "" {
+		request.Header.Add("Authorization", "Bearer "+token)
+	}
+	if lastEventID != "" {
+		request.Header.Add("Last-Event-ID", lastEventID)
+	}
+
+	response, err := server.Client().Do(request)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+	return &stream{cancel: cancel, scanner: bufio.NewScanner(response.Body)}
+}
+
+// next reads the following event, skipping the retry and heartbeat lines.
+func (s *stream) next(t *testing.T) streamEvent {
+	var event streamEvent
+
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			event.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			var data struct {
+				Topic string         `json:"topic"`
+				Data  map[string]any `json:"data"`
+			}
+			json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &data)
+			event.Topic, event.Data = data.Topic, data.Data
+		case line == "" && event.Event != "":
+			return event
+		}
+	}
+
+	t.Fatal("stream ended before the next event")
+	return event
+}
+
+func TestStream(t *testing.T) {
+	server := httptest.NewServer(Router)
+	defer server.Close()
+
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	brandID := createBrand(t, adminToken, "Streambrand")
+	carID := createCar(t, adminToken, brandID, "Supra")
+
+	register(t, "streamauthor", "streamauthor@email.com", "carreview123")
+	authorToken := login(t, "streamauthor@email.com", "carreview123")
+
+	readerID := register(t, "streamreader", "streamreader@email.com", "carreview123")
+	readerToken := login(t, "streamreader@email.com", "carreview123")
+
+	status, review := send(t, http.MethodPost, "/api/reviews/", authorToken, request.ReviewCreateRequest{
+		CarID: carID, Title: "Straight six", Content: "Smooth and brutal.", ImageUrl: "https://example.com/supra.jpg",
+	})
+	assert.Equal(t, 201, status)
+
+	reviewID := review.(map[string]any)["id"]
+	reviewTopic := fmt.Sprintf("review:%v", reviewID)
+
+	t.Run("should check the topics", func(t *testing.T) {
+		status, _ := send(t, http.MethodGet, "/api/stream?topics=user:me", "", nil)
+		assert.Equal(t, 401, status)
+
+		status, _ = send(t, http.MethodGet, fmt.Sprintf("/api/stream?topics=user:%d", readerID), authorToken, nil)
+		assert.Equal(t, 403, status)
+
+		status, _ = send(t, http.MethodGet, "/api/stream?topics=review:999999", "", nil)
+		assert.Equal(t, 404, status)
+
+		status, _ = send(t, http.MethodGet, "/api/stream?topics=car:1", "", nil)
+		assert.Equal(t, 400, status)
+	})
+
+	var commentID any
+	var lastEventID string
+	received := map[string]bool{}
+
+	t.Run("should push comments and notifications", func(t *testing.T) {
+		events := openStream(t, server, reviewTopic+",user:me", authorToken, "")
+		defer events.cancel()
+
+		status, comment := send(t, http.MethodPost, "/api/comments/", readerToken, request.CommentCreateRequest{ReviewID: uint(reviewID.(float64)), Content: "What about the manual?"})
+		assert.Equal(t, 201, status)
+
+		commentID = comment.(map[string]any)["id"]
+
+		pushed := map[string]streamEvent{}
+		for i := 0; i < 2; i++ {
+			event := events.next(t)
+			pushed[event.Event] = event
+			received[event.ID] = true
+		}
+
+		assert.Equal(t, reviewTopic, pushed["comment.created"].Topic)
+		assert.Equal(t, commentID, pushed["comment.created"].Data["id"])
+		assert.Equal(t, "review_comment", pushed["notification.created"].Data["type"])
+
+		status, _ = send(t, http.MethodPatch, fmt.Sprintf("/api/comments/%v", commentID), readerToken, request.CommentUpdateRequest{Content: "What about the manual gearbox?"})
+		assert.Equal(t, 200, status)
+
+		event := events.next(t)
+		assert.Equal(t, "comment.updated", event.Event)
+		assert.Equal(t, "What about the manual gearbox?", event.Data["content"])
+
+		lastEventID = event.ID
+		received[event.ID] = true
+	})
+
+	t.Run("should resume from the last event", func(t *testing.T) {
+		status, _ := send(t, http.MethodDelete, fmt.Sprintf("/api/comments/%v", commentID), readerToken, nil)
+		assert.Equal(t, 200, status)
+
+		events := openStream(t, server, reviewTopic, "", lastEventID)
+		defer events.cancel()
+
+		// the events just before the last one come again, like those that
+		// commit late, and are dropped
+		event := events.next(t)
+		for received[event.ID] {
+			assert.NotEqual(t, lastEventID, event.ID)
+			event = events.next(t)
+		}
+
+		assert.Equal(t, "comment.deleted", event.Event)
+		assert.Equal(t, commentID, event.Data["id"])
+	})
+}
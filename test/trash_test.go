@@ -0,0 +1,160 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrash(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	brandID := createBrand(t, adminToken, "Trashbrand")
+	carID := createCar(t, adminToken, brandID, "Supra")
+
+	carPath := fmt.Sprintf("/api/cars/%v", carID)
+
+	t.Run("should hide a deleted car", func(t *testing.T) {
+		status, _ := send(t, http.MethodDelete, carPath, adminToken, nil)
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodGet, carPath, "", nil)
+		assert.Equal(t, 404, status)
+
+		var count int64
+		DB.Unscoped().Model(&entity.Car{}).Where("id = ? AND deleted_at IS NOT NULL", carID).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("should list the deleted car in the trash", func(t *testing.T) {
+		status, items := send(t, http.MethodGet, "/api/admin/trash/cars", adminToken, nil)
+		assert.Equal(t, 200, status)
+
+		found := false
+		for _, item := range items.([]any) {
+			if item.(map[string]any)["id"] == float64(carID) {
+				found = true
+				assert.Equal(t, "Supra", item.(map[string]any)["label"])
+				assert.NotEmpty(t, item.(map[string]any)["purge_at"])
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("should forbid a user to list the trash", func(t *testing.T) {
+		register(t, "trashuser", "trashuser@email.com", "carreview123")
+		userToken := login(t, "trashuser@email.com", "carreview123")
+
+		status, _ := send(t, http.MethodGet, "/api/admin/trash/cars", userToken, nil)
+		assert.Equal(t, 403, status)
+	})
+
+	t.Run("should not restore a car of a deleted brand", func(t *testing.T) {
+		DB.Delete(&entity.Brand{}, brandID)
+
+		status, _ := send(t, http.MethodPost, carPath+"/restore", adminToken, nil)
+		assert.Equal(t, 409, status)
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/brands/%v/restore", brandID), adminToken, nil)
+		assert.Equal(t, 200, status)
+	})
+
+	t.Run("should restore the car", func(t *testing.T) {
+		status, _ := send(t, http.MethodPost, carPath+"/restore", adminToken, nil)
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodGet, carPath, "", nil)
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodPost, carPath+"/restore", adminToken, nil)
+		assert.Equal(t, 404, status)
+	})
+
+	t.Run("should free the name of a deleted brand", func(t *testing.T) {
+		deletedBrandID := createBrand(t, adminToken, "Reusedbrand")
+
+		status, _ := send(t, http.MethodDelete, fmt.Sprintf("/api/brands/%d", deletedBrandID), adminToken, nil)
+		assert.Equal(t, 200, status)
+
+		createBrand(t, adminToken, "Reusedbrand")
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/brands/%d/restore", deletedBrandID), adminToken, nil)
+		assert.Equal(t, 409, status)
+	})
+
+	t.Run("should trash and restore the reviews of a car", func(t *testing.T) {
+		reviewedCarID := createCar(t, adminToken, brandID, "Reviewed")
+
+		register(t, "trashreviewer", "trashreviewer@email.com", "carreview123")
+		reviewerToken := login(t, "trashreviewer@email.com", "carreview123")
+
+		status, review := send(t, http.MethodPost, "/api/reviews/", reviewerToken, request.ReviewCreateRequest{
+			CarID: reviewedCarID, Title: "Gone with the car", Content: "Back with it.", ImageUrl: "https://example.com/supra.jpg",
+		})
+		assert.Equal(t, 201, status)
+		reviewID := review.(map[string]any)["id"]
+
+		status, _ = send(t, http.MethodPost, "/api/comments/", reviewerToken, request.CommentCreateRequest{ReviewID: uint(reviewID.(float64)), Content: "Indeed."})
+		assert.Equal(t, 201, status)
+
+		reviewsPath := fmt.Sprintf("/api/reviews?car_id=%d", reviewedCarID)
+		commentsPath := fmt.Sprintf("/api/reviews/%v/comments", reviewID)
+
+		status, _ = send(t, http.MethodDelete, fmt.Sprintf("/api/cars/%d", reviewedCarID), adminToken, nil)
+		assert.Equal(t, 200, status)
+
+		_, reviews := send(t, http.MethodGet, reviewsPath, "", nil)
+		assert.Len(t, reviews, 0)
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/cars/%d/restore", reviewedCarID), adminToken, nil)
+		assert.Equal(t, 200, status)
+
+		_, reviews = send(t, http.MethodGet, reviewsPath, "", nil)
+		assert.Len(t, reviews, 1)
+
+		_, comments := send(t, http.MethodGet, commentsPath, "", nil)
+		assert.Len(t, comments, 1)
+	})
+
+	t.Run("should restore reviews and comments", func(t *testing.T) {
+		register(t, "trashauthor", "trashauthor@email.com", "carreview123")
+		authorToken := login(t, "trashauthor@email.com", "carreview123")
+
+		status, review := send(t, http.MethodPost, "/api/reviews/", authorToken, request.ReviewCreateRequest{
+			CarID: carID, Title: "Gone and back", Content: "Still smooth.", ImageUrl: "https://example.com/supra.jpg",
+		})
+		assert.Equal(t, 201, status)
+		reviewID := review.(map[string]any)["id"]
+
+		status, comment := send(t, http.MethodPost, "/api/comments/", authorToken, request.CommentCreateRequest{ReviewID: uint(reviewID.(float64)), Content: "Agreed."})
+		assert.Equal(t, 201, status)
+		commentID := comment.(map[string]any)["id"]
+
+		status, _ = send(t, http.MethodDelete, fmt.Sprintf("/api/comments/%v", commentID), authorToken, nil)
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodDelete, fmt.Sprintf("/api/reviews/%v", reviewID), authorToken, nil)
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/comments/%v/restore", commentID), adminToken, nil)
+		assert.Equal(t, 409, status)
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/reviews/%v/restore", reviewID), adminToken, nil)
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/comments/%v/restore", commentID), adminToken, nil)
+		assert.Equal(t, 200, status)
+
+		status, events := send(t, http.MethodGet, fmt.Sprintf("/api/admin/audit?action=review.restore&target_type=review&target_id=%v", reviewID), adminToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, events, 1)
+
+		status, events = send(t, http.MethodGet, fmt.Sprintf("/api/admin/audit?action=comment.restore&target_type=comment&target_id=%v", commentID), adminToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, events, 1)
+	})
+}
@@ -0,0 +1,166 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/entity"
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/raihanmd/fp-superbootcamp-go/utils"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type recordingMailer struct {
+	mails []utils.Mail
+}
+
+func (mailer *recordingMailer) Send(ctx context.Context, mail *utils.Mail) error {
+	mailer.mails = append(mailer.mails, *mail)
+	return nil
+}
+
+func TestWatch(t *testing.T) {
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	brandID := createBrand(t, adminToken, "Watchbrand")
+	carID := createCar(t, adminToken, brandID, "Watchcar")
+
+	watcherID := register(t, "watcher", "watcher@email.com", "carreview123")
+	watcherToken := login(t, "watcher@email.com", "carreview123")
+
+	register(t, "brandwatcher", "brandwatcher@email.com", "carreview123")
+	brandWatcherToken := login(t, "brandwatcher@email.com", "carreview123")
+
+	register(t, "watchauthor", "watchauthor@email.com", "carreview123")
+	authorToken := login(t, "watchauthor@email.com", "carreview123")
+
+	t.Run("should manage the watch list", func(t *testing.T) {
+		status, _ := send(t, http.MethodPost, fmt.Sprintf("/api/cars/%d/watch", carID), watcherToken, nil)
+		assert.Equal(t, 201, status)
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/cars/%d/watch", carID), watcherToken, nil)
+		assert.Equal(t, 409, status)
+
+		status, _ = send(t, http.MethodPost, "/api/brands/999999/watch", watcherToken, nil)
+		assert.Equal(t, 404, status)
+
+		for _, token := range []string{watcherToken, brandWatcherToken} {
+			status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/brands/%d/watch", brandID), token, nil)
+			assert.Equal(t, 201, status)
+		}
+
+		status, watches := send(t, http.MethodGet, "/api/users/watches", watcherToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, watches, 2)
+
+		status, watches = send(t, http.MethodGet, "/api/users/watches?type=brands", watcherToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, watches, 1)
+		assert.Equal(t, "Watchbrand", watches.([]any)[0].(map[string]any)["name"])
+
+		// watching follows
+		status, following := send(t, http.MethodGet, fmt.Sprintf("/api/users/%d/following?type=cars", watcherID), "", nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, following, 1)
+	})
+
+	t.Run("should notify the watchers of a new review once", func(t *testing.T) {
+		status, _ := send(t, http.MethodPost, fmt.Sprintf("/api/favourites/%d", carID), watcherToken, nil)
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodPost, "/api/reviews/", authorToken, request.ReviewCreateRequest{
+			CarID: carID, Title: "Watched review", Content: "Worth a look.", ImageUrl: "https://example.com/car.jpg",
+		})
+		assert.Equal(t, 201, status)
+
+		status, notifications := send(t, http.MethodGet, "/api/users/notifications", watcherToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, notifications, 1)
+		assert.Equal(t, entity.NotificationFavouriteReview, notifications.([]any)[0].(map[string]any)["type"])
+
+		status, notifications = send(t, http.MethodGet, "/api/users/notifications", brandWatcherToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, notifications, 1)
+		assert.Equal(t, entity.NotificationWatchReview, notifications.([]any)[0].(map[string]any)["type"])
+	})
+
+	t.Run("should notify the watchers of spec changes", func(t *testing.T) {
+		status, _ := send(t, http.MethodPatch, fmt.Sprintf("/api/cars/%d", carID), adminToken, request.CarUpdateRequest{Year: 2025})
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodPatch, fmt.Sprintf("/api/cars/%d", carID), adminToken, request.CarUpdateRequest{Engine: "V8", Torque: 650})
+		assert.Equal(t, 200, status)
+
+		status, notifications := send(t, http.MethodGet, "/api/users/notifications", brandWatcherToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, notifications, 2)
+
+		latest := notifications.([]any)[0].(map[string]any)
+		assert.Equal(t, entity.NotificationWatchSpecs, latest["type"])
+		assert.Contains(t, latest["message"], "changed: engine, torque")
+	})
+
+	t.Run("should mail the unread watch notifications in a digest", func(t *testing.T) {
+		status, _ := send(t, http.MethodPut, "/api/users/watches/digest", watcherToken, request.WatchDigestRequest{Frequency: "hourly"})
+		assert.Equal(t, 400, status)
+
+		status, digest := send(t, http.MethodPut, "/api/users/watches/digest", watcherToken, request.WatchDigestRequest{Frequency: entity.WatchDigestWeekly})
+		assert.Equal(t, 200, status)
+		assert.Equal(t, entity.WatchDigestWeekly, digest.(map[string]any)["frequency"])
+
+		// the digest period starts now, move the notifications inside it
+		DB.Model(&entity.Notification{}).Where("type IN ?", entity.NotificationWatchTypes).Update("created_at", gorm.Expr("created_at + interval '1 minute'"))
+
+		mailer := &recordingMailer{}
+		outbox := services.NewOutbox()
+		services.NewWatchService(outbox, mailer)
+
+		queued, err := services.SendWatchDigests(DB, outbox, zap.NewNop(), time.Now().Add(24*time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, queued)
+
+		later := time.Now().Add(8 * 24 * time.Hour)
+
+		queued, err = services.SendWatchDigests(DB, outbox, zap.NewNop(), later)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, queued)
+
+		_, err = outbox.Dispatch(DB, zap.NewNop(), later)
+		assert.NoError(t, err)
+
+		if assert.Len(t, mailer.mails, 1) {
+			assert.Equal(t, "watcher@email.com", mailer.mails[0].To)
+			assert.Equal(t, "Your weekly digest: 2 updates on what you watch", mailer.mails[0].Subject)
+			assert.Contains(t, mailer.mails[0].Body, "watchauthor reviewed the Watchcar")
+		}
+
+		// nothing new since
+		queued, _ = services.SendWatchDigests(DB, outbox, zap.NewNop(), later.Add(8*24*time.Hour))
+		assert.Equal(t, 0, queued)
+
+		status, _ = send(t, http.MethodPut, "/api/users/watches/digest", watcherToken, request.WatchDigestRequest{Frequency: "off"})
+		assert.Equal(t, 200, status)
+	})
+
+	t.Run("should unwatch", func(t *testing.T) {
+		status, _ := send(t, http.MethodDelete, fmt.Sprintf("/api/cars/%d/watch", carID), watcherToken, nil)
+		assert.Equal(t, 200, status)
+
+		status, _ = send(t, http.MethodDelete, fmt.Sprintf("/api/cars/%d/watch", carID), watcherToken, nil)
+		assert.Equal(t, 404, status)
+
+		// the car stays followed
+		status, following := send(t, http.MethodGet, fmt.Sprintf("/api/users/%d/following?type=cars", watcherID), "", nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, following, 1)
+
+		status, _ = send(t, http.MethodPost, fmt.Sprintf("/api/cars/%d/follow", carID), watcherToken, nil)
+		assert.Equal(t, 409, status)
+	})
+}
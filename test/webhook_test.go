@@ -0,0 +1,141 @@
+package test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/raihanmd/fp-superbootcamp-go/model/web/request"
+	"github.com/raihanmd/fp-superbootcamp-go/services"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestWebhook(t *testing.T) {
+	type received struct {
+		event     string
+		timestamp string
+		signature string
+		body      []byte
+	}
+
+	var mu sync.Mutex
+	var deliveries []received
+
+	// fails the first delivery, accepts the others
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		deliveries = append(deliveries, received{r.Header.Get("X-Webhook-Event"), r.Header.Get("X-Webhook-Timestamp"), r.Header.Get("X-Webhook-Signature"), body})
+		if len(deliveries) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	adminToken := login(t, "root@email.com", "rootpassword")
+
+	register(t, "webhookuser", "webhookuser@email.com", "carreview123")
+	userToken := login(t, "webhookuser@email.com", "carreview123")
+
+	t.Run("should only allow admins", func(t *testing.T) {
+		status, _ := send(t, http.MethodGet, "/api/admin/webhooks", userToken, nil)
+		assert.Equal(t, 403, status)
+	})
+
+	t.Run("should refuse private addresses", func(t *testing.T) {
+		for _, url := range []string{receiver.URL, "http://169.254.169.254/latest/meta-data", "http://10.0.0.1/hook", "http://[::1]/hook"} {
+			status, _ := send(t, http.MethodPost, "/api/admin/webhooks", adminToken, request.WebhookCreateRequest{URL: url, Events: []string{"car.created"}})
+			assert.Equal(t, 400, status, url)
+		}
+
+		// checked again when connecting
+		_, err := services.NewWebhookClient().Get(receiver.URL)
+		assert.ErrorContains(t, err, "webhook address is not public")
+	})
+
+	// the receiver listens on the loopback address
+	t.Setenv("WEBHOOK_ALLOW_PRIVATE_ADDRESSES", "true")
+
+	t.Run("should check the events", func(t *testing.T) {
+		status, _ := send(t, http.MethodPost, "/api/admin/webhooks", adminToken, request.WebhookCreateRequest{URL: receiver.URL, Events: []string{"car.crashed"}})
+		assert.Equal(t, 400, status)
+	})
+
+	status, webhook := send(t, http.MethodPost, "/api/admin/webhooks", adminToken, request.WebhookCreateRequest{URL: receiver.URL, Events: []string{"car.created"}})
+	assert.Equal(t, 201, status)
+
+	webhookID := webhook.(map[string]any)["id"]
+	secret, _ := webhook.(map[string]any)["secret"].(string)
+	assert.NotEmpty(t, secret)
+
+	createCar(t, adminToken, createBrand(t, adminToken, "Hookbrand"), "Hook")
+
+	deliveriesPath := fmt.Sprintf("/api/admin/webhooks/%v/deliveries", webhookID)
+
+	// sends the queued deliveries to the receiver
+	outbox := services.NewOutbox()
+	services.NewWebhookService(services.NewEventBus(), outbox, receiver.Client())
+
+	t.Run("should retry a failed delivery", func(t *testing.T) {
+		_, err := outbox.DispatchCalls(DB, zap.NewNop(), time.Now())
+		assert.NoError(t, err)
+
+		status, log := send(t, http.MethodGet, deliveriesPath, adminToken, nil)
+		assert.Equal(t, 200, status)
+
+		if assert.Len(t, log, 1) {
+			delivery := log.([]any)[0].(map[string]any)
+			assert.Equal(t, "pending", delivery["status"])
+			assert.Equal(t, float64(1), delivery["attempts"])
+			assert.Equal(t, float64(503), delivery["response_status"])
+		}
+
+		_, err = outbox.DispatchCalls(DB, zap.NewNop(), time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+
+		status, log = send(t, http.MethodGet, deliveriesPath+"?status=delivered", adminToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, log, 1)
+	})
+
+	t.Run("should sign the deliveries", func(t *testing.T) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if assert.Len(t, deliveries, 2) {
+			for _, delivery := range deliveries {
+				assert.Equal(t, "car.created", delivery.event)
+				assert.Equal(t, services.WebhookSignature(secret, delivery.timestamp, delivery.body), delivery.signature)
+			}
+		}
+	})
+
+	t.Run("should redeliver", func(t *testing.T) {
+		_, log := send(t, http.MethodGet, deliveriesPath, adminToken, nil)
+		deliveryID := log.([]any)[0].(map[string]any)["id"]
+
+		status, redelivery := send(t, http.MethodPost, fmt.Sprintf("%s/%v/redeliver", deliveriesPath, deliveryID), adminToken, nil)
+		assert.Equal(t, 202, status)
+		assert.Equal(t, deliveryID, redelivery.(map[string]any)["redelivery_of"])
+
+		_, err := outbox.DispatchCalls(DB, zap.NewNop(), time.Now())
+		assert.NoError(t, err)
+
+		status, log = send(t, http.MethodGet, deliveriesPath+"?status=delivered", adminToken, nil)
+		assert.Equal(t, 200, status)
+		assert.Len(t, log, 2)
+
+		status, _ = send(t, http.MethodPost, deliveriesPath+"/999999/redeliver", adminToken, nil)
+		assert.Equal(t, 404, status)
+	})
+}
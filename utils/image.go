@@ -0,0 +1,337 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strconv"
+)
+
+var ErrUnsupportedImage = errors.New("unsupported image")
+
+// ImageVariant is an encoded rendition of an uploaded image.
+type ImageVariant struct {
+	Name        string
+	ContentType string
+	Width       int
+	Height      int
+	Data        []byte
+}
+
+// ProcessImage decodes the upload and encodes it again, which drops EXIF
+// and any other metadata. The EXIF orientation is applied to the pixels
+// first so the image still displays the right way up. A thumbnail is made
+// for every size (longest edge) smaller than the image. maxPixels guards
+// against decompression bombs, it counts every frame of a GIF.
+func ProcessImage(data []byte, contentType string, sizes []int, maxPixels int) ([]ImageVariant, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	if config.Width*config.Height > maxPixels {
+		return nil, errors.New("image has too many pixels")
+	}
+
+	var original ImageVariant
+	var img image.Image
+
+	switch contentType {
+	case "image/jpeg":
+		if img, err = jpeg.Decode(bytes.NewReader(data)); err != nil {
+			return nil, ErrUnsupportedImage
+		}
+		img = applyOrientation(img, jpegOrientation(data))
+
+		if original, err = encodeVariant("original", contentType, img); err != nil {
+			return nil, err
+		}
+	case "image/png":
+		if img, err = png.Decode(bytes.NewReader(data)); err != nil {
+			return nil, ErrUnsupportedImage
+		}
+
+		if original, err = encodeVariant("original", contentType, img); err != nil {
+			return nil, err
+		}
+	case "image/gif":
+		// every frame is decoded, each can be as large as the screen
+		pixels, ok := gifPixels(data)
+		if !ok {
+			return nil, ErrUnsupportedImage
+		}
+		if pixels > maxPixels {
+			return nil, errors.New("image has too many pixels")
+		}
+
+		animation, err := gif.DecodeAll(bytes.NewReader(data))
+		if err != nil || len(animation.Image) == 0 {
+			return nil, ErrUnsupportedImage
+		}
+		// re-encoding keeps the frames and loop count but drops comment and
+		// application extensions
+		var buf bytes.Buffer
+		if err := gif.EncodeAll(&buf, animation); err != nil {
+			return nil, err
+		}
+
+		img = animation.Image[0]
+		original = ImageVariant{Name: "original", ContentType: contentType, Width: config.Width, Height: config.Height, Data: buf.Bytes()}
+	default:
+		return nil, ErrUnsupportedImage
+	}
+
+	variants := []ImageVariant{original}
+
+	// animated gifs get still png thumbnails
+	thumbnailType := contentType
+	if thumbnailType == "image/gif" {
+		thumbnailType = "image/png"
+	}
+
+	bounds := img.Bounds()
+	for _, size := range sizes {
+		if size <= 0 || (bounds.Dx() <= size && bounds.Dy() <= size) {
+			continue
+		}
+
+		width, height := size, bounds.Dy()*size/bounds.Dx()
+		if bounds.Dy() > bounds.Dx() {
+			width, height = bounds.Dx()*size/bounds.Dy(), size
+		}
+
+		thumbnail, err := encodeVariant("thumb_"+strconv.Itoa(size), thumbnailType, resizeImage(img, max(width, 1), max(height, 1)))
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, thumbnail)
+	}
+
+	return variants, nil
+}
+
+func encodeVariant(name, contentType string, img image.Image) (ImageVariant, error) {
+	var buf bytes.Buffer
+	var err error
+
+	switch contentType {
+	case "image/jpeg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	default:
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return ImageVariant{}, err
+	}
+
+	return ImageVariant{
+		Name:        name,
+		ContentType: contentType,
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// resizeImage scales the image down with a box filter, every destination
+// pixel is the average of the source pixels it covers.
+func resizeImage(src image.Image, width, height int) image.Image {
+	rgba := image.NewRGBA(image.Rect(0, 0, src.Bounds().Dx(), src.Bounds().Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, src.Bounds().Min, draw.Src)
+
+	srcWidth, srcHeight := rgba.Bounds().Dx(), rgba.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcHeight/height, max((y+1)*srcHeight/height, y*srcHeight/height+1)
+
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcWidth/width, max((x+1)*srcWidth/width, x*srcWidth/width+1)
+
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				offset := rgba.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += uint32(rgba.Pix[offset])
+					g += uint32(rgba.Pix[offset+1])
+					b += uint32(rgba.Pix[offset+2])
+					a += uint32(rgba.Pix[offset+3])
+					offset += 4
+					n++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{uint8(r / n), uint8(g / n), uint8(b / n), uint8(a / n)})
+		}
+	}
+
+	return dst
+}
+
+// applyOrientation turns the image according to the EXIF orientation tag
+// (1 to 8), see https://exiftool.org/TagNames/EXIF.html.
+func applyOrientation(src image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = width-1-x, y
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dx, dy = x, height-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = height-1-y, x
+			case 7:
+				dx, dy = height-1-y, width-1-x
+			case 8:
+				dx, dy = y, width-1-x
+			}
+			dst.Set(dx, dy, src.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return dst
+}
+
+// jpegOrientation reads the orientation tag from the EXIF APP1 segment, 1
+// (no transformation) when there is none.
+// gifPixels adds up the pixels of the frames of a GIF from their image
+// descriptors, without decoding them.
+func gifPixels(data []byte) (int, bool) {
+	if len(data) < 13 || string(data[:3]) != "GIF" {
+		return 0, false
+	}
+
+	i := 13
+	if data[10]&0x80 != 0 {
+		i += 3 << (data[10]&0x07 + 1)
+	}
+
+	// skipBlocks moves past data sub-blocks up to their terminator
+	skipBlocks := func() bool {
+		for i < len(data) {
+			size := int(data[i])
+			i += 1 + size
+			if size == 0 {
+				return i <= len(data)
+			}
+		}
+		return false
+	}
+
+	pixels := 0
+	for i < len(data) {
+		switch data[i] {
+		case 0x21: // extension
+			i += 2
+			if !skipBlocks() {
+				return 0, false
+			}
+		case 0x2C: // image descriptor
+			if i+10 > len(data) {
+				return 0, false
+			}
+			pixels += int(binary.LittleEndian.Uint16(data[i+5:])) * int(binary.LittleEndian.Uint16(data[i+7:]))
+
+			flags := data[i+9]
+			i += 10
+			if flags&0x80 != 0 {
+				i += 3 << (flags&0x07 + 1)
+			}
+
+			// LZW minimum code size, then the image data
+			i++
+			if !skipBlocks() {
+				return 0, false
+			}
+		case 0x3B: // trailer
+			return pixels, true
+		default:
+			return 0, false
+		}
+	}
+
+	return pixels, true
+}
+
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+
+		marker := data[i+1]
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if marker == 0xDA || length < 2 || i+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && len(segment) > 14 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+
+		i += 2 + length
+	}
+
+	return 1
+}
+
+func tiffOrientation(tiff []byte) int {
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+
+	return 1
+}
@@ -16,17 +16,22 @@ import (
 
 var API_SECRET = helper.GetEnv("API_SECRET", "W8j8sLNYNXyhVyjAcyiuaWMCHGFGfcwEG8WsxlOMsPgX0vF73LmSslCaofZls8oNMSmj8bNFnZpxqD3JUUmPhYtRI5gIsSi9riGHTXpgja6RETJiXFI4WTsIfszZcwoW")
 
-func GenerateToken(userId uint, userRole string) (string, error) {
+func TokenExpiresAt() (time.Time, error) {
 	tokenLifeSpan, err := strconv.Atoi(helper.GetEnv("TOKEN_HOUR_LIFESPAN", "1"))
 	if err != nil {
-		return "", err
+		return time.Time{}, err
 	}
 
+	return time.Now().Add(time.Hour * time.Duration(tokenLifeSpan)), nil
+}
+
+func GenerateToken(userId uint, userRole string, tokenID string, expiresAt time.Time) (string, error) {
 	claims := jwt.MapClaims{}
 	claims["authorized"] = true
 	claims["user_id"] = userId
 	claims["user_role"] = userRole
-	claims["exp"] = time.Now().Add(time.Hour * time.Duration(tokenLifeSpan)).Unix()
+	claims["jti"] = tokenID
+	claims["exp"] = expiresAt.Unix()
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	return token.SignedString([]byte(API_SECRET))
@@ -34,7 +39,7 @@ func GenerateToken(userId uint, userRole string) (string, error) {
 
 func TokenValid(c *gin.Context) error {
 	tokenString := ExtractToken(c)
-	_, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
@@ -43,7 +48,13 @@ func TokenValid(c *gin.Context) error {
 	if err != nil {
 		return err
 	}
-	return nil
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return fmt.Errorf("invalid token")
+	}
+
+	return sessionActive(c, claims)
 }
 
 func ExtractToken(c *gin.Context) string {
@@ -73,6 +84,9 @@ func ExtractTokenClaims(c *gin.Context) (id uint, role string, err error) {
 	if !ok || !token.Valid {
 		return 0, "", exceptions.NewCustomError(http.StatusBadRequest, "Invalid or expired token")
 	}
+	if err := sessionActive(c, claims); err != nil {
+		return 0, "", exceptions.NewCustomError(http.StatusUnauthorized, err.Error())
+	}
 	userId, err := strconv.ParseUint(fmt.Sprintf("%.0f", claims["user_id"]), 10, 32)
 	if err != nil {
 		return 0, "", err
@@ -80,6 +94,73 @@ func ExtractTokenClaims(c *gin.Context) (id uint, role string, err error) {
 	return uint(userId), claims["user_role"].(string), nil
 }
 
+// ExtractTokenID returns the session token id (jti) of the current request
+// token, or an empty string when there is no valid token.
+func ExtractTokenID(c *gin.Context) string {
+	token, err := jwt.Parse(ExtractToken(c), func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(API_SECRET), nil
+	})
+	if err != nil {
+		return ""
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return ""
+	}
+
+	tokenID, _ := claims["jti"].(string)
+	return tokenID
+}
+
+// sessionContextKey holds the outcome of the session check of the request,
+// an error or nil.
+const sessionContextKey = "session_check"
+
+// sessionActive rejects tokens whose session was revoked or never existed.
+// The session is looked up once per request, usually by JwtAuthMiddleware,
+// and the outcome is kept in the context for the later token lookups.
+func sessionActive(c *gin.Context, claims jwt.MapClaims) error {
+	if checked, ok := c.Get(sessionContextKey); ok {
+		err, _ := checked.(error)
+		return err
+	}
+
+	err := checkSession(c, claims)
+	c.Set(sessionContextKey, err)
+
+	return err
+}
+
+// checkSession loads the session of the token. last_seen_at is only
+// refreshed once a minute to avoid a write per request.
+func checkSession(c *gin.Context, claims jwt.MapClaims) error {
+	tokenID, _ := claims["jti"].(string)
+	if tokenID == "" {
+		return fmt.Errorf("session has been revoked")
+	}
+
+	db, _ := helper.GetDBAndLogger(c)
+
+	var session entity.Session
+	if err := db.Joins("User").Take(&session, "sessions.token_id = ? AND sessions.expires_at > ?", tokenID, time.Now()).Error; err != nil {
+		return fmt.Errorf("session has been revoked")
+	}
+
+	if session.User.IsBanned(time.Now()) {
+		return fmt.Errorf("account is suspended")
+	}
+
+	db.Model(&entity.Session{}).
+		Where("id = ? AND last_seen_at < ?", session.ID, time.Now().Add(-time.Minute)).
+		Update("last_seen_at", time.Now())
+
+	return nil
+}
+
 func UserRoleMustAdmin(c *gin.Context) {
 	_, role, err := ExtractTokenClaims(c)
 	if err != nil {
@@ -89,3 +170,18 @@ func UserRoleMustAdmin(c *gin.Context) {
 		helper.PanicIfError(exceptions.NewCustomError(http.StatusForbidden, "Only admin can manipulate data"))
 	}
 }
+
+// IsModerator reports whether the role may moderate reviews and comments.
+func IsModerator(role string) bool {
+	return role == entity.RoleAdmin || role == entity.RoleModerator
+}
+
+func UserRoleMustModerator(c *gin.Context) {
+	_, role, err := ExtractTokenClaims(c)
+	if err != nil {
+		helper.PanicIfError(err)
+	}
+	if !IsModerator(role) {
+		helper.PanicIfError(exceptions.NewCustomError(http.StatusForbidden, "Only moderators can moderate content"))
+	}
+}
@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+)
+
+// Mail is a plain text email.
+type Mail struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer sends emails.
+type Mailer interface {
+	Send(ctx context.Context, mail *Mail) error
+}
+
+// NewMailer returns the mailer selected by MAIL_TRANSPORT, either smtp (the
+// SMTP_* variables) or none, which drops the emails.
+func NewMailer() Mailer {
+	switch strings.ToLower(helper.GetEnv("MAIL_TRANSPORT", "none")) {
+	case "smtp":
+		return NewSMTPMailer(SMTPConfig{
+			Host:     helper.MustGetEnv("SMTP_HOST"),
+			Port:     helper.GetEnv("SMTP_PORT", "587"),
+			Username: helper.GetEnv("SMTP_USERNAME", ""),
+			Password: helper.GetEnv("SMTP_PASSWORD", ""),
+			From:     helper.MustGetEnv("MAIL_FROM"),
+		})
+	default:
+		return NewDiscardMailer()
+	}
+}
+
+type discardMailer struct{}
+
+func NewDiscardMailer() Mailer {
+	return discardMailer{}
+}
+
+func (discardMailer) Send(ctx context.Context, mail *Mail) error {
+	return nil
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+type smtpMailer struct {
+	config SMTPConfig
+}
+
+// NewSMTPMailer sends through an SMTP server, with STARTTLS when the server
+// offers it and PLAIN authentication when a username is set.
+func NewSMTPMailer(config SMTPConfig) Mailer {
+	return &smtpMailer{config: config}
+}
+
+func (mailer *smtpMailer) Send(ctx context.Context, mail *Mail) error {
+	message := strings.Join([]string{
+		"From: " + mailer.config.From,
+		"To: " + mail.To,
+		"Subject: " + mail.Subject,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		strings.ReplaceAll(mail.Body, "\n", "\r\n"),
+	}, "\r\n")
+
+	if err := mailer.send(ctx, mail.To, message); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("send mail to %s: %w", mail.To, err)
+	}
+	return nil
+}
+
+// send is smtp.SendMail on a connection bound to ctx, the deadline of ctx
+// applies to every read and write and cancelling ctx closes the connection.
+func (mailer *smtpMailer) send(ctx context.Context, to, message string) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(mailer.config.Host, mailer.config.Port))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, mailer.config.Host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: mailer.config.Host}); err != nil {
+			return err
+		}
+	}
+
+	if mailer.config.Username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(smtp.PlainAuth("", mailer.config.Username, mailer.config.Password, mailer.config.Host)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := client.Mail(mailer.config.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte(message)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
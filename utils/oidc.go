@@ -0,0 +1,393 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/raihanmd/fp-superbootcamp-go/exceptions"
+	"github.com/raihanmd/fp-superbootcamp-go/helper"
+)
+
+var oidcHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+type OIDCProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	DiscoveryURL string
+	JWKSURL      string
+	Scopes       []string
+}
+
+type OIDCDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type OIDCClaims struct {
+	Email         string `json:"email"`
+	EmailVerified any    `json:"email_verified"`
+	Nonce         string `json:"nonce"`
+	Name          string `json:"name"`
+	jwt.RegisteredClaims
+}
+
+// IsEmailVerified accepts both boolean and string values since some providers
+// serialize email_verified as "true".
+func (c *OIDCClaims) IsEmailVerified() bool {
+	switch v := c.EmailVerified.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
+
+type OIDCFlowClaims struct {
+	Provider     string `json:"provider"`
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+	jwt.RegisteredClaims
+}
+
+// GetOIDCProvider reads the provider configuration from the environment. A
+// provider named "google" is configured with OIDC_GOOGLE_ISSUER,
+// OIDC_GOOGLE_CLIENT_ID, OIDC_GOOGLE_CLIENT_SECRET, OIDC_GOOGLE_REDIRECT_URL and
+// optionally OIDC_GOOGLE_DISCOVERY_URL, OIDC_GOOGLE_JWKS_URL and OIDC_GOOGLE_SCOPES.
+// Only providers listed in OIDC_PROVIDERS are enabled.
+func GetOIDCProvider(name string) (*OIDCProvider, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	enabled := false
+	for _, p := range strings.Split(helper.GetEnv("OIDC_PROVIDERS", ""), ",") {
+		if strings.ToLower(strings.TrimSpace(p)) == name && name != "" {
+			enabled = true
+			break
+		}
+	}
+	if !enabled {
+		return nil, exceptions.NewCustomError(http.StatusNotFound, "OIDC provider not found")
+	}
+
+	prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+	provider := &OIDCProvider{
+		Name:         name,
+		Issuer:       strings.TrimSuffix(helper.GetEnv(prefix+"ISSUER", ""), "/"),
+		ClientID:     helper.GetEnv(prefix+"CLIENT_ID", ""),
+		ClientSecret: helper.GetEnv(prefix+"CLIENT_SECRET", ""),
+		RedirectURL:  helper.GetEnv(prefix+"REDIRECT_URL", ""),
+		DiscoveryURL: helper.GetEnv(prefix+"DISCOVERY_URL", ""),
+		JWKSURL:      helper.GetEnv(prefix+"JWKS_URL", ""),
+		Scopes:       strings.Fields(helper.GetEnv(prefix+"SCOPES", "openid email profile")),
+	}
+
+	if provider.Issuer == "" || provider.ClientID == "" || provider.RedirectURL == "" {
+		return nil, fmt.Errorf("OIDC provider %s is not fully configured", name)
+	}
+
+	if provider.DiscoveryURL == "" {
+		provider.DiscoveryURL = provider.Issuer + "/.well-known/openid-configuration"
+	}
+
+	return provider, nil
+}
+
+type oidcCacheEntry struct {
+	value     any
+	expiresAt time.Time
+}
+
+var (
+	oidcCacheMu  sync.Mutex
+	oidcCache    = map[string]oidcCacheEntry{}
+	oidcCacheTTL = time.Hour
+)
+
+func oidcCacheGet(key string) (any, bool) {
+	oidcCacheMu.Lock()
+	defer oidcCacheMu.Unlock()
+
+	entry, ok := oidcCache[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func oidcCacheSet(key string, value any) {
+	oidcCacheMu.Lock()
+	defer oidcCacheMu.Unlock()
+
+	oidcCache[key] = oidcCacheEntry{value: value, expiresAt: time.Now().Add(oidcCacheTTL)}
+}
+
+func oidcGetJSON(endpoint string, dst any) error {
+	res, err := oidcHTTPClient.Get(endpoint)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", res.StatusCode, endpoint)
+	}
+
+	return json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(dst)
+}
+
+func (p *OIDCProvider) Discover() (*OIDCDiscovery, error) {
+	if cached, ok := oidcCacheGet("discovery:" + p.DiscoveryURL); ok {
+		return cached.(*OIDCDiscovery), nil
+	}
+
+	var discovery OIDCDiscovery
+	if err := oidcGetJSON(p.DiscoveryURL, &discovery); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSuffix(discovery.Issuer, "/") != p.Issuer {
+		return nil, fmt.Errorf("OIDC discovery issuer %q does not match configured issuer %q", discovery.Issuer, p.Issuer)
+	}
+
+	if p.JWKSURL != "" {
+		discovery.JWKSURI = p.JWKSURL
+	}
+
+	oidcCacheSet("discovery:"+p.DiscoveryURL, &discovery)
+
+	return &discovery, nil
+}
+
+func (p *OIDCProvider) AuthCodeURL(discovery *OIDCDiscovery, state, nonce, codeChallenge string) (string, error) {
+	authURL, err := url.Parse(discovery.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.ClientID)
+	query.Set("redirect_uri", p.RedirectURL)
+	query.Set("scope", strings.Join(p.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+// ExchangeCode redeems the authorization code at the token endpoint and
+// returns the raw ID token.
+func (p *OIDCProvider) ExchangeCode(discovery *OIDCDiscovery, code, codeVerifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.RedirectURL)
+	form.Set("client_id", p.ClientID)
+	form.Set("code_verifier", codeVerifier)
+	if p.ClientSecret != "" {
+		form.Set("client_secret", p.ClientSecret)
+	}
+
+	res, err := oidcHTTPClient.PostForm(discovery.TokenEndpoint, form)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	var tokenRes struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(&tokenRes); err != nil {
+		return "", err
+	}
+
+	if res.StatusCode != http.StatusOK || tokenRes.Error != "" {
+		return "", exceptions.NewCustomError(http.StatusUnauthorized, fmt.Sprintf("OIDC token exchange failed: %s %s", tokenRes.Error, tokenRes.ErrorDescription))
+	}
+
+	if tokenRes.IDToken == "" {
+		return "", exceptions.NewCustomError(http.StatusUnauthorized, "OIDC provider did not return an id_token")
+	}
+
+	return tokenRes.IDToken, nil
+}
+
+func (p *OIDCProvider) VerifyIDToken(discovery *OIDCDiscovery, rawIDToken, nonce string) (*OIDCClaims, error) {
+	claims := &OIDCClaims{}
+
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}))
+
+	_, err := parser.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return fetchJWK(discovery.JWKSURI, kid)
+	})
+	if err != nil {
+		return nil, exceptions.NewCustomError(http.StatusUnauthorized, "Invalid OIDC id_token")
+	}
+
+	if !claims.VerifyIssuer(p.Issuer, true) && !claims.VerifyIssuer(p.Issuer+"/", true) {
+		return nil, exceptions.NewCustomError(http.StatusUnauthorized, "Invalid OIDC id_token issuer")
+	}
+
+	if !claims.VerifyAudience(p.ClientID, true) {
+		return nil, exceptions.NewCustomError(http.StatusUnauthorized, "Invalid OIDC id_token audience")
+	}
+
+	if claims.ExpiresAt == nil {
+		return nil, exceptions.NewCustomError(http.StatusUnauthorized, "Invalid OIDC id_token expiry")
+	}
+
+	if claims.Nonce != nonce {
+		return nil, exceptions.NewCustomError(http.StatusUnauthorized, "Invalid OIDC id_token nonce")
+	}
+
+	return claims, nil
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// fetchJWK looks the signing key up by kid, refetching the key set once when
+// the kid is unknown so that provider key rotation is picked up.
+func fetchJWK(jwksURL, kid string) (any, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		keys, ok := oidcCacheGet("jwks:" + jwksURL)
+		if !ok || attempt > 0 {
+			var jwks struct {
+				Keys []jsonWebKey `json:"keys"`
+			}
+			if err := oidcGetJSON(jwksURL, &jwks); err != nil {
+				return nil, err
+			}
+			keys = jwks.Keys
+			oidcCacheSet("jwks:"+jwksURL, keys)
+		}
+
+		for _, key := range keys.([]jsonWebKey) {
+			if key.Use != "" && key.Use != "sig" {
+				continue
+			}
+			if kid == "" || key.Kid == kid {
+				return key.publicKey()
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("signing key %q not found", kid)
+}
+
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func RandomURLSafeString(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func PKCEChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func GenerateOIDCFlowToken(provider, state, nonce, codeVerifier string) (string, error) {
+	claims := &OIDCFlowClaims{
+		Provider:     provider,
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(10 * time.Minute)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(API_SECRET))
+}
+
+func ParseOIDCFlowToken(tokenString string) (*OIDCFlowClaims, error) {
+	claims := &OIDCFlowClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, exceptions.NewCustomError(http.StatusBadRequest, "Invalid or expired login session")
+		}
+		return []byte(API_SECRET), nil
+	})
+
+	if err != nil || !token.Valid {
+		return nil, exceptions.NewCustomError(http.StatusBadRequest, "Invalid or expired login session")
+	}
+
+	return claims, nil
+}